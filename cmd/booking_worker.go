@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/frahmantamala/jadiles/internal/payment"
+	paymentPostgresql "github.com/frahmantamala/jadiles/internal/payment/postgresql"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"github.com/frahmantamala/jadiles/internal/services/booking"
+	"github.com/frahmantamala/jadiles/internal/services/postgresql"
+	"github.com/frahmantamala/jadiles/internal/services/waitlist"
+	"github.com/spf13/cobra"
+)
+
+var bookingWorkerCmd = &cobra.Command{
+	RunE:  runBookingWorker,
+	Use:   "booking_worker",
+	Short: "to run background booking jobs (pending hold expiry, waitlist offers, refund retries)",
+}
+
+func runBookingWorker(_ *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	initLogger(cfg.Name, cfg)
+
+	dbConn, err := initDatabase(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		sqlDB, err := dbConn.DB()
+		if err != nil {
+			log.Printf("failed to get sql.DB for cleanup: %v", err)
+			return
+		}
+		if err := sqlDB.Close(); err != nil {
+			log.Printf("failed to close database connection: %v", err)
+		}
+	}()
+
+	repo := postgresql.NewRepository(dbConn)
+	expirer := booking.NewHoldExpirer(repo, services.NewHoldPolicy(cfg.Booking.Hold))
+	offers := waitlist.NewOfferProcessor(repo, services.NewWaitlistPolicy(cfg.Booking.Waitlist))
+
+	gateway, err := payment.NewGateway(cfg.Payment)
+	if err != nil {
+		log.Fatal(err)
+	}
+	paymentSvc := payment.NewService(paymentPostgresql.NewPaymentRepository(dbConn), gateway, cfg.Payment, services.NewHoldPolicy(cfg.Booking.Hold))
+	refunds := payment.NewRefundRetrier(paymentSvc, payment.NewRefundPolicy(cfg.Payment.Refund))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Println("booking worker is running")
+
+	jobs := []func(context.Context) error{expirer.Run, offers.Run, refunds.Run}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(jobs))
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(run func(context.Context) error) {
+			defer wg.Done()
+			if err := run(ctx); err != nil {
+				errs <- err
+				stop()
+			}
+		}(job)
+	}
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return fmt.Errorf("booking worker stopped: %w", err)
+	}
+
+	log.Println("booking worker stopped")
+	return nil
+}
@@ -41,6 +41,7 @@ func Execute() {
 
 func init() {
 	rootCmd.AddCommand(httpServerCmd)
+	rootCmd.AddCommand(bookingWorkerCmd)
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(versionCmd)
 }
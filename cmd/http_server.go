@@ -38,11 +38,11 @@ func runHTTPServer(_ *cobra.Command, _ []string) error {
 	defer func() {
 		sqlDB, err := dbConn.DB()
 		if err != nil {
-			fmt.Errorf("failed to get sql.DB for cleanup: %v", err)
+			log.Printf("failed to get sql.DB for cleanup: %v", err)
 			return
 		}
 		if err := sqlDB.Close(); err != nil {
-			fmt.Errorf("failed to close database connection: %v", err)
+			log.Printf("failed to close database connection: %v", err)
 		}
 	}()
 
@@ -0,0 +1,24 @@
+-- =====================================================
+-- Migration: 003_add_booking_cancellation_columns.sql
+-- Description: Add optimistic locking and refund tracking to bookings
+-- =====================================================
+-- +goose Up
+
+-- Add version column to bookings table for optimistic locking
+ALTER TABLE bookings ADD COLUMN version INTEGER DEFAULT 1 NOT NULL;
+
+-- Add refund amount computed from the cancellation policy
+ALTER TABLE bookings ADD COLUMN refund_amount DECIMAL(10,2);
+
+ALTER TABLE bookings ADD CONSTRAINT chk_bookings_cancelled_by
+    CHECK (cancelled_by IS NULL OR cancelled_by IN ('parent', 'vendor', 'system'));
+
+CREATE INDEX idx_bookings_version ON bookings(id, version);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_bookings_version;
+
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS chk_bookings_cancelled_by;
+ALTER TABLE bookings DROP COLUMN IF EXISTS refund_amount;
+ALTER TABLE bookings DROP COLUMN IF EXISTS version;
@@ -0,0 +1,47 @@
+-- =====================================================
+-- Migration: 004_add_session_reschedules.sql
+-- Description: Vendor reschedule policy and session reschedule history
+-- =====================================================
+-- +goose Up
+
+-- Vendor-configurable reschedule rules
+ALTER TABLE vendors ADD COLUMN reschedule_cutoff_hours INT DEFAULT 12 NOT NULL CHECK (reschedule_cutoff_hours >= 0);
+ALTER TABLE vendors ADD COLUMN max_reschedules_per_booking INT DEFAULT 2 NOT NULL CHECK (max_reschedules_per_booking >= 0);
+
+-- Sessions are taught by the coach of their slot, rescheduling moves the session to the new slot's coach
+ALTER TABLE booking_sessions ADD COLUMN coach_id BIGINT REFERENCES coaches(id) ON DELETE SET NULL;
+
+UPDATE booking_sessions bs
+SET coach_id = s.coach_id
+FROM schedules s
+WHERE s.id = bs.schedule_id AND bs.coach_id IS NULL;
+
+CREATE TABLE booking_session_reschedules (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL,
+    booking_session_id BIGINT NOT NULL,
+    from_schedule_id BIGINT,
+    from_session_date DATE NOT NULL,
+    to_schedule_id BIGINT,
+    to_session_date DATE NOT NULL,
+    rescheduled_by BIGINT NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+    FOREIGN KEY (booking_session_id) REFERENCES booking_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_schedule_id) REFERENCES schedules(id) ON DELETE SET NULL,
+    FOREIGN KEY (to_schedule_id) REFERENCES schedules(id) ON DELETE SET NULL,
+    FOREIGN KEY (rescheduled_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_booking_session_reschedules_booking_id ON booking_session_reschedules(booking_id);
+CREATE INDEX idx_booking_session_reschedules_session_id ON booking_session_reschedules(booking_session_id);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS booking_session_reschedules CASCADE;
+
+ALTER TABLE booking_sessions DROP COLUMN IF EXISTS coach_id;
+
+ALTER TABLE vendors DROP COLUMN IF EXISTS max_reschedules_per_booking;
+ALTER TABLE vendors DROP COLUMN IF EXISTS reschedule_cutoff_hours;
@@ -0,0 +1,13 @@
+-- =====================================================
+-- Migration: 005_add_booking_hold_index.sql
+-- Description: Support the pending-booking hold expiry sweep
+-- =====================================================
+-- +goose Up
+
+-- Partial index so the expiry worker only scans unpaid pending bookings
+CREATE INDEX idx_bookings_held ON bookings(created_at)
+    WHERE status = 'pending' AND payment_status = 'unpaid';
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_bookings_held;
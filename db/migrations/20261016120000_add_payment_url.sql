@@ -0,0 +1,16 @@
+-- =====================================================
+-- Migration: 006_add_payment_url.sql
+-- Description: Keep the gateway checkout URL so pending payments can be resumed
+-- =====================================================
+-- +goose Up
+
+ALTER TABLE payments ADD COLUMN payment_url TEXT;
+
+-- At most one pending payment per booking
+CREATE UNIQUE INDEX idx_payments_booking_pending ON payments(booking_id) WHERE status = 'pending';
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_payments_booking_pending;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS payment_url;
@@ -0,0 +1,28 @@
+-- =====================================================
+-- Migration: 007_add_payment_notifications.sql
+-- Description: Log gateway callbacks so retried notifications are processed once
+-- =====================================================
+-- +goose Up
+
+CREATE TABLE payment_notifications (
+    id BIGSERIAL PRIMARY KEY,
+    payment_id BIGINT NOT NULL,
+    transaction_id TEXT NOT NULL,
+    transaction_status TEXT NOT NULL,
+    status_code VARCHAR(10) NOT NULL,
+    gross_amount VARCHAR(30) NOT NULL,
+    payload JSONB NOT NULL,
+    applied BOOLEAN DEFAULT FALSE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE
+);
+
+-- A transaction reports each status once; retries of the same notification collide here
+CREATE UNIQUE INDEX idx_payment_notifications_dedupe ON payment_notifications(transaction_id, transaction_status);
+CREATE INDEX idx_payment_notifications_payment_id ON payment_notifications(payment_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_payment_notifications_payment_id;
+DROP INDEX IF EXISTS idx_payment_notifications_dedupe;
+DROP TABLE IF EXISTS payment_notifications;
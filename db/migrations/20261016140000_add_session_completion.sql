@@ -0,0 +1,14 @@
+-- =====================================================
+-- Migration: 008_add_session_completion.sql
+-- Description: Record who completed booking sessions and index sessions by coach
+-- =====================================================
+-- +goose Up
+
+ALTER TABLE booking_sessions ADD COLUMN completed_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_booking_sessions_coach_id ON booking_sessions(coach_id, session_date);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_booking_sessions_coach_id;
+ALTER TABLE booking_sessions DROP COLUMN IF EXISTS completed_by;
@@ -0,0 +1,36 @@
+-- =====================================================
+-- Migration: 009_add_booking_status_history.sql
+-- Description: Audit every booking status transition with its actor and reason
+-- =====================================================
+-- +goose Up
+
+CREATE TABLE booking_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL,
+    from_status TEXT CHECK (from_status IN ('pending', 'confirmed', 'ongoing', 'completed', 'cancelled')),
+    to_status TEXT NOT NULL CHECK (to_status IN ('pending', 'confirmed', 'ongoing', 'completed', 'cancelled')),
+    actor_type TEXT NOT NULL CHECK (actor_type IN ('parent', 'vendor', 'coach', 'admin', 'system')),
+    actor_id BIGINT,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_booking_status_history_booking_id ON booking_status_history(booking_id, created_at);
+
+-- Start the timeline of existing bookings with their creation
+INSERT INTO booking_status_history (booking_id, from_status, to_status, actor_type, actor_id, created_at)
+SELECT id, NULL, 'pending', 'parent', parent_id, created_at
+FROM bookings;
+
+-- Keep who cancelled existing bookings; the status they were cancelled from is unknown
+INSERT INTO booking_status_history (booking_id, from_status, to_status, actor_type, reason, created_at)
+SELECT id, NULL, 'cancelled', COALESCE(cancelled_by, 'system'), cancellation_reason, COALESCE(cancelled_at, updated_at)
+FROM bookings
+WHERE status = 'cancelled';
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_booking_status_history_booking_id;
+DROP TABLE IF EXISTS booking_status_history;
@@ -0,0 +1,39 @@
+-- =====================================================
+-- Migration: 010_add_waitlist_entries.sql
+-- Description: Waitlist for fully booked schedule slots with time-limited seat offers
+-- =====================================================
+-- +goose Up
+
+CREATE TABLE waitlist_entries (
+    id BIGSERIAL PRIMARY KEY,
+    schedule_id BIGINT NOT NULL,
+    session_date DATE NOT NULL,
+    parent_id BIGINT NOT NULL,
+    child_id BIGINT NOT NULL,
+    status TEXT DEFAULT 'waiting' NOT NULL CHECK (status IN ('waiting', 'offered', 'claimed', 'expired', 'cancelled')),
+    offered_at TIMESTAMP,
+    offer_expires_at TIMESTAMP,
+    claimed_booking_id BIGINT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (child_id) REFERENCES children(id) ON DELETE CASCADE,
+    FOREIGN KEY (claimed_booking_id) REFERENCES bookings(id) ON DELETE SET NULL
+);
+
+-- A child waits at most once per slot
+CREATE UNIQUE INDEX idx_waitlist_entries_active_child
+    ON waitlist_entries(schedule_id, session_date, child_id)
+    WHERE status IN ('waiting', 'offered');
+
+-- Queue order and offered seats of a slot
+CREATE INDEX idx_waitlist_entries_slot ON waitlist_entries(schedule_id, session_date, status, created_at);
+CREATE INDEX idx_waitlist_entries_parent_id ON waitlist_entries(parent_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_waitlist_entries_parent_id;
+DROP INDEX IF EXISTS idx_waitlist_entries_slot;
+DROP INDEX IF EXISTS idx_waitlist_entries_active_child;
+DROP TABLE IF EXISTS waitlist_entries;
@@ -0,0 +1,25 @@
+-- =====================================================
+-- Migration: 011_add_service_lifecycle.sql
+-- Description: Archived service status and optimistic locking for vendor-managed services
+-- =====================================================
+-- +goose Up
+
+-- Services with bookings are archived instead of deleted
+ALTER TABLE services DROP CONSTRAINT IF EXISTS services_status_check;
+ALTER TABLE services ADD CONSTRAINT services_status_check
+    CHECK (status IN ('active', 'inactive', 'draft', 'archived'));
+
+-- Add version column for optimistic locking
+ALTER TABLE services ADD COLUMN version INTEGER DEFAULT 1 NOT NULL;
+
+-- Vendor service listing
+CREATE INDEX idx_services_vendor_status ON services(vendor_id, status);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_services_vendor_status;
+ALTER TABLE services DROP COLUMN IF EXISTS version;
+UPDATE services SET status = 'inactive' WHERE status = 'archived';
+ALTER TABLE services DROP CONSTRAINT IF EXISTS services_status_check;
+ALTER TABLE services ADD CONSTRAINT services_status_check
+    CHECK (status IN ('active', 'inactive', 'draft'));
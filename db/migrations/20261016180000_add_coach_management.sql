@@ -0,0 +1,16 @@
+-- =====================================================
+-- Migration: 012_add_coach_management.sql
+-- Description: One coach profile per user account and a single primary coach per service
+-- =====================================================
+-- +goose Up
+
+-- Each coach profile has its own login
+ALTER TABLE coaches ADD CONSTRAINT coaches_user_id_key UNIQUE (user_id);
+
+-- At most one primary coach per service
+CREATE UNIQUE INDEX idx_service_coaches_primary ON service_coaches(service_id) WHERE is_primary;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_service_coaches_primary;
+ALTER TABLE coaches DROP CONSTRAINT IF EXISTS coaches_user_id_key;
@@ -0,0 +1,14 @@
+-- =====================================================
+-- Migration: 013_add_review_per_booking.sql
+-- Description: A booking can be reviewed once
+-- =====================================================
+-- +goose Up
+
+-- The unique constraint replaces the plain booking index
+DROP INDEX IF EXISTS idx_reviews_booking_id;
+ALTER TABLE reviews ADD CONSTRAINT reviews_booking_id_key UNIQUE (booking_id);
+
+-- +goose Down
+
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS reviews_booking_id_key;
+CREATE INDEX idx_reviews_booking_id ON reviews(booking_id);
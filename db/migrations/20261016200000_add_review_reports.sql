@@ -0,0 +1,36 @@
+-- =====================================================
+-- Migration: 014_add_review_reports.sql
+-- Description: Reports of reviews by parents and vendors, resolved by admins in a moderation queue
+-- =====================================================
+-- +goose Up
+
+CREATE TABLE review_reports (
+    id BIGSERIAL PRIMARY KEY,
+    review_id BIGINT NOT NULL,
+    reporter_id BIGINT NOT NULL,
+    reporter_role TEXT NOT NULL CHECK (reporter_role IN ('parent', 'vendor')),
+    reason TEXT NOT NULL,
+    status TEXT DEFAULT 'open' NOT NULL CHECK (status IN ('open', 'hidden', 'dismissed')),
+    resolved_by BIGINT,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+    FOREIGN KEY (reporter_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (resolved_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- A user reports a review at most once while it is awaiting moderation
+CREATE UNIQUE INDEX idx_review_reports_open_reporter
+    ON review_reports(review_id, reporter_id)
+    WHERE status = 'open';
+
+-- Moderation queue, oldest open report first
+CREATE INDEX idx_review_reports_status ON review_reports(status, created_at);
+CREATE INDEX idx_review_reports_review_id ON review_reports(review_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_review_reports_review_id;
+DROP INDEX IF EXISTS idx_review_reports_status;
+DROP INDEX IF EXISTS idx_review_reports_open_reporter;
+DROP TABLE IF EXISTS review_reports;
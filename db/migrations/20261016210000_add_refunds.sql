@@ -0,0 +1,32 @@
+-- =====================================================
+-- Migration: 015_add_refunds.sql
+-- Description: Queue refunds with the change that owes them so failed gateway refunds are retried
+-- =====================================================
+-- +goose Up
+
+CREATE TABLE refunds (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL,
+    payment_id BIGINT NOT NULL,
+    amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    status TEXT DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INT DEFAULT 0 NOT NULL,
+    next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_error TEXT,
+    processed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+    FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE
+);
+
+-- The refund worker picks up pending refunds once their next attempt is due
+CREATE INDEX idx_refunds_due ON refunds(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_refunds_booking_id ON refunds(booking_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_refunds_booking_id;
+DROP INDEX IF EXISTS idx_refunds_due;
+DROP TABLE IF EXISTS refunds;
@@ -0,0 +1,15 @@
+-- =====================================================
+-- Migration: 016_extend_booking_hold_index.sql
+-- Description: Holds also expire on bookings the vendor confirmed but the parent never paid
+-- =====================================================
+-- +goose Up
+
+DROP INDEX IF EXISTS idx_bookings_held;
+CREATE INDEX idx_bookings_held ON bookings(created_at)
+    WHERE status IN ('pending', 'confirmed') AND payment_status = 'unpaid';
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_bookings_held;
+CREATE INDEX idx_bookings_held ON bookings(created_at)
+    WHERE status = 'pending' AND payment_status = 'unpaid';
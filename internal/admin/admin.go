@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/user"
+)
+
+// ActionType is the kind of decision an admin took, as stored in admin_actions
+type ActionType string
+
+const (
+	ActionApproveVendor ActionType = "approve_vendor"
+	ActionRejectVendor  ActionType = "reject_vendor"
+	ActionSuspendVendor ActionType = "suspend_vendor"
+
+	ActionHideReview           ActionType = "hide_review"
+	ActionDismissReviewReports ActionType = "dismiss_review_reports"
+)
+
+// TargetType is the kind of record an admin action applies to
+type TargetType string
+
+const (
+	TargetVendor TargetType = "vendor"
+	TargetReview TargetType = "review"
+)
+
+// Action is one audited admin decision
+type Action struct {
+	ID         int64
+	AdminID    int64
+	ActionType ActionType
+	TargetType TargetType
+	TargetID   int64
+	Reason     *string
+	Metadata   map[string]interface{}
+	CreatedAt  time.Time
+}
+
+// vendorTransitions lists the vendor statuses each decision may be taken from.
+// Approving a suspended vendor reinstates them; rejected vendors have to register again.
+var vendorTransitions = map[ActionType][]user.VendorStatus{
+	ActionApproveVendor: {user.VendorStatusPending, user.VendorStatusSuspended},
+	ActionRejectVendor:  {user.VendorStatusPending},
+	ActionSuspendVendor: {user.VendorStatusActive},
+}
+
+// CanApplyTo checks if the decision may be taken on a vendor in the given status
+func (a ActionType) CanApplyTo(status user.VendorStatus) bool {
+	for _, allowed := range vendorTransitions[a] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationChannel is how a notification reaches its recipient
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "email"
+)
+
+// NotificationStatus tracks the delivery of a queued notification
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	NotificationFailed  NotificationStatus = "failed"
+)
+
+// Notification is a message queued for a user in the notifications table
+type Notification struct {
+	ID        int64
+	UserID    int64
+	Type      string
+	Channel   NotificationChannel
+	Recipient string
+	Subject   string
+	Message   string
+}
+
+// VendorDocument is a file a vendor submitted with their application
+type VendorDocument struct {
+	Type string // business_license, logo, cover_image, photo
+	URL  string
+}
+
+// VendorApplication is a vendor as reviewed by an admin: the business, its owner and its documents
+type VendorApplication struct {
+	Vendor     *user.Vendor
+	OwnerName  string
+	OwnerEmail string
+	OwnerPhone string
+	Documents  []VendorDocument
+}
+
+// VendorFilter narrows the admin vendor list
+type VendorFilter struct {
+	Status user.VendorStatus
+	Page   int
+	Limit  int
+}
+
+// VendorDecision is a vendor status change together with its audit record and the vendor's notification.
+// From is the status the decision was taken on, so a concurrent decision is detected.
+type VendorDecision struct {
+	Vendor       *user.Vendor
+	From         user.VendorStatus
+	Action       *Action
+	Notification *Notification
+}
+
+// NewVendorNotification builds the message telling a vendor's owner about a decision
+func NewVendorNotification(application *VendorApplication, action ActionType) *Notification {
+	vendor := application.Vendor
+	notification := &Notification{
+		UserID:    vendor.UserID,
+		Type:      string(action),
+		Channel:   ChannelEmail,
+		Recipient: application.OwnerEmail,
+	}
+
+	switch action {
+	case ActionApproveVendor:
+		notification.Subject = "Your vendor account has been approved"
+		notification.Message = fmt.Sprintf("Hi %s, %s has been approved. You can now publish services and accept bookings.",
+			application.OwnerName, vendor.BusinessName)
+	case ActionRejectVendor:
+		notification.Subject = "Your vendor application was not approved"
+		notification.Message = fmt.Sprintf("Hi %s, we could not approve %s. Reason: %s",
+			application.OwnerName, vendor.BusinessName, vendor.RejectionReason)
+	case ActionSuspendVendor:
+		notification.Subject = "Your vendor account has been suspended"
+		notification.Message = fmt.Sprintf("Hi %s, %s has been suspended and its services are hidden from parents. Reason: %s",
+			application.OwnerName, vendor.BusinessName, vendor.RejectionReason)
+	}
+
+	return notification
+}
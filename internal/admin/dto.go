@@ -0,0 +1,563 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/common"
+	"github.com/frahmantamala/jadiles/internal/user"
+	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+)
+
+const (
+	defaultVendorListLimit = 20
+	maxVendorListLimit     = 100
+)
+
+// ListVendorsParams represents the query parameters for the admin vendor list
+type ListVendorsParams struct {
+	Status user.VendorStatus
+	Page   int
+	Limit  int
+}
+
+// NewListVendorsParams parses the admin vendor list query parameters.
+// Without a status the list shows the applications awaiting approval.
+func NewListVendorsParams(r *http.Request) (*ListVendorsParams, error) {
+	query := r.URL.Query()
+
+	params := &ListVendorsParams{
+		Status: user.VendorStatusPending,
+		Page:   1,
+		Limit:  defaultVendorListLimit,
+	}
+
+	if status := query.Get("status"); status != "" {
+		params.Status = user.VendorStatus(status)
+	}
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil {
+			return nil, internal.NewValidationError("page must be a valid integer")
+		}
+		params.Page = page
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, internal.NewValidationError("limit must be a valid integer")
+		}
+		params.Limit = limit
+	}
+
+	return params, nil
+}
+
+// Validate validates the admin vendor list parameters
+func (p *ListVendorsParams) Validate(ctx context.Context) error {
+	vendor := &user.Vendor{Status: p.Status}
+	if err := vendor.ValidateStatus(); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+
+	if p.Page < 1 {
+		return internal.NewValidationError("page must be at least 1")
+	}
+
+	if p.Limit < 1 || p.Limit > maxVendorListLimit {
+		return internal.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", maxVendorListLimit))
+	}
+
+	return nil
+}
+
+// ToVendorFilter converts the query parameters to a repository filter
+func (p *ListVendorsParams) ToVendorFilter() *VendorFilter {
+	return &VendorFilter{
+		Status: p.Status,
+		Page:   p.Page,
+		Limit:  p.Limit,
+	}
+}
+
+// VendorReasonParams represents the HTTP request body for rejecting or suspending a vendor
+type VendorReasonParams struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+// NewVendorReasonParams parses a vendor rejection or suspension request
+func NewVendorReasonParams(r *http.Request) (*VendorReasonParams, error) {
+	var params VendorReasonParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the reason parameters
+func (p *VendorReasonParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// VendorOwnerDTO is the user account that registered the vendor
+type VendorOwnerDTO struct {
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone"`
+}
+
+// VendorDocumentDTO is a file submitted with a vendor application
+type VendorDocumentDTO struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// VendorApplicationDTO is a vendor as shown to admins
+type VendorApplicationDTO struct {
+	v1.Vendor
+	RejectionReason *string             `json:"rejection_reason,omitempty"`
+	VerifiedAt      *time.Time          `json:"verified_at,omitempty"`
+	CreatedAt       time.Time           `json:"created_at"`
+	User            VendorOwnerDTO      `json:"user"`
+	Documents       []VendorDocumentDTO `json:"documents"`
+}
+
+// VendorsResponse is a page of vendors for admins
+type VendorsResponse struct {
+	Data struct {
+		Vendors    []VendorApplicationDTO `json:"vendors"`
+		Pagination v1.Pagination          `json:"pagination"`
+	} `json:"data"`
+}
+
+// VendorResponse is the outcome of an admin decision on a vendor
+type VendorResponse struct {
+	Message string               `json:"message"`
+	Data    VendorApplicationDTO `json:"data"`
+}
+
+// ToVendorsResponse converts a page of vendor applications to response
+func ToVendorsResponse(applications []*VendorApplication, page, limit int, total int64) *VendorsResponse {
+	resp := &VendorsResponse{}
+	resp.Data.Vendors = make([]VendorApplicationDTO, 0, len(applications))
+	for _, application := range applications {
+		resp.Data.Vendors = append(resp.Data.Vendors, ToVendorApplicationDTO(application))
+	}
+
+	totalCount := int(total)
+	totalPages := (totalCount + limit - 1) / limit
+	resp.Data.Pagination = v1.Pagination{
+		Page:       &page,
+		Limit:      &limit,
+		Total:      &totalCount,
+		TotalPages: &totalPages,
+	}
+
+	return resp
+}
+
+// ToVendorResponse converts a decided vendor application to response
+func ToVendorResponse(application *VendorApplication, message string) *VendorResponse {
+	return &VendorResponse{
+		Message: message,
+		Data:    ToVendorApplicationDTO(application),
+	}
+}
+
+// ToVendorApplicationDTO converts a domain vendor application to DTO
+func ToVendorApplicationDTO(application *VendorApplication) VendorApplicationDTO {
+	v := application.Vendor
+
+	status := v1.VendorStatus(v.Status)
+	businessType := v1.BusinessType(v.BusinessType)
+	dto := VendorApplicationDTO{
+		Vendor: v1.Vendor{
+			Id:            &v.ID,
+			BusinessName:  &v.BusinessName,
+			BusinessType:  &businessType,
+			Description:   optionalString(v.Description),
+			Phone:         &v.Phone,
+			Whatsapp:      optionalString(v.Whatsapp),
+			Address:       &v.Address,
+			City:          &v.City,
+			District:      optionalString(v.District),
+			Logo:          optionalString(v.Logo),
+			CoverImage:    optionalString(v.CoverImage),
+			Photos:        &v.Photos,
+			Amenities:     &v.Amenities,
+			Status:        &status,
+			RatingAvg:     &v.RatingAvg,
+			TotalReviews:  &v.TotalReviews,
+			TotalBookings: &v.TotalBookings,
+			Verified:      &v.Verified,
+		},
+		RejectionReason: optionalString(v.RejectionReason),
+		VerifiedAt:      v.VerifiedAt,
+		CreatedAt:       v.CreatedAt,
+		User: VendorOwnerDTO{
+			FullName: application.OwnerName,
+			Email:    application.OwnerEmail,
+			Phone:    application.OwnerPhone,
+		},
+		Documents: make([]VendorDocumentDTO, 0, len(application.Documents)),
+	}
+
+	for _, document := range application.Documents {
+		dto.Documents = append(dto.Documents, VendorDocumentDTO{
+			Type: document.Type,
+			URL:  document.URL,
+		})
+	}
+
+	return dto
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// defaultStatisticsDays is the window shown when no range is given, ending today
+const defaultStatisticsDays = 30
+
+// GetStatisticsParams represents the query parameters for the platform statistics
+type GetStatisticsParams struct {
+	From        time.Time
+	To          time.Time
+	Granularity Granularity
+}
+
+// NewGetStatisticsParams parses the statistics query parameters.
+// Dates are YYYY-MM-DD and inclusive; the default is the last 30 days by day.
+func NewGetStatisticsParams(r *http.Request, now time.Time) (*GetStatisticsParams, error) {
+	query := r.URL.Query()
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	params := &GetStatisticsParams{
+		To:          today,
+		Granularity: GranularityDay,
+	}
+
+	if toStr := query.Get("to"); toStr != "" {
+		to, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return nil, internal.NewValidationError("to must be in YYYY-MM-DD format")
+		}
+		params.To = to
+	}
+
+	params.From = params.To.AddDate(0, 0, -(defaultStatisticsDays - 1))
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return nil, internal.NewValidationError("from must be in YYYY-MM-DD format")
+		}
+		params.From = from
+	}
+
+	if granularity := query.Get("granularity"); granularity != "" {
+		params.Granularity = Granularity(granularity)
+	}
+
+	return params, nil
+}
+
+// Validate validates the statistics parameters
+func (p *GetStatisticsParams) Validate(ctx context.Context) error {
+	if err := p.ToStatisticsFilter().Validate(); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+	return nil
+}
+
+// ToStatisticsFilter converts the query parameters to a repository filter
+func (p *GetStatisticsParams) ToStatisticsFilter() *StatisticsFilter {
+	return &StatisticsFilter{
+		From:        p.From,
+		To:          p.To,
+		Granularity: p.Granularity,
+	}
+}
+
+// StatisticsResponse is the platform statistics of a window
+type StatisticsResponse struct {
+	Data StatisticsData `json:"data"`
+}
+
+// StatisticsData is the body of the statistics response
+type StatisticsData struct {
+	From             string                 `json:"from"`
+	To               string                 `json:"to"`
+	Granularity      Granularity            `json:"granularity"`
+	Summary          StatisticsSummaryDTO   `json:"summary"`
+	BookingsByStatus map[string]int         `json:"bookings_by_status"`
+	TopCategories    []CategoryStatisticDTO `json:"top_categories"`
+	Series           []StatisticsBucketDTO  `json:"series"`
+}
+
+// StatisticsSummaryDTO totals the window
+type StatisticsSummaryDTO struct {
+	GMV               float64 `json:"gmv"`
+	TotalBookings     int     `json:"total_bookings"`
+	CancelledBookings int     `json:"cancelled_bookings"`
+	CancellationRate  float64 `json:"cancellation_rate"`
+	NewParents        int     `json:"new_parents"`
+	NewVendors        int     `json:"new_vendors"`
+	AverageRating     float64 `json:"average_rating"`
+	TotalReviews      int     `json:"total_reviews"`
+}
+
+// CategoryStatisticDTO is a ranked category
+type CategoryStatisticDTO struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Slug       string  `json:"slug"`
+	Bookings   int     `json:"bookings"`
+	GMV        float64 `json:"gmv"`
+	Percentage float64 `json:"percentage"`
+}
+
+// StatisticsBucketDTO is one point of the time series
+type StatisticsBucketDTO struct {
+	Start             string  `json:"start"`
+	GMV               float64 `json:"gmv"`
+	Bookings          int     `json:"bookings"`
+	CancelledBookings int     `json:"cancelled_bookings"`
+	NewParents        int     `json:"new_parents"`
+	NewVendors        int     `json:"new_vendors"`
+}
+
+// ToStatisticsResponse converts platform statistics to response
+func ToStatisticsResponse(stats *PlatformStatistics) *StatisticsResponse {
+	summary := stats.Summary
+	data := StatisticsData{
+		From:        stats.Filter.From.Format("2006-01-02"),
+		To:          stats.Filter.To.Format("2006-01-02"),
+		Granularity: stats.Filter.Granularity,
+		Summary: StatisticsSummaryDTO{
+			GMV:               summary.GMV,
+			TotalBookings:     summary.TotalBookings,
+			CancelledBookings: summary.CancelledBookings,
+			CancellationRate:  roundTwo(summary.CancellationRate),
+			NewParents:        summary.NewParents,
+			NewVendors:        summary.NewVendors,
+			AverageRating:     roundTwo(summary.AverageRating),
+			TotalReviews:      summary.TotalReviews,
+		},
+		BookingsByStatus: make(map[string]int, len(stats.BookingsByStatus)),
+		TopCategories:    make([]CategoryStatisticDTO, 0, len(stats.TopCategories)),
+		Series:           make([]StatisticsBucketDTO, 0, len(stats.Series)),
+	}
+
+	for _, status := range stats.BookingsByStatus {
+		data.BookingsByStatus[status.Status] = status.Count
+	}
+
+	for _, category := range stats.TopCategories {
+		data.TopCategories = append(data.TopCategories, CategoryStatisticDTO{
+			ID:         category.CategoryID,
+			Name:       category.Name,
+			Slug:       category.Slug,
+			Bookings:   category.Bookings,
+			GMV:        category.GMV,
+			Percentage: roundTwo(category.Percentage),
+		})
+	}
+
+	for _, bucket := range stats.Series {
+		data.Series = append(data.Series, StatisticsBucketDTO{
+			Start:             bucket.Start.Format("2006-01-02"),
+			GMV:               bucket.GMV,
+			Bookings:          bucket.Bookings,
+			CancelledBookings: bucket.CancelledBookings,
+			NewParents:        bucket.NewParents,
+			NewVendors:        bucket.NewVendors,
+		})
+	}
+
+	return &StatisticsResponse{Data: data}
+}
+
+func roundTwo(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// ListReportedReviewsParams represents the query parameters for the review moderation queue
+type ListReportedReviewsParams struct {
+	Page  int
+	Limit int
+}
+
+// NewListReportedReviewsParams parses the moderation queue query parameters
+func NewListReportedReviewsParams(r *http.Request) (*ListReportedReviewsParams, error) {
+	query := r.URL.Query()
+
+	params := &ListReportedReviewsParams{
+		Page:  1,
+		Limit: defaultVendorListLimit,
+	}
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil {
+			return nil, internal.NewValidationError("page must be a valid integer")
+		}
+		params.Page = page
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, internal.NewValidationError("limit must be a valid integer")
+		}
+		params.Limit = limit
+	}
+
+	return params, nil
+}
+
+// Validate validates the moderation queue parameters
+func (p *ListReportedReviewsParams) Validate(ctx context.Context) error {
+	if p.Page < 1 {
+		return internal.NewValidationError("page must be at least 1")
+	}
+
+	if p.Limit < 1 || p.Limit > maxVendorListLimit {
+		return internal.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", maxVendorListLimit))
+	}
+
+	return nil
+}
+
+// ToReviewReportFilter converts the query parameters to a repository filter
+func (p *ListReportedReviewsParams) ToReviewReportFilter() *ReviewReportFilter {
+	return &ReviewReportFilter{
+		Page:  p.Page,
+		Limit: p.Limit,
+	}
+}
+
+// HideReviewParams represents the HTTP request body for hiding a review
+type HideReviewParams struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+// NewHideReviewParams parses a review hiding request
+func NewHideReviewParams(r *http.Request) (*HideReviewParams, error) {
+	var params HideReviewParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the hiding parameters
+func (p *HideReviewParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ReviewReportDTO is one report of a review as shown to admins
+type ReviewReportDTO struct {
+	ID           int64     `json:"id"`
+	ReporterID   int64     `json:"reporter_id"`
+	ReporterRole string    `json:"reporter_role"`
+	Reason       string    `json:"reason"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ReportedReviewDTO is a review in the moderation queue
+type ReportedReviewDTO struct {
+	ReviewID       int64             `json:"review_id"`
+	VendorID       int64             `json:"vendor_id"`
+	VendorName     string            `json:"vendor_name"`
+	ServiceID      int64             `json:"service_id"`
+	ServiceName    string            `json:"service_name"`
+	ParentID       int64             `json:"parent_id"`
+	Rating         int               `json:"rating"`
+	ReviewText     *string           `json:"review_text,omitempty"`
+	VendorResponse *string           `json:"vendor_response,omitempty"`
+	IsApproved     bool              `json:"is_approved"`
+	CreatedAt      time.Time         `json:"created_at"`
+	Reports        []ReviewReportDTO `json:"reports"`
+}
+
+// ReportedReviewsResponse is a page of the review moderation queue
+type ReportedReviewsResponse struct {
+	Data struct {
+		Reviews    []ReportedReviewDTO `json:"reviews"`
+		Pagination v1.Pagination       `json:"pagination"`
+	} `json:"data"`
+}
+
+// ReviewModerationResponse is the outcome of an admin decision on a review
+type ReviewModerationResponse struct {
+	Message string `json:"message"`
+	Data    struct {
+		ReviewID     int64  `json:"review_id"`
+		IsApproved   bool   `json:"is_approved"`
+		ReportStatus string `json:"report_status"`
+	} `json:"data"`
+}
+
+// ToReportedReviewsResponse converts a page of the moderation queue to response
+func ToReportedReviewsResponse(reviews []*ReportedReview, page, limit int, total int64) *ReportedReviewsResponse {
+	resp := &ReportedReviewsResponse{}
+	resp.Data.Reviews = make([]ReportedReviewDTO, 0, len(reviews))
+	for _, review := range reviews {
+		reports := make([]ReviewReportDTO, 0, len(review.Reports))
+		for _, report := range review.Reports {
+			reports = append(reports, ReviewReportDTO{
+				ID:           report.ID,
+				ReporterID:   report.ReporterID,
+				ReporterRole: report.ReporterRole,
+				Reason:       report.Reason,
+				CreatedAt:    report.CreatedAt,
+			})
+		}
+
+		resp.Data.Reviews = append(resp.Data.Reviews, ReportedReviewDTO{
+			ReviewID:       review.ReviewID,
+			VendorID:       review.VendorID,
+			VendorName:     review.VendorName,
+			ServiceID:      review.ServiceID,
+			ServiceName:    review.ServiceName,
+			ParentID:       review.ParentID,
+			Rating:         review.Rating,
+			ReviewText:     review.ReviewText,
+			VendorResponse: review.VendorResponse,
+			IsApproved:     review.IsApproved,
+			CreatedAt:      review.CreatedAt,
+			Reports:        reports,
+		})
+	}
+
+	totalCount := int(total)
+	totalPages := (totalCount + limit - 1) / limit
+	resp.Data.Pagination = v1.Pagination{
+		Page:       &page,
+		Limit:      &limit,
+		Total:      &totalCount,
+		TotalPages: &totalPages,
+	}
+
+	return resp
+}
+
+// ToReviewModerationResponse converts a moderation decision to response
+func ToReviewModerationResponse(moderation *ReviewModeration, message string) *ReviewModerationResponse {
+	resp := &ReviewModerationResponse{Message: message}
+	resp.Data.ReviewID = moderation.ReviewID
+	resp.Data.IsApproved = !moderation.Hide
+	resp.Data.ReportStatus = string(moderation.ReportStatus())
+	return resp
+}
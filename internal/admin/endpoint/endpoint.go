@@ -0,0 +1,42 @@
+package endpoint
+
+import (
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/admin"
+	"github.com/frahmantamala/jadiles/internal/admin/postgresql"
+	authpkg "github.com/frahmantamala/jadiles/internal/auth"
+	"github.com/frahmantamala/jadiles/internal/notification"
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// RegisterAdminRoutes registers all admin back office routes
+func RegisterAdminRoutes(
+	r chi.Router,
+	db *gorm.DB,
+	jwtAuth *authpkg.JWTAuthentication,
+	config internal.Config,
+) error {
+	sender, err := notification.NewSender(config.Notification)
+	if err != nil {
+		return err
+	}
+
+	repo := postgresql.NewAdminRepository(db)
+	adminService := admin.NewService(repo, sender)
+	adminHandler := admin.NewHandler(adminService)
+
+	r.Group(func(r chi.Router) {
+		r.Use(jwtAuth.Authenticator)
+		r.Use(jwtAuth.RequireRole("admin"))
+
+		r.Get("/admin/vendors", adminHandler.ListVendors)
+		r.Post("/admin/vendors/{id}/approve", adminHandler.ApproveVendor)
+		r.Post("/admin/vendors/{id}/reject", adminHandler.RejectVendor)
+		r.Post("/admin/vendors/{id}/suspend", adminHandler.SuspendVendor)
+
+		r.Get("/admin/statistics", adminHandler.GetStatistics)
+	})
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+type ServiceAPI interface {
+	ListVendors(ctx context.Context, params *ListVendorsParams) (*VendorsResponse, error)
+	ApproveVendor(ctx context.Context, adminID, vendorID int64) (*VendorResponse, error)
+	RejectVendor(ctx context.Context, adminID, vendorID int64, params *VendorReasonParams) (*VendorResponse, error)
+	SuspendVendor(ctx context.Context, adminID, vendorID int64, params *VendorReasonParams) (*VendorResponse, error)
+	GetStatistics(ctx context.Context, params *GetStatisticsParams) (*StatisticsResponse, error)
+	ListReportedReviews(ctx context.Context, params *ListReportedReviewsParams) (*ReportedReviewsResponse, error)
+	HideReview(ctx context.Context, adminID, reviewID int64, params *HideReviewParams) (*ReviewModerationResponse, error)
+	DismissReviewReports(ctx context.Context, adminID, reviewID int64) (*ReviewModerationResponse, error)
+}
+
+type Handler struct {
+	service ServiceAPI
+}
+
+func NewHandler(service ServiceAPI) *Handler {
+	return &Handler{service: service}
+}
+
+// ListVendors handles GET /admin/vendors
+func (h *Handler) ListVendors(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params, err := NewListVendorsParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.ListVendors(ctx, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ApproveVendor handles POST /admin/vendors/{id}/approve
+func (h *Handler) ApproveVendor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminID, vendorID, err := parseVendorDecisionRequest(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.ApproveVendor(ctx, adminID, vendorID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// RejectVendor handles POST /admin/vendors/{id}/reject
+func (h *Handler) RejectVendor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminID, vendorID, err := parseVendorDecisionRequest(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	params, err := NewVendorReasonParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.RejectVendor(ctx, adminID, vendorID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// SuspendVendor handles POST /admin/vendors/{id}/suspend
+func (h *Handler) SuspendVendor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminID, vendorID, err := parseVendorDecisionRequest(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	params, err := NewVendorReasonParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.SuspendVendor(ctx, adminID, vendorID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// GetStatistics handles GET /admin/statistics
+func (h *Handler) GetStatistics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params, err := NewGetStatisticsParams(r, time.Now())
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.GetStatistics(ctx, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ListReportedReviews handles GET /admin/reviews/reports
+func (h *Handler) ListReportedReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params, err := NewListReportedReviewsParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.ListReportedReviews(ctx, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// HideReview handles POST /admin/reviews/{review_id}/hide
+func (h *Handler) HideReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminID, reviewID, err := parseReviewModerationRequest(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	params, err := NewHideReviewParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.HideReview(ctx, adminID, reviewID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// DismissReviewReports handles POST /admin/reviews/{review_id}/dismiss
+func (h *Handler) DismissReviewReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	adminID, reviewID, err := parseReviewModerationRequest(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.DismissReviewReports(ctx, adminID, reviewID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// parseVendorDecisionRequest extracts the acting admin and the vendor ID from the URL
+func parseVendorDecisionRequest(r *http.Request) (int64, int64, error) {
+	adminID, err := internal.ExtractUserID(r.Context())
+	if err != nil {
+		return 0, 0, internal.NewUnauthorizedError("Authentication required")
+	}
+
+	vendorID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return 0, 0, internal.NewValidationError("id must be a valid integer")
+	}
+
+	return adminID, vendorID, nil
+}
+
+// parseReviewModerationRequest extracts the acting admin and the review ID from the URL
+func parseReviewModerationRequest(r *http.Request) (int64, int64, error) {
+	adminID, err := internal.ExtractUserID(r.Context())
+	if err != nil {
+		return 0, 0, internal.NewUnauthorizedError("Authentication required")
+	}
+
+	reviewID, err := strconv.ParseInt(chi.URLParam(r, "review_id"), 10, 64)
+	if err != nil {
+		return 0, 0, internal.NewValidationError("review_id must be a valid integer")
+	}
+
+	return adminID, reviewID, nil
+}
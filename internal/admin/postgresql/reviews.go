@@ -0,0 +1,203 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/admin"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	userPostgresql "github.com/frahmantamala/jadiles/internal/user/postgresql"
+	"gorm.io/gorm"
+)
+
+// ReportedReviewData represents a reported review joined with its vendor and service
+type ReportedReviewData struct {
+	ReviewID       int64
+	VendorID       int64
+	VendorName     string
+	ServiceID      int64
+	ServiceName    string
+	ParentID       int64
+	Rating         int
+	ReviewText     *string
+	VendorResponse *string
+	IsApproved     bool
+	CreatedAt      time.Time
+}
+
+// ReviewReportData represents an open report row
+type ReviewReportData struct {
+	ID           int64
+	ReviewID     int64
+	ReporterID   int64
+	ReporterRole string
+	Reason       string
+	CreatedAt    time.Time
+}
+
+// ListReportedReviews lists reviews with open reports, the longest waiting report first
+func (r *Repository) ListReportedReviews(ctx context.Context, filter *admin.ReviewReportFilter) ([]*admin.ReportedReview, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(DISTINCT review_id) FROM review_reports WHERE status = 'open'
+	`).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []*ReportedReviewData
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			r.id AS review_id, r.vendor_id, v.business_name AS vendor_name,
+			r.service_id, s.name AS service_name, r.parent_id, r.rating,
+			r.review_text, r.vendor_response, r.is_approved, r.created_at
+		FROM reviews r
+		INNER JOIN (
+			SELECT review_id, MIN(created_at) AS first_reported_at
+			FROM review_reports
+			WHERE status = 'open'
+			GROUP BY review_id
+		) q ON q.review_id = r.id
+		INNER JOIN vendors v ON v.id = r.vendor_id
+		INNER JOIN services s ON s.id = r.service_id
+		ORDER BY q.first_reported_at ASC, r.id ASC
+		LIMIT ? OFFSET ?
+	`, filter.Limit, (filter.Page-1)*filter.Limit).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(rows) == 0 {
+		return []*admin.ReportedReview{}, total, nil
+	}
+
+	reviewIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		reviewIDs = append(reviewIDs, row.ReviewID)
+	}
+
+	var reports []*ReviewReportData
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT id, review_id, reporter_id, reporter_role, reason, created_at
+		FROM review_reports
+		WHERE review_id IN ? AND status = 'open'
+		ORDER BY created_at ASC, id ASC
+	`, reviewIDs).Scan(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+
+	reportsByReview := make(map[int64][]admin.ReviewReport, len(rows))
+	for _, report := range reports {
+		reportsByReview[report.ReviewID] = append(reportsByReview[report.ReviewID], admin.ReviewReport{
+			ID:           report.ID,
+			ReporterID:   report.ReporterID,
+			ReporterRole: report.ReporterRole,
+			Reason:       report.Reason,
+			CreatedAt:    report.CreatedAt,
+		})
+	}
+
+	reviews := make([]*admin.ReportedReview, 0, len(rows))
+	for _, row := range rows {
+		reviews = append(reviews, &admin.ReportedReview{
+			ReviewID:       row.ReviewID,
+			VendorID:       row.VendorID,
+			VendorName:     row.VendorName,
+			ServiceID:      row.ServiceID,
+			ServiceName:    row.ServiceName,
+			ParentID:       row.ParentID,
+			Rating:         row.Rating,
+			ReviewText:     row.ReviewText,
+			VendorResponse: row.VendorResponse,
+			IsApproved:     row.IsApproved,
+			CreatedAt:      row.CreatedAt,
+			Reports:        reportsByReview[row.ReviewID],
+		})
+	}
+
+	return reviews, total, nil
+}
+
+// ModerateReview applies an admin's decision on a review in one transaction. Hiding a review
+// recomputes the vendor's rating from its remaining published reviews, the open reports are
+// resolved and the decision is audited in admin_actions.
+func (r *Repository) ModerateReview(ctx context.Context, moderation *admin.ReviewModeration) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		action := moderation.Action
+
+		// 1. Lock the review
+		var review struct {
+			ID         int64
+			VendorID   int64
+			IsApproved bool
+		}
+		if err := tx.Raw(`
+			SELECT id, vendor_id, is_approved FROM reviews WHERE id = ? FOR UPDATE
+		`, moderation.ReviewID).Scan(&review).Error; err != nil {
+			return err
+		}
+		if review.ID == 0 {
+			return internal.NewNotFoundError("Review")
+		}
+
+		// 2. Unpublish it and take it out of the vendor's rating
+		if moderation.Hide {
+			if !review.IsApproved {
+				return internal.NewBusinessRuleError("Review is already hidden", internal.ErrInvalidState)
+			}
+
+			if err := tx.Model(&datamodel.Review{}).
+				Where("id = ?", review.ID).
+				Updates(map[string]interface{}{
+					"is_approved": false,
+					"updated_at":  action.CreatedAt,
+				}).Error; err != nil {
+				return err
+			}
+
+			if err := userPostgresql.RecomputeVendorRating(tx, review.VendorID, action.CreatedAt); err != nil {
+				return err
+			}
+		}
+
+		// 3. Resolve the open reports
+		resolved := tx.Exec(`
+			UPDATE review_reports
+			SET status = ?, resolved_by = ?, resolved_at = ?
+			WHERE review_id = ? AND status = 'open'
+		`, string(moderation.ReportStatus()), action.AdminID, action.CreatedAt, review.ID)
+		if resolved.Error != nil {
+			return resolved.Error
+		}
+		if !moderation.Hide && resolved.RowsAffected == 0 {
+			return internal.NewBusinessRuleError("Review has no open reports", internal.ErrInvalidState)
+		}
+
+		// 4. Audit the decision
+		action.TargetID = review.ID
+		action.Metadata["vendor_id"] = review.VendorID
+		action.Metadata["resolved_reports"] = resolved.RowsAffected
+
+		metadata, err := json.Marshal(action.Metadata)
+		if err != nil {
+			return err
+		}
+		metadataStr := string(metadata)
+
+		actionDM := &datamodel.AdminAction{
+			AdminID:    action.AdminID,
+			ActionType: string(action.ActionType),
+			TargetType: string(action.TargetType),
+			TargetID:   action.TargetID,
+			Reason:     action.Reason,
+			Metadata:   &metadataStr,
+			CreatedAt:  action.CreatedAt,
+		}
+		if err := tx.Create(actionDM).Error; err != nil {
+			return err
+		}
+		action.ID = actionDM.ID
+
+		return nil
+	})
+}
@@ -0,0 +1,117 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/frahmantamala/jadiles/internal/admin"
+	"gorm.io/gorm"
+)
+
+// GetPlatformStatistics computes the platform statistics of a window in a few set-based queries.
+// All queries run in one read-only transaction so the series, breakdowns and ratings agree.
+func (r *Repository) GetPlatformStatistics(ctx context.Context, filter *admin.StatisticsFilter) (*admin.PlatformStatistics, error) {
+	stats := &admin.PlatformStatistics{Filter: *filter}
+
+	from := filter.From.Format("2006-01-02")
+	end := filter.End().Format("2006-01-02")
+	unit := string(filter.Granularity)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY").Error; err != nil {
+			return err
+		}
+
+		// 1. Time series, one row per bucket including empty ones
+		if err := tx.Raw(`
+			WITH buckets AS (
+				SELECT generate_series(
+					date_trunc(@unit, @from::timestamp),
+					date_trunc(@unit, @end::timestamp - INTERVAL '1 day'),
+					@interval::interval
+				) AS start
+			),
+			booking_buckets AS (
+				SELECT
+					date_trunc(@unit, created_at) AS start,
+					COUNT(*) AS bookings,
+					COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_bookings,
+					COALESCE(SUM(total_amount) FILTER (WHERE payment_status = 'paid'), 0) AS gmv
+				FROM bookings
+				WHERE created_at >= @from AND created_at < @end
+				GROUP BY 1
+			),
+			parent_buckets AS (
+				SELECT date_trunc(@unit, created_at) AS start, COUNT(*) AS new_parents
+				FROM users
+				WHERE role = 'parent' AND created_at >= @from AND created_at < @end
+				GROUP BY 1
+			),
+			vendor_buckets AS (
+				SELECT date_trunc(@unit, created_at) AS start, COUNT(*) AS new_vendors
+				FROM vendors
+				WHERE created_at >= @from AND created_at < @end
+				GROUP BY 1
+			)
+			SELECT
+				b.start,
+				COALESCE(bb.gmv, 0) AS gmv,
+				COALESCE(bb.bookings, 0) AS bookings,
+				COALESCE(bb.cancelled_bookings, 0) AS cancelled_bookings,
+				COALESCE(pb.new_parents, 0) AS new_parents,
+				COALESCE(vb.new_vendors, 0) AS new_vendors
+			FROM buckets b
+			LEFT JOIN booking_buckets bb ON bb.start = b.start
+			LEFT JOIN parent_buckets pb ON pb.start = b.start
+			LEFT JOIN vendor_buckets vb ON vb.start = b.start
+			ORDER BY b.start ASC
+		`, map[string]interface{}{
+			"unit":     unit,
+			"from":     from,
+			"end":      end,
+			"interval": filter.Granularity.Interval(),
+		}).Scan(&stats.Series).Error; err != nil {
+			return err
+		}
+
+		// 2. Bookings placed in the window by their current status
+		if err := tx.Raw(`
+			SELECT status, COUNT(*) AS count
+			FROM bookings
+			WHERE created_at >= ? AND created_at < ?
+			GROUP BY status
+			ORDER BY count DESC, status ASC
+		`, from, end).Scan(&stats.BookingsByStatus).Error; err != nil {
+			return err
+		}
+
+		// 3. Categories ranked by non-cancelled bookings
+		if err := tx.Raw(`
+			SELECT
+				sc.id AS category_id, sc.name, sc.slug,
+				COUNT(b.id) AS bookings,
+				COALESCE(SUM(b.total_amount) FILTER (WHERE b.payment_status = 'paid'), 0) AS gmv
+			FROM bookings b
+			INNER JOIN services s ON s.id = b.service_id
+			INNER JOIN service_categories sc ON sc.id = s.category_id
+			WHERE b.created_at >= ? AND b.created_at < ? AND b.status <> 'cancelled'
+			GROUP BY sc.id, sc.name, sc.slug
+			ORDER BY bookings DESC, gmv DESC, sc.name ASC
+			LIMIT ?
+		`, from, end, admin.TopCategoriesLimit).Scan(&stats.TopCategories).Error; err != nil {
+			return err
+		}
+
+		// 4. Ratings of the published reviews written in the window
+		return tx.Raw(`
+			SELECT COALESCE(AVG(rating), 0) AS average_rating, COUNT(*) AS total_reviews
+			FROM reviews
+			WHERE is_approved = true AND created_at >= ? AND created_at < ?
+		`, from, end).Row().Scan(&stats.Summary.AverageRating, &stats.Summary.TotalReviews)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Summarize()
+	return stats, nil
+}
@@ -0,0 +1,253 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/admin"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/user"
+	"gorm.io/gorm"
+)
+
+// VendorApplicationData represents a vendor row joined with its owner
+type VendorApplicationData struct {
+	datamodel.Vendor
+	OwnerName  string
+	OwnerEmail string
+	OwnerPhone string
+}
+
+const vendorApplicationSelect = `
+	SELECT v.*, u.full_name AS owner_name, u.email AS owner_email, u.phone AS owner_phone
+	FROM vendors v
+	INNER JOIN users u ON u.id = v.user_id
+`
+
+// ListVendors lists vendors in a status, oldest application first so none are left waiting
+func (r *Repository) ListVendors(ctx context.Context, filter *admin.VendorFilter) ([]*admin.VendorApplication, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).
+		Model(&datamodel.Vendor{}).
+		Where("status = ?", string(filter.Status)).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []*VendorApplicationData
+	if err := r.db.WithContext(ctx).Raw(vendorApplicationSelect+`
+		WHERE v.status = ?
+		ORDER BY v.created_at ASC, v.id ASC
+		LIMIT ? OFFSET ?
+	`, string(filter.Status), filter.Limit, (filter.Page-1)*filter.Limit).Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	applications := make([]*admin.VendorApplication, 0, len(rows))
+	for _, row := range rows {
+		applications = append(applications, toVendorApplication(row))
+	}
+
+	return applications, total, nil
+}
+
+// GetVendorApplication retrieves a vendor with its owner and documents
+func (r *Repository) GetVendorApplication(ctx context.Context, vendorID int64) (*admin.VendorApplication, error) {
+	var row VendorApplicationData
+	result := r.db.WithContext(ctx).Raw(vendorApplicationSelect+`
+		WHERE v.id = ?
+	`, vendorID).Scan(&row)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return toVendorApplication(&row), nil
+}
+
+// ApplyVendorDecision changes the vendor status, records the admin action and queues the vendor's
+// notification in one transaction. The update only applies while the vendor is still in the status
+// the decision was taken on, so two admins deciding on the same vendor cannot both succeed.
+func (r *Repository) ApplyVendorDecision(ctx context.Context, decision *admin.VendorDecision) error {
+	vendor := decision.Vendor
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Move the vendor
+		var rejectionReason *string
+		if vendor.RejectionReason != "" {
+			rejectionReason = &vendor.RejectionReason
+		}
+
+		update := tx.Model(&datamodel.Vendor{}).
+			Where("id = ? AND status = ?", vendor.ID, string(decision.From)).
+			Updates(map[string]interface{}{
+				"status":           string(vendor.Status),
+				"rejection_reason": rejectionReason,
+				"verified":         vendor.Verified,
+				"verified_at":      vendor.VerifiedAt,
+				"version":          gorm.Expr("version + 1"),
+				"updated_at":       vendor.UpdatedAt,
+			})
+		if update.Error != nil {
+			return update.Error
+		}
+		if update.RowsAffected == 0 {
+			return internal.NewConflictError("Vendor status was changed by another admin, please reload", internal.ErrConflict)
+		}
+
+		// 2. Audit the decision
+		action := decision.Action
+		metadata, err := json.Marshal(action.Metadata)
+		if err != nil {
+			return err
+		}
+		metadataStr := string(metadata)
+
+		actionDM := &datamodel.AdminAction{
+			AdminID:    action.AdminID,
+			ActionType: string(action.ActionType),
+			TargetType: string(action.TargetType),
+			TargetID:   action.TargetID,
+			Reason:     action.Reason,
+			Metadata:   &metadataStr,
+			CreatedAt:  action.CreatedAt,
+		}
+		if err := tx.Create(actionDM).Error; err != nil {
+			return err
+		}
+		action.ID = actionDM.ID
+
+		// 3. Queue the vendor's notification
+		if decision.Notification == nil {
+			return nil
+		}
+		notification := decision.Notification
+		subject := notification.Subject
+		notificationDM := &datamodel.Notification{
+			UserID:    notification.UserID,
+			Type:      notification.Type,
+			Channel:   string(notification.Channel),
+			Recipient: notification.Recipient,
+			Subject:   &subject,
+			Message:   notification.Message,
+			Status:    string(admin.NotificationPending),
+			CreatedAt: action.CreatedAt,
+		}
+		if err := tx.Create(notificationDM).Error; err != nil {
+			return err
+		}
+		notification.ID = notificationDM.ID
+
+		return nil
+	})
+}
+
+// MarkNotificationSent records that a queued notification was delivered
+func (r *Repository) MarkNotificationSent(ctx context.Context, id int64, sentAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&datamodel.Notification{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":  string(admin.NotificationSent),
+			"sent_at": sentAt,
+		}).Error
+}
+
+// MarkNotificationFailed records why a queued notification could not be delivered
+func (r *Repository) MarkNotificationFailed(ctx context.Context, id int64, reason string) error {
+	return r.db.WithContext(ctx).
+		Model(&datamodel.Notification{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        string(admin.NotificationFailed),
+			"error_message": reason,
+		}).Error
+}
+
+func toVendorApplication(row *VendorApplicationData) *admin.VendorApplication {
+	v := &row.Vendor
+	vendor := &user.Vendor{
+		ID:              v.ID,
+		UserID:          v.UserID,
+		BusinessName:    v.BusinessName,
+		Description:     stringValue(v.Description),
+		BusinessType:    v.BusinessType,
+		Phone:           v.Phone,
+		Whatsapp:        stringValue(v.Whatsapp),
+		Address:         v.Address,
+		City:            v.City,
+		District:        stringValue(v.District),
+		PostalCode:      stringValue(v.PostalCode),
+		GoogleMapsURL:   stringValue(v.GoogleMapsURL),
+		Logo:            stringValue(v.Logo),
+		CoverImage:      stringValue(v.CoverImage),
+		Photos:          jsonStrings(v.Photos),
+		Amenities:       jsonStrings(v.Amenities),
+		BusinessLicense: stringValue(v.BusinessLicense),
+		Status:          user.VendorStatus(v.Status),
+		RejectionReason: stringValue(v.RejectionReason),
+		RatingAvg:       v.RatingAvg,
+		TotalReviews:    v.TotalReviews,
+		TotalBookings:   v.TotalBookings,
+		Verified:        v.Verified,
+		VerifiedAt:      v.VerifiedAt,
+		CreatedAt:       v.CreatedAt,
+		UpdatedAt:       v.UpdatedAt,
+	}
+	if v.Latitude != nil {
+		vendor.Latitude = *v.Latitude
+	}
+	if v.Longitude != nil {
+		vendor.Longitude = *v.Longitude
+	}
+
+	return &admin.VendorApplication{
+		Vendor:     vendor,
+		OwnerName:  row.OwnerName,
+		OwnerEmail: row.OwnerEmail,
+		OwnerPhone: row.OwnerPhone,
+		Documents:  toVendorDocuments(vendor),
+	}
+}
+
+// toVendorDocuments lists the files an admin reviews before approving a vendor
+func toVendorDocuments(vendor *user.Vendor) []admin.VendorDocument {
+	documents := make([]admin.VendorDocument, 0, 3+len(vendor.Photos))
+	if vendor.BusinessLicense != "" {
+		documents = append(documents, admin.VendorDocument{Type: "business_license", URL: vendor.BusinessLicense})
+	}
+	if vendor.Logo != "" {
+		documents = append(documents, admin.VendorDocument{Type: "logo", URL: vendor.Logo})
+	}
+	if vendor.CoverImage != "" {
+		documents = append(documents, admin.VendorDocument{Type: "cover_image", URL: vendor.CoverImage})
+	}
+	for _, photo := range vendor.Photos {
+		documents = append(documents, admin.VendorDocument{Type: "photo", URL: photo})
+	}
+	return documents
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// jsonStrings decodes a JSONB array of strings, treating malformed values as empty
+func jsonStrings(raw *string) []string {
+	if raw == nil || *raw == "" {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(*raw), &values); err != nil {
+		return nil
+	}
+	return values
+}
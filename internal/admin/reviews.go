@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"time"
+)
+
+// ReviewReportStatus tracks a report through the moderation queue
+type ReviewReportStatus string
+
+const (
+	ReviewReportStatusOpen      ReviewReportStatus = "open"
+	ReviewReportStatusHidden    ReviewReportStatus = "hidden"
+	ReviewReportStatusDismissed ReviewReportStatus = "dismissed"
+)
+
+// ReviewReport is one parent's or vendor's reason for flagging a review
+type ReviewReport struct {
+	ID           int64
+	ReporterID   int64
+	ReporterRole string // parent, vendor
+	Reason       string
+	CreatedAt    time.Time
+}
+
+// ReportedReview is a review in the moderation queue together with its open reports
+type ReportedReview struct {
+	ReviewID       int64
+	VendorID       int64
+	VendorName     string
+	ServiceID      int64
+	ServiceName    string
+	ParentID       int64
+	Rating         int
+	ReviewText     *string
+	VendorResponse *string
+	IsApproved     bool
+	CreatedAt      time.Time
+	Reports        []ReviewReport
+}
+
+// ReviewReportFilter pages through the moderation queue
+type ReviewReportFilter struct {
+	Page  int
+	Limit int
+}
+
+// ReviewModeration is an admin's decision on a review together with its audit record.
+// Hiding unpublishes the review and takes it out of the vendor's rating; either way the
+// review's open reports are resolved.
+type ReviewModeration struct {
+	ReviewID int64
+	Hide     bool
+	Action   *Action
+}
+
+// ReportStatus is the status the review's open reports are resolved to
+func (m *ReviewModeration) ReportStatus() ReviewReportStatus {
+	if m.Hide {
+		return ReviewReportStatusHidden
+	}
+	return ReviewReportStatusDismissed
+}
@@ -0,0 +1,251 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/notification"
+	"github.com/frahmantamala/jadiles/internal/user"
+)
+
+type Repository interface {
+	ListVendors(ctx context.Context, filter *VendorFilter) ([]*VendorApplication, int64, error)
+	GetVendorApplication(ctx context.Context, vendorID int64) (*VendorApplication, error)
+	ApplyVendorDecision(ctx context.Context, decision *VendorDecision) error
+	MarkNotificationSent(ctx context.Context, id int64, sentAt time.Time) error
+	MarkNotificationFailed(ctx context.Context, id int64, reason string) error
+	GetPlatformStatistics(ctx context.Context, filter *StatisticsFilter) (*PlatformStatistics, error)
+	ListReportedReviews(ctx context.Context, filter *ReviewReportFilter) ([]*ReportedReview, int64, error)
+	ModerateReview(ctx context.Context, moderation *ReviewModeration) error
+}
+
+// Service handles the admin back office: reviewing vendor applications, auditing every decision
+// and reporting platform statistics
+type Service struct {
+	repo   Repository
+	sender notification.Sender
+}
+
+func NewService(repo Repository, sender notification.Sender) *Service {
+	return &Service{
+		repo:   repo,
+		sender: sender,
+	}
+}
+
+// ListVendors lists vendors in a status, by default the applications awaiting approval
+func (s *Service) ListVendors(ctx context.Context, params *ListVendorsParams) (*VendorsResponse, error) {
+	applications, total, err := s.repo.ListVendors(ctx, params.ToVendorFilter())
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToVendorsResponse(applications, params.Page, params.Limit, total), nil
+}
+
+// ApproveVendor activates a pending vendor, or reinstates a suspended one
+func (s *Service) ApproveVendor(ctx context.Context, adminID, vendorID int64) (*VendorResponse, error) {
+	application, err := s.decideVendor(ctx, adminID, vendorID, ActionApproveVendor, "", func(v *user.Vendor) error {
+		v.Approve()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToVendorResponse(application, "Vendor approved successfully"), nil
+}
+
+// RejectVendor rejects a pending vendor application with a reason shown to the vendor
+func (s *Service) RejectVendor(ctx context.Context, adminID, vendorID int64, params *VendorReasonParams) (*VendorResponse, error) {
+	application, err := s.decideVendor(ctx, adminID, vendorID, ActionRejectVendor, params.Reason, func(v *user.Vendor) error {
+		return v.Reject(params.Reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToVendorResponse(application, "Vendor rejected"), nil
+}
+
+// SuspendVendor suspends an active vendor, hiding their services until they are approved again
+func (s *Service) SuspendVendor(ctx context.Context, adminID, vendorID int64, params *VendorReasonParams) (*VendorResponse, error) {
+	application, err := s.decideVendor(ctx, adminID, vendorID, ActionSuspendVendor, params.Reason, func(v *user.Vendor) error {
+		v.Suspend(params.Reason)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToVendorResponse(application, "Vendor suspended"), nil
+}
+
+// GetStatistics computes the platform statistics ops reviews, with a time series over the window
+func (s *Service) GetStatistics(ctx context.Context, params *GetStatisticsParams) (*StatisticsResponse, error) {
+	stats, err := s.repo.GetPlatformStatistics(ctx, params.ToStatisticsFilter())
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToStatisticsResponse(stats), nil
+}
+
+// ListReportedReviews lists the reviews parents or vendors reported, the longest waiting first
+func (s *Service) ListReportedReviews(ctx context.Context, params *ListReportedReviewsParams) (*ReportedReviewsResponse, error) {
+	reviews, total, err := s.repo.ListReportedReviews(ctx, params.ToReviewReportFilter())
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToReportedReviewsResponse(reviews, params.Page, params.Limit, total), nil
+}
+
+// HideReview unpublishes a review and takes it out of the vendor's rating, resolving its reports
+func (s *Service) HideReview(ctx context.Context, adminID, reviewID int64, params *HideReviewParams) (*ReviewModerationResponse, error) {
+	moderation, err := s.moderateReview(ctx, adminID, reviewID, ActionHideReview, params.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToReviewModerationResponse(moderation, "Review hidden"), nil
+}
+
+// DismissReviewReports keeps a reported review published and closes its open reports
+func (s *Service) DismissReviewReports(ctx context.Context, adminID, reviewID int64) (*ReviewModerationResponse, error) {
+	moderation, err := s.moderateReview(ctx, adminID, reviewID, ActionDismissReviewReports, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return ToReviewModerationResponse(moderation, "Review reports dismissed"), nil
+}
+
+// moderateReview persists a decision on a review together with its admin_actions audit row
+func (s *Service) moderateReview(
+	ctx context.Context,
+	adminID, reviewID int64,
+	actionType ActionType,
+	reason string,
+) (*ReviewModeration, error) {
+	action := &Action{
+		AdminID:    adminID,
+		ActionType: actionType,
+		TargetType: TargetReview,
+		TargetID:   reviewID,
+		Metadata:   map[string]interface{}{},
+		CreatedAt:  time.Now(),
+	}
+	if reason != "" {
+		action.Reason = &reason
+	}
+
+	moderation := &ReviewModeration{
+		ReviewID: reviewID,
+		Hide:     actionType == ActionHideReview,
+		Action:   action,
+	}
+
+	if err := s.repo.ModerateReview(ctx, moderation); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return moderation, nil
+}
+
+// decideVendor applies a decision to a vendor through its domain method, then persists it
+// together with the admin_actions audit row and the notification to the vendor, which is sent
+// once the decision is committed
+func (s *Service) decideVendor(
+	ctx context.Context,
+	adminID, vendorID int64,
+	actionType ActionType,
+	reason string,
+	apply func(v *user.Vendor) error,
+) (*VendorApplication, error) {
+	application, err := s.repo.GetVendorApplication(ctx, vendorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Vendor")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	vendor := application.Vendor
+	from := vendor.Status
+	if !actionType.CanApplyTo(from) {
+		return nil, internal.NewBusinessRuleError("Vendor is "+string(from)+" and cannot be changed this way", internal.ErrInvalidState)
+	}
+
+	if err := apply(vendor); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	action := &Action{
+		AdminID:    adminID,
+		ActionType: actionType,
+		TargetType: TargetVendor,
+		TargetID:   vendor.ID,
+		Metadata: map[string]interface{}{
+			"business_name":   vendor.BusinessName,
+			"previous_status": string(from),
+			"new_status":      string(vendor.Status),
+		},
+		CreatedAt: time.Now(),
+	}
+	if reason != "" {
+		action.Reason = &reason
+	}
+
+	decision := &VendorDecision{
+		Vendor:       vendor,
+		From:         from,
+		Action:       action,
+		Notification: NewVendorNotification(application, actionType),
+	}
+
+	if err := s.repo.ApplyVendorDecision(ctx, decision); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if decision.Notification != nil {
+		s.sendNotification(ctx, decision.Notification)
+	}
+
+	return application, nil
+}
+
+// sendNotification delivers a notification queued with a committed decision and records the outcome
+// on its row. The decision stands either way, so a failed delivery is logged and left for follow-up
+// rather than returned to the admin.
+func (s *Service) sendNotification(ctx context.Context, n *Notification) {
+	err := s.sender.Send(ctx, &notification.Message{
+		To:      n.Recipient,
+		Subject: n.Subject,
+		Body:    n.Message,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to send notification",
+			slog.Int64("notification_id", n.ID),
+			slog.Any("error", err),
+		)
+		err = s.repo.MarkNotificationFailed(ctx, n.ID, err.Error())
+	} else {
+		err = s.repo.MarkNotificationSent(ctx, n.ID, time.Now())
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record notification delivery",
+			slog.Int64("notification_id", n.ID),
+			slog.Any("error", err),
+		)
+	}
+}
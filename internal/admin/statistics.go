@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"fmt"
+	"time"
+)
+
+// Granularity is the width of each bucket in the statistics time series
+type Granularity string
+
+const (
+	GranularityDay   Granularity = "day"
+	GranularityWeek  Granularity = "week"
+	GranularityMonth Granularity = "month"
+)
+
+// MaxStatisticsBuckets bounds the time series so one request cannot scan years day by day
+const MaxStatisticsBuckets = 366
+
+// TopCategoriesLimit is how many categories the statistics rank
+const TopCategoriesLimit = 5
+
+// Interval returns the PostgreSQL interval between two bucket starts
+func (g Granularity) Interval() string {
+	return "1 " + string(g)
+}
+
+// StatisticsFilter is the reporting window, both dates inclusive
+type StatisticsFilter struct {
+	From        time.Time
+	To          time.Time
+	Granularity Granularity
+}
+
+// Validate checks the window and that it yields a bounded number of buckets
+func (f *StatisticsFilter) Validate() error {
+	switch f.Granularity {
+	case GranularityDay, GranularityWeek, GranularityMonth:
+	default:
+		return fmt.Errorf("granularity must be one of day, week, month")
+	}
+
+	if f.To.Before(f.From) {
+		return fmt.Errorf("to must not be before from")
+	}
+
+	if buckets := f.BucketCount(); buckets > MaxStatisticsBuckets {
+		return fmt.Errorf("range spans %d %ss, at most %d are allowed", buckets, f.Granularity, MaxStatisticsBuckets)
+	}
+
+	return nil
+}
+
+// BucketCount returns how many buckets the window touches
+func (f *StatisticsFilter) BucketCount() int {
+	switch f.Granularity {
+	case GranularityWeek:
+		return int(weekStart(f.To).Sub(weekStart(f.From)).Hours()/24)/7 + 1
+	case GranularityMonth:
+		return (f.To.Year()-f.From.Year())*12 + int(f.To.Month()-f.From.Month()) + 1
+	default:
+		return int(f.To.Sub(f.From).Hours()/24) + 1
+	}
+}
+
+// End returns the first instant after the window
+func (f *StatisticsFilter) End() time.Time {
+	return f.To.AddDate(0, 0, 1)
+}
+
+// weekStart returns the Monday of the date's week, matching PostgreSQL date_trunc('week')
+func weekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+// PlatformStatistics are the platform-wide figures ops reviews, for one reporting window
+type PlatformStatistics struct {
+	Filter           StatisticsFilter
+	Summary          StatisticsSummary
+	BookingsByStatus []StatusCount
+	TopCategories    []CategoryStatistic
+	Series           []StatisticsBucket
+}
+
+// StatisticsSummary totals the window.
+// GMV is the value of paid bookings placed in the window; cancellation rate is over all bookings placed.
+type StatisticsSummary struct {
+	GMV               float64
+	TotalBookings     int
+	CancelledBookings int
+	CancellationRate  float64
+	NewParents        int
+	NewVendors        int
+	AverageRating     float64
+	TotalReviews      int
+}
+
+// StatusCount is the number of bookings placed in the window that are now in a status
+type StatusCount struct {
+	Status string
+	Count  int
+}
+
+// CategoryStatistic ranks a category by the non-cancelled bookings placed in the window
+type CategoryStatistic struct {
+	CategoryID int64
+	Name       string
+	Slug       string
+	Bookings   int
+	GMV        float64
+	Percentage float64
+}
+
+// StatisticsBucket is one point of the time series, starting at Start
+type StatisticsBucket struct {
+	Start             time.Time
+	GMV               float64
+	Bookings          int
+	CancelledBookings int
+	NewParents        int
+	NewVendors        int
+}
+
+// Summarize totals the series into the summary and derives the percentages.
+// Rating figures are queried separately and left untouched.
+func (s *PlatformStatistics) Summarize() {
+	summary := &s.Summary
+	for _, bucket := range s.Series {
+		summary.GMV += bucket.GMV
+		summary.TotalBookings += bucket.Bookings
+		summary.CancelledBookings += bucket.CancelledBookings
+		summary.NewParents += bucket.NewParents
+		summary.NewVendors += bucket.NewVendors
+	}
+
+	if summary.TotalBookings > 0 {
+		summary.CancellationRate = float64(summary.CancelledBookings) / float64(summary.TotalBookings) * 100
+	}
+
+	active := summary.TotalBookings - summary.CancelledBookings
+	for i := range s.TopCategories {
+		if active > 0 {
+			s.TopCategories[i].Percentage = float64(s.TopCategories[i].Bookings) / float64(active) * 100
+		}
+	}
+}
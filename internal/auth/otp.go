@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	goRedis "github.com/redis/go-redis/v9"
+)
+
+// One-time code purposes, each stored separately
+const (
+	PurposePhoneVerification = "phone_verification"
+)
+
+const otpDigits = 6
+
+var (
+	ErrOTPExpired          = errors.New("one-time code has expired")
+	ErrOTPMismatch         = errors.New("one-time code does not match")
+	ErrOTPAttemptsExceeded = errors.New("too many wrong one-time codes")
+)
+
+// verifyOTPScript counts the attempt and deletes the code once it matched or ran out of attempts.
+// Returns 1 on match, 0 on a wrong code, -1 when no code is pending and -2 when attempts ran out.
+var verifyOTPScript = goRedis.NewScript(`
+local hash = redis.call("HGET", KEYS[1], "hash")
+if not hash then
+	return -1
+end
+local attempts = redis.call("HINCRBY", KEYS[1], "attempts", 1)
+if hash == ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	return 1
+end
+if attempts >= tonumber(redis.call("HGET", KEYS[1], "max_attempts")) then
+	redis.call("DEL", KEYS[1])
+	return -2
+end
+return 0
+`)
+
+// OTPStore issues short numeric one-time codes sent to users over SMS or WhatsApp.
+// Only an HMAC of the code, bound to the user and the target it was sent to, is kept in Redis,
+// together with the number of attempts left. One code is pending per user and purpose.
+type OTPStore struct {
+	client goRedis.UniversalClient
+	secret []byte
+}
+
+func NewOTPStore(client goRedis.UniversalClient, config internal.HTTPServerConfig) (*OTPStore, error) {
+	secret, err := config.GetVerificationSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode verification secret: %w", err)
+	}
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("verification secret is required")
+	}
+
+	return &OTPStore{
+		client: client,
+		secret: secret,
+	}, nil
+}
+
+// Issue creates a code for the user and target that expires after ttl, replacing any pending code
+func (s *OTPStore) Issue(ctx context.Context, purpose string, userID int64, target string, ttl time.Duration, maxAttempts int) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate one-time code: %w", err)
+	}
+	code := fmt.Sprintf("%0*d", otpDigits, n.Int64())
+
+	key := s.generateKey(purpose, userID)
+	_, err = s.client.TxPipelined(ctx, func(pipe goRedis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key,
+			"hash", s.hash(purpose, userID, target, code),
+			"attempts", 0,
+			"max_attempts", maxAttempts,
+		)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store one-time code: %w", err)
+	}
+
+	return code, nil
+}
+
+// Verify checks a code the user entered for the target. A matching code can be used once.
+func (s *OTPStore) Verify(ctx context.Context, purpose string, userID int64, target, code string) error {
+	result, err := verifyOTPScript.Run(ctx, s.client,
+		[]string{s.generateKey(purpose, userID)},
+		s.hash(purpose, userID, target, code),
+	).Int()
+	if err != nil {
+		return fmt.Errorf("failed to verify one-time code: %w", err)
+	}
+
+	switch result {
+	case 1:
+		return nil
+	case -1:
+		return ErrOTPExpired
+	case -2:
+		return ErrOTPAttemptsExceeded
+	}
+	return ErrOTPMismatch
+}
+
+func (s *OTPStore) hash(purpose string, userID int64, target, code string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(fmt.Sprintf("%s:%d:%s:%s", purpose, userID, target, code)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *OTPStore) generateKey(purpose string, userID int64) string {
+	return fmt.Sprintf("otp:%s:user:%d", purpose, userID)
+}
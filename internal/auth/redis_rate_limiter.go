@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goRedis "github.com/redis/go-redis/v9"
+)
+
+// RedisRateLimiter counts attempts per key in fixed windows shared by all API instances
+type RedisRateLimiter struct {
+	client goRedis.UniversalClient
+}
+
+func NewRedisRateLimiter(client goRedis.UniversalClient) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		client: client,
+	}
+}
+
+// Allow records an attempt and reports whether it is within limit attempts per window.
+// When it is not, it also returns how long until the window resets.
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	redisKey := l.generateKey(key)
+
+	var incr *goRedis.IntCmd
+	var ttl *goRedis.DurationCmd
+	_, err := l.client.TxPipelined(ctx, func(pipe goRedis.Pipeliner) error {
+		incr = pipe.Incr(ctx, redisKey)
+		pipe.ExpireNX(ctx, redisKey, window)
+		ttl = pipe.PTTL(ctx, redisKey)
+		return nil
+	})
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+
+	if incr.Val() > int64(limit) {
+		retryAfter := ttl.Val()
+		if retryAfter < 0 {
+			retryAfter = window
+		}
+		return false, retryAfter, nil
+	}
+
+	return true, 0, nil
+}
+
+func (l *RedisRateLimiter) generateKey(key string) string {
+	return fmt.Sprintf("ratelimit:%s", key)
+}
@@ -5,9 +5,28 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/frahmantamala/jadiles/internal"
 	goRedis "github.com/redis/go-redis/v9"
 )
 
+// rotateRefreshScript moves a token family to its next refresh token. Only the family's current
+// token may be rotated; presenting an older one means it was stolen or replayed, so the whole
+// family is revoked. Returns 1 when rotated, 0 when the family is unknown and -1 on reuse.
+var rotateRefreshScript = goRedis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	return -1
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+return 1
+`)
+
+// RedisTokenStorage tracks issued tokens by their jti, and refresh token families by the jti of
+// the family's current refresh token. All keys of a user share a prefix so they can be revoked at once.
 type RedisTokenStorage struct {
 	client goRedis.UniversalClient
 }
@@ -18,8 +37,8 @@ func NewRedisTokenStorage(client goRedis.UniversalClient) *RedisTokenStorage {
 	}
 }
 
-func (r *RedisTokenStorage) StoreToken(ctx context.Context, userID int64, token string, expiry time.Duration) error {
-	key := r.generateTokenKey(userID, token)
+func (r *RedisTokenStorage) StoreToken(ctx context.Context, userID int64, tokenID string, expiry time.Duration) error {
+	key := r.generateTokenKey(userID, tokenID)
 	err := r.client.Set(ctx, key, "valid", expiry).Err()
 	if err != nil {
 		return fmt.Errorf("failed to store token: %w", err)
@@ -27,8 +46,8 @@ func (r *RedisTokenStorage) StoreToken(ctx context.Context, userID int64, token
 	return nil
 }
 
-func (r *RedisTokenStorage) IsTokenValid(ctx context.Context, userID int64, token string) (bool, error) {
-	key := r.generateTokenKey(userID, token)
+func (r *RedisTokenStorage) IsTokenValid(ctx context.Context, userID int64, tokenID string) (bool, error) {
+	key := r.generateTokenKey(userID, tokenID)
 	result, err := r.client.Get(ctx, key).Result()
 	if err != nil {
 		if err == goRedis.Nil {
@@ -39,8 +58,8 @@ func (r *RedisTokenStorage) IsTokenValid(ctx context.Context, userID int64, toke
 	return result == "valid", nil
 }
 
-func (r *RedisTokenStorage) InvalidateToken(ctx context.Context, userID int64, token string) error {
-	key := r.generateTokenKey(userID, token)
+func (r *RedisTokenStorage) InvalidateToken(ctx context.Context, userID int64, tokenID string) error {
+	key := r.generateTokenKey(userID, tokenID)
 	err := r.client.Del(ctx, key).Err()
 	if err != nil {
 		return fmt.Errorf("failed to invalidate token: %w", err)
@@ -48,6 +67,45 @@ func (r *RedisTokenStorage) InvalidateToken(ctx context.Context, userID int64, t
 	return nil
 }
 
+// StartRefreshFamily starts a token family at login with its first refresh token
+func (r *RedisTokenStorage) StartRefreshFamily(ctx context.Context, userID int64, familyID, tokenID string, expiry time.Duration) error {
+	key := r.generateFamilyKey(userID, familyID)
+	if err := r.client.Set(ctx, key, tokenID, expiry).Err(); err != nil {
+		return fmt.Errorf("failed to store token family: %w", err)
+	}
+	return nil
+}
+
+// RotateRefreshFamily replaces the family's current refresh token with the next one.
+// It returns internal.ErrRefreshTokenNotFound when the family was revoked or expired, and
+// internal.ErrRefreshTokenIsBlocked when an already rotated token was presented, after
+// revoking the family.
+func (r *RedisTokenStorage) RotateRefreshFamily(ctx context.Context, userID int64, familyID, currentTokenID, nextTokenID string, expiry time.Duration) error {
+	key := r.generateFamilyKey(userID, familyID)
+	result, err := rotateRefreshScript.Run(ctx, r.client, []string{key},
+		currentTokenID, nextTokenID, expiry.Milliseconds(),
+	).Int()
+	if err != nil {
+		return fmt.Errorf("failed to rotate token family: %w", err)
+	}
+
+	switch result {
+	case 1:
+		return nil
+	case -1:
+		return internal.ErrRefreshTokenIsBlocked
+	}
+	return internal.ErrRefreshTokenNotFound
+}
+
+// RevokeRefreshFamily ends a token family, so none of its refresh tokens can be used again
+func (r *RedisTokenStorage) RevokeRefreshFamily(ctx context.Context, userID int64, familyID string) error {
+	if err := r.client.Del(ctx, r.generateFamilyKey(userID, familyID)).Err(); err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+	return nil
+}
+
 func (r *RedisTokenStorage) InvalidateAllUserTokens(ctx context.Context, userID int64) error {
 	pattern := r.generateUserTokenPattern(userID)
 
@@ -72,8 +130,12 @@ func (r *RedisTokenStorage) InvalidateAllUserTokens(ctx context.Context, userID
 	return nil
 }
 
-func (r *RedisTokenStorage) generateTokenKey(userID int64, token string) string {
-	return fmt.Sprintf("token:user:%d:%s", userID, token)
+func (r *RedisTokenStorage) generateTokenKey(userID int64, tokenID string) string {
+	return fmt.Sprintf("token:user:%d:%s", userID, tokenID)
+}
+
+func (r *RedisTokenStorage) generateFamilyKey(userID int64, familyID string) string {
+	return fmt.Sprintf("token:user:%d:family:%s", userID, familyID)
 }
 
 func (r *RedisTokenStorage) generateUserTokenPattern(userID int64) string {
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -15,35 +16,47 @@ import (
 )
 
 const (
-	ctxUserIDKey = "user_id"
-	ctxEmailKey  = "email"
-	ctxRoleKey   = "role"
-	ctxTokenKey  = "token"
+	ctxUserIDKey = internal.UserIDCtxKey
+	ctxEmailKey  = internal.EmailCtxKey
+	ctxRoleKey   = internal.RoleCtxKey
+	ctxTokenKey  = internal.TokenKey
 )
 
 type TokenClaims struct {
-	UserID int64
-	Email  string
-	Role   string
-	JTI    string
+	UserID    int64
+	Email     string
+	Role      string
+	JTI       string
+	FamilyID  string
+	ExpiresAt time.Time
 }
 
+// Claims are the JWT claims of access and refresh tokens. Every token has a unique jti (RegisteredClaims.ID),
+// which is how stored tokens are tracked. Refresh tokens also carry the family they were rotated from.
 type Claims struct {
-	UserID int64  `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID   int64  `json:"user_id"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	FamilyID string `json:"fid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// TokenValidator checks if an issued token is still live. Tokens are revoked on logout and password changes
+// long before their JWT expires.
+type TokenValidator interface {
+	IsTokenValid(ctx context.Context, userID int64, tokenID string) (bool, error)
+}
+
 type JWTAuthentication struct {
 	accessSecret         []byte
 	refreshSecret        []byte
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
 	issuer               string
+	tokens               TokenValidator
 }
 
-func NewJWTAuthentication(config internal.HTTPServerConfig) (*JWTAuthentication, error) {
+func NewJWTAuthentication(config internal.HTTPServerConfig, tokens TokenValidator) (*JWTAuthentication, error) {
 	accessSecret, err := config.GetJWTSecret()
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode jwt secret: %w", err)
@@ -60,56 +73,60 @@ func NewJWTAuthentication(config internal.HTTPServerConfig) (*JWTAuthentication,
 		accessTokenDuration:  config.AuthConfig.AccessTokenDuration,
 		refreshTokenDuration: config.AuthConfig.RefreshTokenDuration,
 		issuer:               config.AuthConfig.Issuer,
+		tokens:               tokens,
 	}, nil
 }
 
-func (ja *JWTAuthentication) GenerateAccessToken(ctx context.Context, userID int64, email, role string) (string, time.Time, error) {
-	expiresAt := time.Now().Add(ja.accessTokenDuration)
-
-	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    ja.issuer,
-			ID:        uuid.NewString(),
-		},
+// GenerateAccessToken creates a new JWT access token
+func (ja *JWTAuthentication) GenerateAccessToken(ctx context.Context, userID int64, email, role string) (string, *TokenClaims, error) {
+	token, claims, err := ja.generateToken(userID, email, role, "", ja.accessTokenDuration, ja.accessSecret)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign access token: %w", err)
 	}
+	return token, claims, nil
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(ja.accessSecret)
+// GenerateRefreshToken creates a new JWT refresh token in the given token family
+func (ja *JWTAuthentication) GenerateRefreshToken(ctx context.Context, userID int64, email, role, familyID string) (string, *TokenClaims, error) {
+	token, claims, err := ja.generateToken(userID, email, role, familyID, ja.refreshTokenDuration, ja.refreshSecret)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+		return "", nil, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
-
-	return tokenString, expiresAt, nil
+	return token, claims, nil
 }
 
-// GenerateRefreshToken creates a new JWT refresh token
-func (ja *JWTAuthentication) GenerateRefreshToken(ctx context.Context, userID int64, email, role string) (string, time.Time, error) {
-	expiresAt := time.Now().Add(ja.refreshTokenDuration)
+func (ja *JWTAuthentication) generateToken(userID int64, email, role, familyID string, duration time.Duration, secret []byte) (string, *TokenClaims, error) {
+	now := time.Now()
+	expiresAt := now.Add(duration)
+	jti := uuid.NewString()
 
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:   userID,
+		Email:    email,
+		Role:     role,
+		FamilyID: familyID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    ja.issuer,
-			ID:        uuid.NewString(),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(ja.refreshSecret)
+	tokenString, err := token.SignedString(secret)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("failed to sign refresh token: %w", err)
+		return "", nil, err
 	}
 
-	return tokenString, expiresAt, nil
+	return tokenString, &TokenClaims{
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		JTI:       jti,
+		FamilyID:  familyID,
+		ExpiresAt: expiresAt,
+	}, nil
 }
 
 func (ja *JWTAuthentication) ParseAccessToken(ctx context.Context, tokenString string) (*TokenClaims, error) {
@@ -137,11 +154,18 @@ func (ja *JWTAuthentication) parseToken(ctx context.Context, tokenString string,
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
 	return &TokenClaims{
-		UserID: claims.UserID,
-		Email:  claims.Email,
-		Role:   claims.Role,
-		JTI:    claims.ID,
+		UserID:    claims.UserID,
+		Email:     claims.Email,
+		Role:      claims.Role,
+		JTI:       claims.ID,
+		FamilyID:  claims.FamilyID,
+		ExpiresAt: expiresAt,
 	}, nil
 }
 
@@ -159,6 +183,17 @@ func (ja *JWTAuthentication) Authenticator(next http.Handler) http.Handler {
 			return
 		}
 
+		valid, err := ja.isTokenLive(r.Context(), claims)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to check access token", slog.Any("error", err))
+			ja.handleAuthError(w, r, "unable to verify token", http.StatusInternalServerError)
+			return
+		}
+		if !valid {
+			ja.handleAuthError(w, r, "token has been revoked", http.StatusUnauthorized)
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), ctxUserIDKey, claims.UserID)
 		ctx = context.WithValue(ctx, ctxEmailKey, claims.Email)
 		ctx = context.WithValue(ctx, ctxRoleKey, claims.Role)
@@ -174,17 +209,28 @@ func (ja *JWTAuthentication) OptionalAuthenticator(next http.Handler) http.Handl
 		if token != "" {
 			claims, err := ja.ParseAccessToken(r.Context(), token)
 			if err == nil {
-				ctx := context.WithValue(r.Context(), ctxUserIDKey, claims.UserID)
-				ctx = context.WithValue(ctx, ctxEmailKey, claims.Email)
-				ctx = context.WithValue(ctx, ctxRoleKey, claims.Role)
-				ctx = context.WithValue(ctx, ctxTokenKey, token)
-				r = r.WithContext(ctx)
+				// Revoked tokens are treated as anonymous
+				if valid, err := ja.isTokenLive(r.Context(), claims); err == nil && valid {
+					ctx := context.WithValue(r.Context(), ctxUserIDKey, claims.UserID)
+					ctx = context.WithValue(ctx, ctxEmailKey, claims.Email)
+					ctx = context.WithValue(ctx, ctxRoleKey, claims.Role)
+					ctx = context.WithValue(ctx, ctxTokenKey, token)
+					r = r.WithContext(ctx)
+				}
 			}
 		}
 		next.ServeHTTP(w, r)
 	})
 }
 
+// isTokenLive checks the token's jti against the token storage, so revoked tokens are rejected before they expire
+func (ja *JWTAuthentication) isTokenLive(ctx context.Context, claims *TokenClaims) (bool, error) {
+	if claims.JTI == "" {
+		return false, nil
+	}
+	return ja.tokens.IsTokenValid(ctx, claims.UserID, claims.JTI)
+}
+
 func (ja *JWTAuthentication) RequireRole(roles ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	goRedis "github.com/redis/go-redis/v9"
+)
+
+// Verification token purposes, each signed and stored separately
+const (
+	PurposeEmailVerification = "email_verification"
+	PurposePasswordReset     = "password_reset"
+)
+
+// consumeVerificationScript deletes the stored nonce only if it matches, so a token is used once
+var consumeVerificationScript = goRedis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// VerificationTokenStore issues signed, single-use tokens for links sent to users.
+// A token carries the user ID and a random nonce, signed with HMAC-SHA256 so forged tokens are
+// rejected without a Redis lookup. Only the nonce of the latest token per user and purpose is
+// kept in Redis, so issuing a new token revokes the previous one and consuming it deletes it.
+type VerificationTokenStore struct {
+	client goRedis.UniversalClient
+	secret []byte
+}
+
+func NewVerificationTokenStore(client goRedis.UniversalClient, config internal.HTTPServerConfig) (*VerificationTokenStore, error) {
+	secret, err := config.GetVerificationSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode verification secret: %w", err)
+	}
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("verification secret is required")
+	}
+
+	return &VerificationTokenStore{
+		client: client,
+		secret: secret,
+	}, nil
+}
+
+// Issue creates a token for the user that expires after ttl
+func (s *VerificationTokenStore) Issue(ctx context.Context, purpose string, userID int64, ttl time.Duration) (string, error) {
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return "", fmt.Errorf("failed to generate token nonce: %w", err)
+	}
+	nonce := hex.EncodeToString(nonceBytes)
+
+	if err := s.client.Set(ctx, s.generateKey(purpose, userID), nonce, ttl).Err(); err != nil {
+		return "", fmt.Errorf("failed to store verification token: %w", err)
+	}
+
+	payload := strconv.FormatInt(userID, 10) + "." + nonce
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + s.sign(purpose, payload), nil
+}
+
+// Consume checks the token and invalidates it, returning the user it was issued to
+func (s *VerificationTokenStore) Consume(ctx context.Context, purpose string, token string) (int64, error) {
+	encodedPayload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, internal.ErrInvalidToken
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, internal.ErrInvalidToken
+	}
+	payload := string(payloadBytes)
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(purpose, payload))) {
+		return 0, internal.ErrInvalidSignature
+	}
+
+	userIDStr, nonce, ok := strings.Cut(payload, ".")
+	if !ok {
+		return 0, internal.ErrInvalidToken
+	}
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		return 0, internal.ErrInvalidToken
+	}
+
+	deleted, err := consumeVerificationScript.Run(ctx, s.client, []string{s.generateKey(purpose, userID)}, nonce).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to consume verification token: %w", err)
+	}
+	if deleted == 0 {
+		return 0, internal.ErrInvalidToken
+	}
+
+	return userID, nil
+}
+
+func (s *VerificationTokenStore) sign(purpose, payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(purpose + ":" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *VerificationTokenStore) generateKey(purpose string, userID int64) string {
+	return fmt.Sprintf("verification:%s:user:%d", purpose, userID)
+}
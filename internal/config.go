@@ -20,11 +20,17 @@ type Config struct {
 	RateLimit    RateLimitConfig    `mapstructure:"rate_limit"`
 	Redis        RedisConfig        `mapstructure:"redis"`
 	Swagger      SwaggerConfig      `mapstructure:"swagger"`
+	Booking      BookingConfig      `mapstructure:"booking"`
+	Payment      PaymentConfig      `mapstructure:"payment"`
+	Idempotency  IdempotencyConfig  `mapstructure:"idempotency"`
+	Dashboard    DashboardConfig    `mapstructure:"dashboard"`
+	Verification VerificationConfig `mapstructure:"verification"`
 }
 
 type HTTPServerConfig struct {
 	Port              int           `mapstructure:"port"`
 	AllowedOrigins    string        `mapstructure:"allowed_origins"`
+	TrustedProxies    string        `mapstructure:"trusted_proxies"` // Space separated IPs or CIDRs of the proxies allowed to set X-Forwarded-For
 	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
 	ReadTimeout       time.Duration `mapstructure:"read_timeout"`
 	IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
@@ -38,6 +44,7 @@ type AuthConfig struct {
 	JWTSecretEncoded          string        `mapstructure:"jwt_secret_encoded"`
 	RefreshTokenSecretEncoded string        `mapstructure:"refresh_token_secret_encoded"`
 	Issuer                    string        `mapstructure:"issuer"`
+	VerificationSecretEncoded string        `mapstructure:"verification_secret_encoded"` // Signs email verification tokens, defaults to the JWT secret
 }
 
 type DatabaseConfig struct {
@@ -54,7 +61,7 @@ type DatabaseConfig struct {
 }
 
 type NotificationConfig struct {
-	Provider       string         `mapstructure:"provider"`
+	Provider       string         `mapstructure:"provider"`       // smtp or log, required
 	AppURL         string         `mapstructure:"app_url"`        // Web URL (fallback)
 	MobileAppURL   string         `mapstructure:"mobile_app_url"` // Deep link for mobile app
 	RequestTimeout time.Duration  `mapstructure:"request_timeout"`
@@ -62,6 +69,8 @@ type NotificationConfig struct {
 	SMTP           SMTPConfig     `mapstructure:"smtp"`
 	SendGrid       SendGridConfig `mapstructure:"sendgrid"`
 	Resend         ResendConfig   `mapstructure:"resend"`
+	WhatsApp       WhatsAppConfig `mapstructure:"whatsapp"`
+	SMS            SMSConfig      `mapstructure:"sms"`
 }
 
 type SMTPConfig struct {
@@ -89,6 +98,23 @@ type ResendConfig struct {
 	MaxRetries     int           `mapstructure:"max_retries"`
 }
 
+type WhatsAppConfig struct {
+	APIURL         string        `mapstructure:"api_url"` // WhatsApp Cloud API base URL
+	PhoneNumberID  string        `mapstructure:"phone_number_id"`
+	AccessToken    string        `mapstructure:"access_token"`
+	OTPTemplate    string        `mapstructure:"otp_template"` // Approved authentication template carrying the code
+	LanguageCode   string        `mapstructure:"language_code"`
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+}
+
+type SMSConfig struct {
+	APIURL         string        `mapstructure:"api_url"` // Twilio compatible messages API base URL
+	AccountSID     string        `mapstructure:"account_sid"`
+	AuthToken      string        `mapstructure:"auth_token"`
+	From           string        `mapstructure:"from"`
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+}
+
 type FirebaseConfig struct {
 	ProjectID            string        `mapstructure:"project_id"`
 	ServiceAccountPath   string        `mapstructure:"service_account_path"`
@@ -137,6 +163,88 @@ type RateLimitConfig struct {
 	} `mapstructure:"authenticated_endpoints"`
 }
 
+type BookingConfig struct {
+	Cancellation CancellationConfig `mapstructure:"cancellation"`
+	Hold         HoldConfig         `mapstructure:"hold"`
+	Waitlist     WaitlistConfig     `mapstructure:"waitlist"`
+}
+
+type CancellationConfig struct {
+	FullRefundWindow     time.Duration `mapstructure:"full_refund_window"`    // Full refund when cancelled at least this long before the first session
+	PartialRefundWindow  time.Duration `mapstructure:"partial_refund_window"` // Partial refund when cancelled at least this long before the first session
+	PartialRefundPercent float64       `mapstructure:"partial_refund_percent"`
+}
+
+type HoldConfig struct {
+	Window        time.Duration `mapstructure:"window"`         // How long an unpaid booking keeps its slots
+	SweepInterval time.Duration `mapstructure:"sweep_interval"` // How often the worker looks for expired holds
+	BatchSize     int           `mapstructure:"batch_size"`
+}
+
+type WaitlistConfig struct {
+	OfferWindow   time.Duration `mapstructure:"offer_window"`   // How long a waitlisted parent has to claim a freed seat
+	SweepInterval time.Duration `mapstructure:"sweep_interval"` // How often the worker expires offers and hands out freed seats
+}
+
+type PaymentConfig struct {
+	Gateway   string         `mapstructure:"gateway"`    // midtrans or fake, required
+	AllowFake bool           `mapstructure:"allow_fake"` // Lets the fake gateway be selected, local development only
+	Expiry    time.Duration  `mapstructure:"expiry"`     // How long a payment can be completed after it is created
+	Midtrans  MidtransConfig `mapstructure:"midtrans"`
+	Refund    RefundConfig   `mapstructure:"refund"`
+}
+
+type RefundConfig struct {
+	MaxAttempts   int           `mapstructure:"max_attempts"`   // Gateway attempts before a refund is left for manual follow-up
+	RetryBackoff  time.Duration `mapstructure:"retry_backoff"`  // Wait before the first retry, doubled after each failed attempt
+	SweepInterval time.Duration `mapstructure:"sweep_interval"` // How often the worker retries due refunds
+	BatchSize     int           `mapstructure:"batch_size"`
+}
+
+type MidtransConfig struct {
+	ServerKey      string        `mapstructure:"server_key"`
+	ClientKey      string        `mapstructure:"client_key"`
+	IsProduction   bool          `mapstructure:"is_production"`
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+}
+
+type IdempotencyConfig struct {
+	TTL     time.Duration `mapstructure:"ttl"`      // How long a completed response is replayed
+	LockTTL time.Duration `mapstructure:"lock_ttl"` // How long a key stays claimed while its first request runs
+}
+
+type DashboardConfig struct {
+	CacheTTL time.Duration `mapstructure:"cache_ttl"` // How long a vendor dashboard is served from Redis, zero disables caching
+}
+
+type VerificationConfig struct {
+	Email         EmailVerificationConfig `mapstructure:"email"`
+	Phone         PhoneVerificationConfig `mapstructure:"phone"`
+	PasswordReset PasswordResetConfig     `mapstructure:"password_reset"`
+}
+
+type EmailVerificationConfig struct {
+	TokenTTL       time.Duration `mapstructure:"token_ttl"`       // How long a verification link stays valid
+	ResendCooldown time.Duration `mapstructure:"resend_cooldown"` // Minimum wait between two resend requests of a user
+	MaxResends     int           `mapstructure:"max_resends"`     // Resend requests allowed per user within ResendWindow
+	ResendWindow   time.Duration `mapstructure:"resend_window"`
+}
+
+type PhoneVerificationConfig struct {
+	Channel        string        `mapstructure:"channel"`         // whatsapp, sms or stub, required
+	CodeTTL        time.Duration `mapstructure:"code_ttl"`        // How long a one-time code can be confirmed
+	MaxAttempts    int           `mapstructure:"max_attempts"`    // Wrong codes allowed before the code is discarded
+	ResendCooldown time.Duration `mapstructure:"resend_cooldown"` // Minimum wait between two code requests of a user
+	MaxRequests    int           `mapstructure:"max_requests"`    // Codes sent to one number within RequestWindow
+	RequestWindow  time.Duration `mapstructure:"request_window"`
+}
+
+type PasswordResetConfig struct {
+	TokenTTL      time.Duration `mapstructure:"token_ttl"`    // How long a reset link stays valid
+	MaxRequests   int           `mapstructure:"max_requests"` // Reset emails sent to one account within RequestWindow
+	RequestWindow time.Duration `mapstructure:"request_window"`
+}
+
 type SwaggerConfig struct {
 	Enable bool `mapstructure:"enable"`
 }
@@ -149,10 +257,21 @@ func (h HTTPServerConfig) GetRefreshTokenSecret() ([]byte, error) {
 	return base64.StdEncoding.DecodeString(h.AuthConfig.RefreshTokenSecretEncoded)
 }
 
+func (h HTTPServerConfig) GetVerificationSecret() ([]byte, error) {
+	if h.AuthConfig.VerificationSecretEncoded == "" {
+		return h.GetJWTSecret()
+	}
+	return base64.StdEncoding.DecodeString(h.AuthConfig.VerificationSecretEncoded)
+}
+
 func (h *HTTPServerConfig) GetAllowedOrigins() []string {
 	return strings.Split(h.AllowedOrigins, " ")
 }
 
+func (h *HTTPServerConfig) GetTrustedProxies() []string {
+	return strings.Fields(h.TrustedProxies)
+}
+
 func (c LoggerConfig) ParseSlogLevel() slog.Level {
 	switch strings.ToUpper(c.Level) {
 	case "INFO":
@@ -0,0 +1,41 @@
+package datamodel
+
+import "time"
+
+// AdminAction represents the admin_actions table
+type AdminAction struct {
+	ID         int64     `db:"id" gorm:"primaryKey,autoIncrement"`
+	AdminID    int64     `db:"admin_id"`
+	ActionType string    `db:"action_type"`
+	TargetType string    `db:"target_type"`
+	TargetID   int64     `db:"target_id"`
+	Reason     *string   `db:"reason"`
+	Metadata   *string   `db:"metadata"` // JSONB stored as string
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// TableName specifies the table name
+func (AdminAction) TableName() string {
+	return "admin_actions"
+}
+
+// Notification represents the notifications table
+type Notification struct {
+	ID           int64      `db:"id" gorm:"primaryKey,autoIncrement"`
+	UserID       int64      `db:"user_id"`
+	Type         string     `db:"type"`
+	Channel      string     `db:"channel"` // email, whatsapp, sms
+	Recipient    string     `db:"recipient"`
+	Subject      *string    `db:"subject"`
+	Message      string     `db:"message"`
+	BookingID    *int64     `db:"booking_id"`
+	SentAt       *time.Time `db:"sent_at"`
+	Status       string     `db:"status"` // pending, sent, failed
+	ErrorMessage *string    `db:"error_message"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// TableName specifies the table name
+func (Notification) TableName() string {
+	return "notifications"
+}
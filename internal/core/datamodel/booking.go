@@ -4,21 +4,27 @@ import "time"
 
 // Booking represents the bookings table
 type Booking struct {
-	ID             int64      `db:"id" gorm:"primaryKey,autoIncrement"`
-	BookingNumber  string     `db:"booking_number" gorm:"uniqueIndex"`
-	ParentID       int64      `db:"parent_id"`
-	ChildID        int64      `db:"child_id"`
-	ServiceID      int64      `db:"service_id"`
-	VendorID       int64      `db:"vendor_id"`
-	BookingType    string     `db:"booking_type"` // trial, single, package_4, package_8, package_12
-	TotalSessions  int        `db:"total_sessions"`
-	TotalAmount    float64    `db:"total_amount"`
-	Status         string     `db:"status"` // pending, confirmed, cancelled, completed
-	PreferredCoach *int64     `db:"preferred_coach"`
-	ParentNotes    *string    `db:"parent_notes"`
-	Version        int        `db:"version" gorm:"default:1"` // Optimistic locking
-	CreatedAt      time.Time  `db:"created_at"`
-	UpdatedAt      time.Time  `db:"updated_at"`
+	ID                 int64      `db:"id" gorm:"primaryKey,autoIncrement"`
+	BookingNumber      string     `db:"booking_number" gorm:"uniqueIndex"`
+	ParentID           int64      `db:"parent_id"`
+	ChildID            int64      `db:"child_id"`
+	ServiceID          int64      `db:"service_id"`
+	VendorID           int64      `db:"vendor_id"`
+	BookingType        string     `db:"booking_type"` // trial, single, package_4, package_8, package_12
+	TotalSessions      int        `db:"total_sessions"`
+	TotalAmount        float64    `db:"total_amount"`
+	Status             string     `db:"status"`                               // pending, confirmed, ongoing, cancelled, completed
+	PaymentStatus      string     `db:"payment_status" gorm:"default:unpaid"` // unpaid, paid, refunded
+	PreferredCoach     *int64     `db:"coach_id" gorm:"column:coach_id"`
+	CompletedSessions  int        `db:"completed_sessions" gorm:"default:0"`
+	ParentNotes        *string    `db:"parent_notes"`
+	CancellationReason *string    `db:"cancellation_reason"`
+	CancelledBy        *string    `db:"cancelled_by"` // parent, vendor, system
+	CancelledAt        *time.Time `db:"cancelled_at"`
+	RefundAmount       *float64   `db:"refund_amount"`
+	Version            int        `db:"version" gorm:"default:1"` // Optimistic locking
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
 }
 
 // TableName specifies the table name
@@ -28,17 +34,81 @@ func (Booking) TableName() string {
 
 // BookingSession represents the booking_sessions table
 type BookingSession struct {
-	ID          int64     `db:"id" gorm:"primaryKey,autoIncrement"`
-	BookingID   int64     `db:"booking_id"`
-	ScheduleID  int64     `db:"schedule_id"`
-	SessionDate time.Time `db:"session_date"`
-	Status      string    `db:"status"` // scheduled, completed, cancelled, no_show
-	CoachID     *int64    `db:"coach_id"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID            int64      `db:"id" gorm:"primaryKey,autoIncrement"`
+	BookingID     int64      `db:"booking_id"`
+	ScheduleID    int64      `db:"schedule_id"`
+	SessionNumber int        `db:"session_number"`
+	SessionDate   time.Time  `db:"session_date"`
+	StartTime     string     `db:"start_time"` // HH:MM:SS format
+	EndTime       string     `db:"end_time"`   // HH:MM:SS format
+	Status        string     `db:"status"`     // scheduled, completed, cancelled, no_show
+	CoachID       *int64     `db:"coach_id"`
+	Attended      bool       `db:"attended"`
+	CoachNotes    *string    `db:"coach_notes"`
+	Rating        *int       `db:"rating"`
+	CompletedAt   *time.Time `db:"completed_at"`
+	CompletedBy   *int64     `db:"completed_by"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
 }
 
 // TableName specifies the table name
 func (BookingSession) TableName() string {
 	return "booking_sessions"
 }
+
+// BookingSessionReschedule represents the booking_session_reschedules table
+type BookingSessionReschedule struct {
+	ID               int64     `db:"id" gorm:"primaryKey,autoIncrement"`
+	BookingID        int64     `db:"booking_id"`
+	BookingSessionID int64     `db:"booking_session_id"`
+	FromScheduleID   int64     `db:"from_schedule_id"`
+	FromSessionDate  time.Time `db:"from_session_date"`
+	ToScheduleID     int64     `db:"to_schedule_id"`
+	ToSessionDate    time.Time `db:"to_session_date"`
+	RescheduledBy    int64     `db:"rescheduled_by"`
+	Reason           *string   `db:"reason"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// TableName specifies the table name
+func (BookingSessionReschedule) TableName() string {
+	return "booking_session_reschedules"
+}
+
+// BookingStatusHistory represents the booking_status_history table
+type BookingStatusHistory struct {
+	ID         int64     `db:"id" gorm:"primaryKey,autoIncrement"`
+	BookingID  int64     `db:"booking_id"`
+	FromStatus *string   `db:"from_status"`
+	ToStatus   string    `db:"to_status"`
+	ActorType  string    `db:"actor_type"` // parent, vendor, coach, admin, system
+	ActorID    *int64    `db:"actor_id"`
+	Reason     *string   `db:"reason"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// TableName specifies the table name
+func (BookingStatusHistory) TableName() string {
+	return "booking_status_history"
+}
+
+// WaitlistEntry represents the waitlist_entries table
+type WaitlistEntry struct {
+	ID               int64      `db:"id" gorm:"primaryKey,autoIncrement"`
+	ScheduleID       int64      `db:"schedule_id"`
+	SessionDate      time.Time  `db:"session_date"`
+	ParentID         int64      `db:"parent_id"`
+	ChildID          int64      `db:"child_id"`
+	Status           string     `db:"status"` // waiting, offered, claimed, expired, cancelled
+	OfferedAt        *time.Time `db:"offered_at"`
+	OfferExpiresAt   *time.Time `db:"offer_expires_at"`
+	ClaimedBookingID *int64     `db:"claimed_booking_id"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+}
+
+// TableName specifies the table name
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
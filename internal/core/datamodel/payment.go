@@ -0,0 +1,67 @@
+package datamodel
+
+import "time"
+
+// Payment represents the payments table
+type Payment struct {
+	ID              int64      `db:"id" gorm:"primaryKey,autoIncrement"`
+	BookingID       int64      `db:"booking_id"`
+	PaymentNumber   string     `db:"payment_number" gorm:"uniqueIndex"`
+	Amount          float64    `db:"amount"`
+	PaymentMethod   string     `db:"payment_method"`  // credit_card, bank_transfer, e_wallet, qris
+	PaymentGateway  string     `db:"payment_gateway"` // midtrans, fake
+	TransactionID   *string    `db:"transaction_id"`
+	PaymentURL      *string    `db:"payment_url"`
+	Status          string     `db:"status"` // pending, success, failed, expired, refunded
+	ExpiredAt       *time.Time `db:"expired_at"`
+	PaidAt          *time.Time `db:"paid_at"`
+	RefundedAt      *time.Time `db:"refunded_at"`
+	RefundAmount    *float64   `db:"refund_amount"`
+	GatewayResponse *string    `db:"gateway_response"` // JSONB stored as string
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+// TableName specifies the table name
+func (Payment) TableName() string {
+	return "payments"
+}
+
+// PaymentNotification represents the payment_notifications table
+type PaymentNotification struct {
+	ID                int64     `db:"id" gorm:"primaryKey,autoIncrement"`
+	PaymentID         int64     `db:"payment_id"`
+	TransactionID     string    `db:"transaction_id"`
+	TransactionStatus string    `db:"transaction_status"`
+	StatusCode        string    `db:"status_code"`
+	GrossAmount       string    `db:"gross_amount"`
+	Payload           string    `db:"payload"` // JSONB stored as string
+	Applied           bool      `db:"applied"`
+	CreatedAt         time.Time `db:"created_at"`
+}
+
+// TableName specifies the table name
+func (PaymentNotification) TableName() string {
+	return "payment_notifications"
+}
+
+// Refund represents the refunds table, a queue of refunds owed to parents
+type Refund struct {
+	ID            int64      `db:"id" gorm:"primaryKey,autoIncrement"`
+	BookingID     int64      `db:"booking_id"`
+	PaymentID     int64      `db:"payment_id"`
+	Amount        float64    `db:"amount"`
+	Reason        string     `db:"reason"`
+	Status        string     `db:"status"` // pending, succeeded, failed
+	Attempts      int        `db:"attempts"`
+	NextAttemptAt time.Time  `db:"next_attempt_at"`
+	LastError     *string    `db:"last_error"`
+	ProcessedAt   *time.Time `db:"processed_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
+
+// TableName specifies the table name
+func (Refund) TableName() string {
+	return "refunds"
+}
@@ -58,19 +58,22 @@ type Schedule struct {
 
 // Review represents the reviews table
 type Review struct {
-	ID                int64      `db:"id" gorm:"primaryKey,autoIncrement"`
-	BookingID         int64      `db:"booking_id"`
-	ServiceID         int64      `db:"service_id"`
-	ParentID          int64      `db:"parent_id"`
-	Rating            int        `db:"rating"` // 1-5
-	ReviewText        *string    `db:"review_text"`
-	DidChildEnjoy     *bool      `db:"did_child_enjoy"`
-	WouldRecommend    bool       `db:"would_recommend"`
-	Photos            *string    `db:"photos"` // JSONB
-	VendorResponse    *string    `db:"vendor_response"`
-	VendorRespondedAt *time.Time `db:"vendor_responded_at"`
-	CreatedAt         time.Time  `db:"created_at"`
-	UpdatedAt         time.Time  `db:"updated_at"`
+	ID             int64      `db:"id" gorm:"primaryKey,autoIncrement"`
+	BookingID      int64      `db:"booking_id"`
+	ParentID       int64      `db:"parent_id"`
+	VendorID       int64      `db:"vendor_id"`
+	ServiceID      int64      `db:"service_id"`
+	CoachID        *int64     `db:"coach_id"`
+	Rating         int        `db:"rating"` // 1-5
+	ReviewText     *string    `db:"review_text"`
+	ChildEnjoyed   *bool      `db:"child_enjoyed"`
+	WouldRecommend *bool      `db:"would_recommend"`
+	Photos         *string    `db:"photos"` // JSONB
+	VendorResponse *string    `db:"vendor_response"`
+	RespondedAt    *time.Time `db:"responded_at"`
+	IsApproved     bool       `db:"is_approved" gorm:"default:true"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
 }
 
 // ServiceCoach represents the service_coaches join table
@@ -35,35 +35,37 @@ type ParentProfile struct {
 
 // Vendor represents the vendors table
 type Vendor struct {
-	ID              int64      `db:"id" gorm:"primaryKey,autoIncrement"`
-	UserID          int64      `db:"user_id" gorm:"foreignKey:UserID"`
-	BusinessName    string     `db:"business_name"`
-	Description     *string    `db:"description"`
-	BusinessType    string     `db:"business_type"` // swimming_school, tutoring_center, art_studio, individual_coach
-	Phone           string     `db:"phone"`
-	Whatsapp        *string    `db:"whatsapp"`
-	Address         string     `db:"address"`
-	City            string     `db:"city"`
-	District        *string    `db:"district"`
-	PostalCode      *string    `db:"postal_code"`
-	Latitude        *float64   `db:"latitude"`
-	Longitude       *float64   `db:"longitude"`
-	GoogleMapsURL   *string    `db:"google_maps_url"`
-	Logo            *string    `db:"logo"`
-	CoverImage      *string    `db:"cover_image"`
-	Photos          *string    `db:"photos"`    // JSONB stored as string
-	Amenities       *string    `db:"amenities"` // JSONB stored as string
-	BusinessLicense *string    `db:"business_license"`
-	Status          string     `db:"status"` // pending, active, suspended, rejected
-	RejectionReason *string    `db:"rejection_reason"`
-	RatingAvg       float64    `db:"rating_avg"`
-	TotalReviews    int        `db:"total_reviews"`
-	TotalBookings   int        `db:"total_bookings"`
-	Verified        bool       `db:"verified"`
-	VerifiedAt      *time.Time `db:"verified_at"`
-	Version         int        `db:"version" gorm:"default:1"` // Optimistic locking
-	CreatedAt       time.Time  `db:"created_at"`
-	UpdatedAt       time.Time  `db:"updated_at"`
+	ID                       int64      `db:"id" gorm:"primaryKey,autoIncrement"`
+	UserID                   int64      `db:"user_id" gorm:"foreignKey:UserID"`
+	BusinessName             string     `db:"business_name"`
+	Description              *string    `db:"description"`
+	BusinessType             string     `db:"business_type"` // swimming_school, tutoring_center, art_studio, individual_coach
+	Phone                    string     `db:"phone"`
+	Whatsapp                 *string    `db:"whatsapp"`
+	Address                  string     `db:"address"`
+	City                     string     `db:"city"`
+	District                 *string    `db:"district"`
+	PostalCode               *string    `db:"postal_code"`
+	Latitude                 *float64   `db:"latitude"`
+	Longitude                *float64   `db:"longitude"`
+	GoogleMapsURL            *string    `db:"google_maps_url"`
+	Logo                     *string    `db:"logo"`
+	CoverImage               *string    `db:"cover_image"`
+	Photos                   *string    `db:"photos"`    // JSONB stored as string
+	Amenities                *string    `db:"amenities"` // JSONB stored as string
+	BusinessLicense          *string    `db:"business_license"`
+	Status                   string     `db:"status"` // pending, active, suspended, rejected
+	RejectionReason          *string    `db:"rejection_reason"`
+	RatingAvg                float64    `db:"rating_avg"`
+	TotalReviews             int        `db:"total_reviews"`
+	TotalBookings            int        `db:"total_bookings"`
+	Verified                 bool       `db:"verified"`
+	VerifiedAt               *time.Time `db:"verified_at"`
+	RescheduleCutoffHours    int        `db:"reschedule_cutoff_hours" gorm:"default:12"`
+	MaxReschedulesPerBooking int        `db:"max_reschedules_per_booking" gorm:"default:2"`
+	Version                  int        `db:"version" gorm:"default:1"` // Optimistic locking
+	CreatedAt                time.Time  `db:"created_at"`
+	UpdatedAt                time.Time  `db:"updated_at"`
 }
 
 type Children struct {
@@ -143,6 +143,10 @@ func NewBusinessRuleError(message string, err error) *AppError {
 	return NewAppError("BUSINESS_RULE_VIOLATION", message, http.StatusUnprocessableEntity, err)
 }
 
+func NewRateLimitError(message string) *AppError {
+	return NewAppError("RATE_LIMIT_EXCEEDED", message, http.StatusTooManyRequests, ErrRateLimitExceeded)
+}
+
 // Error helpers
 func IsAppError(err error) bool {
 	var appErr *AppError
@@ -249,6 +253,8 @@ func HandleEndpointError(w http.ResponseWriter, r *http.Request, err error) {
 	case errors.Is(err, ErrForbiddenToUseApplication),
 		errors.Is(err, ErrForbidden):
 		httpCode = http.StatusForbidden
+	case errors.Is(err, ErrRateLimitExceeded):
+		httpCode = http.StatusTooManyRequests
 	}
 
 	var maxErr *http.MaxBytesError
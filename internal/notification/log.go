@@ -0,0 +1,23 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogSender writes the recipient and subject of messages to the application log instead of delivering them.
+// Meant for local development. Bodies carry verification and reset links, so they are never logged.
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// Send logs who the message was for
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	slog.InfoContext(ctx, "Notification not delivered, log sender in use",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+	)
+	return nil
+}
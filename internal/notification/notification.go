@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+const (
+	ProviderSMTP = "smtp"
+	ProviderLog  = "log"
+)
+
+// Message is an email to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string // plain text
+}
+
+// Sender delivers messages to users
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSender creates the sender selected in config. The provider is required, the log sender
+// delivers nothing and has to be chosen on purpose.
+func NewSender(cfg internal.NotificationConfig) (Sender, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, fmt.Errorf("notification.provider is required")
+	case ProviderLog:
+		return NewLogSender(), nil
+	case ProviderSMTP:
+		if cfg.SMTP.Host == "" {
+			return nil, fmt.Errorf("notification.smtp.host is required")
+		}
+		if cfg.SMTP.From == "" {
+			return nil, fmt.Errorf("notification.smtp.from is required")
+		}
+		return NewSMTPSender(cfg.SMTP), nil
+	}
+	return nil, fmt.Errorf("unknown notification provider %q", cfg.Provider)
+}
@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+const (
+	ChannelWhatsApp = "whatsapp"
+	ChannelSMS      = "sms"
+	ChannelStub     = "stub"
+	ChannelLog      = "log" // same as stub, matching the log notification provider
+)
+
+// OTPChannel delivers one-time codes to a phone number in E.164
+type OTPChannel interface {
+	Name() string
+	SendCode(ctx context.Context, phone, code string, ttl time.Duration) error
+}
+
+// NewOTPChannel creates the channel selected for phone verification. The channel is required, the stub
+// channel delivers nothing and has to be chosen on purpose. Provider credentials are only required once
+// a provider is selected.
+func NewOTPChannel(channel string, cfg internal.NotificationConfig) (OTPChannel, error) {
+	switch channel {
+	case "":
+		return nil, fmt.Errorf("verification.phone.channel is required")
+	case ChannelStub, ChannelLog:
+		return NewStubChannel(), nil
+	case ChannelWhatsApp:
+		if cfg.WhatsApp.PhoneNumberID == "" || cfg.WhatsApp.AccessToken == "" {
+			return nil, fmt.Errorf("notification.whatsapp.phone_number_id and access_token are required")
+		}
+		if cfg.WhatsApp.OTPTemplate == "" {
+			return nil, fmt.Errorf("notification.whatsapp.otp_template is required")
+		}
+		return NewWhatsAppChannel(cfg.WhatsApp), nil
+	case ChannelSMS:
+		if cfg.SMS.AccountSID == "" || cfg.SMS.AuthToken == "" || cfg.SMS.From == "" {
+			return nil, fmt.Errorf("notification.sms.account_sid, auth_token and from are required")
+		}
+		return NewSMSChannel(cfg.SMS), nil
+	}
+	return nil, fmt.Errorf("unknown otp channel %q", channel)
+}
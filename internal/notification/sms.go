@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+const (
+	defaultSMSAPIURL  = "https://api.twilio.com/2010-04-01"
+	defaultSMSTimeout = 10 * time.Second
+)
+
+// SMSChannel sends one-time codes as text messages through a Twilio compatible messages API
+type SMSChannel struct {
+	apiURL     string
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+func NewSMSChannel(cfg internal.SMSConfig) *SMSChannel {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultSMSAPIURL
+	}
+	timeout := cfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultSMSTimeout
+	}
+
+	return &SMSChannel{
+		apiURL:     strings.TrimRight(apiURL, "/"),
+		accountSID: cfg.AccountSID,
+		authToken:  cfg.AuthToken,
+		from:       cfg.From,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the channel name
+func (c *SMSChannel) Name() string {
+	return ChannelSMS
+}
+
+// SendCode texts the code to the phone
+func (c *SMSChannel) SendCode(ctx context.Context, phone, code string, ttl time.Duration) error {
+	form := url.Values{}
+	form.Set("To", phone)
+	form.Set("From", c.from)
+	form.Set("Body", fmt.Sprintf("Your Jadiles verification code is %s. It expires in %d minutes. Do not share this code with anyone.",
+		code, int(math.Ceil(ttl.Minutes()))))
+
+	endpoint := c.apiURL + "/Accounts/" + url.PathEscape(c.accountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.accountSID, c.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("sms: unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
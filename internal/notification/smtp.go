@@ -0,0 +1,142 @@
+package notification
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+const defaultSMTPTimeout = 10 * time.Second
+
+// SMTPSender delivers messages through an SMTP server.
+// With use_tls the connection is TLS from the start (usually port 465); otherwise STARTTLS is
+// used when the server offers it (usually port 587).
+type SMTPSender struct {
+	addr       string
+	host       string
+	from       string
+	auth       smtp.Auth
+	useTLS     bool
+	timeout    time.Duration
+	maxRetries int
+}
+
+// NewSMTPSender creates an SMTP sender, authenticating with PLAIN when a username is set
+func NewSMTPSender(cfg internal.SMTPConfig) *SMTPSender {
+	timeout := cfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultSMTPTimeout
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &SMTPSender{
+		addr:       net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host:       cfg.Host,
+		from:       cfg.From,
+		auth:       auth,
+		useTLS:     cfg.UseTLS,
+		timeout:    timeout,
+		maxRetries: cfg.MaxRetries,
+	}
+}
+
+// Send delivers the message, retrying failed attempts up to max_retries times
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	var err error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+
+		if err = s.send(ctx, msg); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("smtp: failed to send to %s: %w", msg.To, err)
+}
+
+func (s *SMTPSender) send(ctx context.Context, msg *Message) error {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if s.useTLS {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.host})
+	}
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if !s.useTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.buildMessage(msg)); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// buildMessage renders the headers and plain text body
+func (s *SMTPSender) buildMessage(msg *Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
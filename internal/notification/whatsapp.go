@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+const (
+	defaultWhatsAppAPIURL   = "https://graph.facebook.com/v21.0"
+	defaultWhatsAppLanguage = "id"
+	defaultWhatsAppTimeout  = 10 * time.Second
+)
+
+// WhatsAppChannel sends one-time codes through the WhatsApp Cloud API. Business-initiated
+// messages must use an approved template, so the code is sent as the parameter of an
+// authentication template, which also fills its copy-code button.
+type WhatsAppChannel struct {
+	apiURL        string
+	phoneNumberID string
+	accessToken   string
+	template      string
+	languageCode  string
+	client        *http.Client
+}
+
+func NewWhatsAppChannel(cfg internal.WhatsAppConfig) *WhatsAppChannel {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultWhatsAppAPIURL
+	}
+	languageCode := cfg.LanguageCode
+	if languageCode == "" {
+		languageCode = defaultWhatsAppLanguage
+	}
+	timeout := cfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultWhatsAppTimeout
+	}
+
+	return &WhatsAppChannel{
+		apiURL:        strings.TrimRight(apiURL, "/"),
+		phoneNumberID: cfg.PhoneNumberID,
+		accessToken:   cfg.AccessToken,
+		template:      cfg.OTPTemplate,
+		languageCode:  languageCode,
+		client:        &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the channel name
+func (c *WhatsAppChannel) Name() string {
+	return ChannelWhatsApp
+}
+
+type whatsAppMessage struct {
+	MessagingProduct string           `json:"messaging_product"`
+	To               string           `json:"to"`
+	Type             string           `json:"type"`
+	Template         whatsAppTemplate `json:"template"`
+}
+
+type whatsAppTemplate struct {
+	Name       string              `json:"name"`
+	Language   whatsAppLanguage    `json:"language"`
+	Components []whatsAppComponent `json:"components"`
+}
+
+type whatsAppLanguage struct {
+	Code string `json:"code"`
+}
+
+type whatsAppComponent struct {
+	Type       string              `json:"type"`
+	SubType    string              `json:"sub_type,omitempty"`
+	Index      string              `json:"index,omitempty"`
+	Parameters []whatsAppParameter `json:"parameters"`
+}
+
+type whatsAppParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SendCode sends the code to the phone's WhatsApp account
+func (c *WhatsAppChannel) SendCode(ctx context.Context, phone, code string, _ time.Duration) error {
+	codeParam := []whatsAppParameter{{Type: "text", Text: code}}
+	body, err := json.Marshal(whatsAppMessage{
+		MessagingProduct: "whatsapp",
+		To:               strings.TrimPrefix(phone, "+"),
+		Type:             "template",
+		Template: whatsAppTemplate{
+			Name:     c.template,
+			Language: whatsAppLanguage{Code: c.languageCode},
+			Components: []whatsAppComponent{
+				{Type: "body", Parameters: codeParam},
+				{Type: "button", SubType: "url", Index: "0", Parameters: codeParam},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+"/"+c.phoneNumberID+"/messages", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("whatsapp: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("whatsapp: unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/common"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+)
+
+// CreatePaymentParams represents the HTTP request body for starting a payment
+type CreatePaymentParams struct {
+	PaymentMethod string `json:"payment_method" validate:"required,oneof=credit_card bank_transfer e_wallet qris"`
+	Option        string `json:"option,omitempty" validate:"omitempty,max=30"` // e.g. bca, gopay
+}
+
+// NewCreatePaymentParams parses the create payment request
+func NewCreatePaymentParams(r *http.Request) (*CreatePaymentParams, error) {
+	var params CreatePaymentParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the create payment parameters
+func (p *CreatePaymentParams) Validate(ctx context.Context) error {
+	if err := common.ValidateStruct(p); err != nil {
+		return err
+	}
+
+	if p.Option == "" {
+		return nil
+	}
+
+	// The option must be one offered for the chosen method
+	for _, method := range AvailableMethods() {
+		if string(method.Code) != p.PaymentMethod {
+			continue
+		}
+		for _, option := range method.Options {
+			if option == p.Option {
+				return nil
+			}
+		}
+	}
+
+	return internal.NewValidationError("option is not available for the selected payment_method")
+}
+
+// CallbackParams represents a payment notification sent by the gateway
+type CallbackParams struct {
+	OrderID           string `json:"order_id" validate:"required"`
+	StatusCode        string `json:"status_code" validate:"required"`
+	TransactionStatus string `json:"transaction_status" validate:"required"`
+	GrossAmount       string `json:"gross_amount" validate:"required"`
+	TransactionID     string `json:"transaction_id" validate:"required"`
+	FraudStatus       string `json:"fraud_status"`
+	PaymentType       string `json:"payment_type"`
+	SignatureKey      string `json:"signature_key" validate:"required"`
+}
+
+// NewCallbackParams parses a gateway notification
+func NewCallbackParams(body []byte) (*CallbackParams, error) {
+	var params CallbackParams
+	if err := json.Unmarshal(body, &params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the notification fields
+func (p *CallbackParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToNotification converts the callback body to a domain notification
+func (p *CallbackParams) ToNotification() *Notification {
+	return &Notification{
+		OrderID:           p.OrderID,
+		StatusCode:        p.StatusCode,
+		TransactionStatus: p.TransactionStatus,
+		GrossAmount:       p.GrossAmount,
+		TransactionID:     p.TransactionID,
+		FraudStatus:       p.FraudStatus,
+		PaymentType:       p.PaymentType,
+		SignatureKey:      p.SignatureKey,
+	}
+}
+
+// newCallbackBody encodes a notification the way the gateway posts it to the callback
+func newCallbackBody(n *Notification) ([]byte, error) {
+	return json.Marshal(&CallbackParams{
+		OrderID:           n.OrderID,
+		StatusCode:        n.StatusCode,
+		TransactionStatus: n.TransactionStatus,
+		GrossAmount:       n.GrossAmount,
+		TransactionID:     n.TransactionID,
+		FraudStatus:       n.FraudStatus,
+		PaymentType:       n.PaymentType,
+		SignatureKey:      n.SignatureKey,
+	})
+}
+
+// PaymentResponse is the response for a created or resumed payment
+type PaymentResponse struct {
+	Data    v1.Payment `json:"data"`
+	Message string     `json:"message"`
+}
+
+// ToPaymentResponse converts a payment to response
+func ToPaymentResponse(p *Payment, message string) *PaymentResponse {
+	return &PaymentResponse{
+		Data:    ToV1Payment(p),
+		Message: message,
+	}
+}
+
+// ToV1Payment converts domain payment to API payment
+func ToV1Payment(p *Payment) v1.Payment {
+	method := string(p.Method)
+	status := v1.PaymentStatus(p.Status)
+
+	return v1.Payment{
+		PaymentNumber: &p.PaymentNumber,
+		Amount:        &p.Amount,
+		PaymentMethod: &method,
+		Status:        &status,
+		PaidAt:        p.PaidAt,
+		ExpiredAt:     p.ExpiredAt,
+		PaymentUrl:    p.PaymentURL,
+	}
+}
+
+// ToV1PaymentStatus converts a payment and its booking to status response
+func ToV1PaymentStatus(p *Payment, booking *BookingSummary) *v1.PaymentStatusResponse {
+	resp := &v1.PaymentStatusResponse{}
+
+	method := string(p.Method)
+	status := string(p.Status)
+	bookingStatus := v1.BookingStatus(booking.Status)
+
+	resp.Data.PaymentNumber = &p.PaymentNumber
+	resp.Data.Amount = &p.Amount
+	resp.Data.PaymentMethod = &method
+	resp.Data.Status = &status
+	resp.Data.PaidAt = p.PaidAt
+	resp.Data.Booking = &struct {
+		BookingNumber *string           `json:"booking_number,omitempty"`
+		Status        *v1.BookingStatus `json:"status,omitempty"`
+	}{
+		BookingNumber: &booking.BookingNumber,
+		Status:        &bookingStatus,
+	}
+
+	return resp
+}
+
+// ToV1PaymentMethods converts payment method options to response
+func ToV1PaymentMethods(methods []MethodOption) *v1.PaymentMethodsResponse {
+	data := make([]v1.PaymentMethod, 0, len(methods))
+	for _, m := range methods {
+		code := string(m.Code)
+		name := m.Name
+		icon := m.Icon
+		processingTime := m.ProcessingTime
+
+		method := v1.PaymentMethod{
+			Code:           &code,
+			Name:           &name,
+			Icon:           &icon,
+			ProcessingTime: &processingTime,
+		}
+		if len(m.Options) > 0 {
+			options := m.Options
+			method.Options = &options
+		}
+		data = append(data, method)
+	}
+
+	return &v1.PaymentMethodsResponse{Data: data}
+}
+
+// ToDataModel converts domain payment to data model
+func (p *Payment) ToDataModel() *datamodel.Payment {
+	return &datamodel.Payment{
+		ID:              p.ID,
+		BookingID:       p.BookingID,
+		PaymentNumber:   p.PaymentNumber,
+		Amount:          p.Amount,
+		PaymentMethod:   string(p.Method),
+		PaymentGateway:  p.Gateway,
+		TransactionID:   p.TransactionID,
+		PaymentURL:      p.PaymentURL,
+		Status:          string(p.Status),
+		ExpiredAt:       p.ExpiredAt,
+		PaidAt:          p.PaidAt,
+		RefundedAt:      p.RefundedAt,
+		RefundAmount:    p.RefundAmount,
+		GatewayResponse: p.GatewayResponse,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+// FromDataModel converts data model to domain payment
+func FromDataModel(dm *datamodel.Payment) *Payment {
+	return &Payment{
+		ID:              dm.ID,
+		BookingID:       dm.BookingID,
+		PaymentNumber:   dm.PaymentNumber,
+		Amount:          dm.Amount,
+		Method:          Method(dm.PaymentMethod),
+		Gateway:         dm.PaymentGateway,
+		TransactionID:   dm.TransactionID,
+		PaymentURL:      dm.PaymentURL,
+		Status:          Status(dm.Status),
+		ExpiredAt:       dm.ExpiredAt,
+		PaidAt:          dm.PaidAt,
+		RefundedAt:      dm.RefundedAt,
+		RefundAmount:    dm.RefundAmount,
+		GatewayResponse: dm.GatewayResponse,
+		CreatedAt:       dm.CreatedAt,
+		UpdatedAt:       dm.UpdatedAt,
+	}
+}
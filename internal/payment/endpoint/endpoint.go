@@ -0,0 +1,47 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/frahmantamala/jadiles/internal"
+	authpkg "github.com/frahmantamala/jadiles/internal/auth"
+	"github.com/frahmantamala/jadiles/internal/payment"
+	"github.com/frahmantamala/jadiles/internal/payment/postgresql"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+)
+
+// RegisterPaymentRoutes registers all payment-related routes
+func RegisterPaymentRoutes(
+	r chi.Router,
+	db *gorm.DB,
+	jwtAuth *authpkg.JWTAuthentication,
+	gateway payment.Gateway,
+	idempotent func(http.Handler) http.Handler,
+	config internal.Config,
+) error {
+	repo := postgresql.NewPaymentRepository(db)
+	paymentService := payment.NewService(repo, gateway, config.Payment, services.NewHoldPolicy(config.Booking.Hold))
+	paymentHandler := payment.NewHandler(paymentService)
+
+	// Public routes
+	r.Get("/payments/methods", paymentHandler.GetPaymentMethods)
+	r.Post("/payments/callback", paymentHandler.PaymentCallback)
+
+	// Protected routes (require authentication and parent role)
+	r.Group(func(r chi.Router) {
+		r.Use(jwtAuth.Authenticator)
+		r.Use(jwtAuth.RequireRole("parent"))
+
+		r.With(idempotent).Post("/bookings/{booking_id}/payments", paymentHandler.CreatePayment)
+		r.Get("/payments/{payment_number}/status", paymentHandler.GetPaymentStatus)
+
+		// Local development only: stands in for paying on the fake gateway's payment page
+		if _, ok := gateway.(*payment.FakeGateway); ok {
+			r.Post("/payments/{payment_number}/fake/{outcome}", paymentHandler.SimulateFakePayment)
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FakeGateway is an in-process gateway for local development and tests.
+// Payments stay pending until Settle, Fail or Expire is called, in development through
+// POST /payments/{payment_number}/fake/{outcome}. Notifications are signed the same way as Midtrans, see Notify.
+// Transactions live in the memory of the process that created them, so refunds of orders it does not know,
+// such as those retried by the booking worker, are accepted without checks.
+type FakeGateway struct {
+	mu           sync.Mutex
+	serverKey    string
+	transactions map[string]*fakeTransaction
+}
+
+type fakeTransaction struct {
+	TransactionID string  `json:"transaction_id"`
+	OrderID       string  `json:"order_id"`
+	Status        Status  `json:"status"`
+	GrossAmount   float64 `json:"gross_amount"`
+	RefundAmount  float64 `json:"refund_amount,omitempty"`
+}
+
+// NewFakeGateway creates an empty fake gateway that signs notifications with the given server key
+func NewFakeGateway(serverKey string) *FakeGateway {
+	return &FakeGateway{
+		serverKey:    serverKey,
+		transactions: make(map[string]*fakeTransaction),
+	}
+}
+
+// Name returns the gateway name
+func (g *FakeGateway) Name() string {
+	return GatewayFake
+}
+
+// CreateCharge registers a pending transaction
+func (g *FakeGateway) CreateCharge(_ context.Context, req *ChargeRequest) (*ChargeResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.transactions[req.OrderID]; exists {
+		return nil, fmt.Errorf("fake gateway: order %s already exists", req.OrderID)
+	}
+
+	txn := &fakeTransaction{
+		TransactionID: fmt.Sprintf("fake-%d", time.Now().UnixNano()),
+		OrderID:       req.OrderID,
+		Status:        StatusPending,
+		GrossAmount:   req.Amount,
+	}
+	g.transactions[req.OrderID] = txn
+
+	return &ChargeResult{
+		TransactionID: txn.TransactionID,
+		PaymentURL:    "https://fake-gateway.local/pay/" + req.OrderID,
+		Status:        StatusPending,
+		RawResponse:   txn.raw(),
+	}, nil
+}
+
+// GetStatus returns the current state of a transaction
+func (g *FakeGateway) GetStatus(_ context.Context, orderID string) (*StatusResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	txn, ok := g.transactions[orderID]
+	if !ok {
+		return nil, fmt.Errorf("fake gateway: order %s not found", orderID)
+	}
+
+	return &StatusResult{
+		OrderID:       txn.OrderID,
+		TransactionID: txn.TransactionID,
+		Status:        txn.Status,
+		GrossAmount:   strconv.FormatFloat(txn.GrossAmount, 'f', 2, 64),
+		RawResponse:   txn.raw(),
+	}, nil
+}
+
+// Refund refunds a settled transaction. Orders created by another process are refunded without checks.
+func (g *FakeGateway) Refund(_ context.Context, req *RefundRequest) (*RefundResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	txn, ok := g.transactions[req.OrderID]
+	if !ok {
+		txn = &fakeTransaction{
+			OrderID:      req.OrderID,
+			Status:       StatusRefunded,
+			RefundAmount: req.Amount,
+		}
+		return &RefundResult{
+			Status:      StatusRefunded,
+			RawResponse: txn.raw(),
+		}, nil
+	}
+	if txn.Status != StatusSuccess {
+		return nil, fmt.Errorf("fake gateway: order %s is %s, only settled payments can be refunded", req.OrderID, txn.Status)
+	}
+	if req.Amount > txn.GrossAmount {
+		return nil, fmt.Errorf("fake gateway: refund amount exceeds gross amount")
+	}
+
+	txn.Status = StatusRefunded
+	txn.RefundAmount = req.Amount
+
+	return &RefundResult{
+		Status:      StatusRefunded,
+		RawResponse: txn.raw(),
+	}, nil
+}
+
+// VerifyNotification checks the notification signature_key against the server key
+func (g *FakeGateway) VerifyNotification(n *Notification) error {
+	return verifyMidtransSignature(n, g.serverKey)
+}
+
+// Notify builds the signed notification the gateway would send for the current state of an order
+func (g *FakeGateway) Notify(orderID string) (*Notification, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	txn, ok := g.transactions[orderID]
+	if !ok {
+		return nil, fmt.Errorf("fake gateway: order %s not found", orderID)
+	}
+
+	n := &Notification{
+		OrderID:           txn.OrderID,
+		StatusCode:        "200",
+		TransactionStatus: fakeTransactionStatus(txn.Status),
+		GrossAmount:       strconv.FormatFloat(txn.GrossAmount, 'f', 2, 64),
+		TransactionID:     txn.TransactionID,
+		PaymentType:       "fake",
+	}
+	if txn.Status == StatusPending {
+		n.StatusCode = "201"
+	}
+	n.SignatureKey = MidtransSignature(n.OrderID, n.StatusCode, n.GrossAmount, g.serverKey)
+
+	return n, nil
+}
+
+// Settle marks a pending transaction as paid
+func (g *FakeGateway) Settle(orderID string) error {
+	return g.transition(orderID, StatusSuccess)
+}
+
+// Fail marks a pending transaction as failed
+func (g *FakeGateway) Fail(orderID string) error {
+	return g.transition(orderID, StatusFailed)
+}
+
+// Expire marks a pending transaction as expired
+func (g *FakeGateway) Expire(orderID string) error {
+	return g.transition(orderID, StatusExpired)
+}
+
+// track registers a pending transaction for an order created before this process started,
+// so a restarted server can still settle it
+func (g *FakeGateway) track(orderID, transactionID string, amount float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.transactions[orderID]; ok {
+		return
+	}
+	g.transactions[orderID] = &fakeTransaction{
+		TransactionID: transactionID,
+		OrderID:       orderID,
+		Status:        StatusPending,
+		GrossAmount:   amount,
+	}
+}
+
+func (g *FakeGateway) transition(orderID string, to Status) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	txn, ok := g.transactions[orderID]
+	if !ok {
+		return fmt.Errorf("fake gateway: order %s not found", orderID)
+	}
+	if txn.Status != StatusPending {
+		return fmt.Errorf("fake gateway: order %s is already %s", orderID, txn.Status)
+	}
+
+	txn.Status = to
+	return nil
+}
+
+func (t *fakeTransaction) raw() []byte {
+	raw, _ := json.Marshal(t)
+	return raw
+}
+
+// fakeTransactionStatus maps a payment status back to the Midtrans transaction_status
+func fakeTransactionStatus(status Status) string {
+	switch status {
+	case StatusSuccess:
+		return "settlement"
+	case StatusFailed:
+		return "deny"
+	case StatusExpired:
+		return "expire"
+	case StatusRefunded:
+		return "refund"
+	}
+	return "pending"
+}
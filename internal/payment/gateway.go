@@ -0,0 +1,36 @@
+package payment
+
+import (
+	"fmt"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+const (
+	GatewayMidtrans = "midtrans"
+	GatewayFake     = "fake"
+)
+
+// NewGateway creates the gateway selected in config. There is no default: the fake gateway settles
+// payments on request, so it is only built when payment.allow_fake is set for local development.
+// Both gateways sign notifications with payment.midtrans.server_key.
+func NewGateway(cfg internal.PaymentConfig) (Gateway, error) {
+	switch cfg.Gateway {
+	case "":
+		return nil, fmt.Errorf("payment.gateway is required")
+	case GatewayMidtrans:
+		if cfg.Midtrans.ServerKey == "" {
+			return nil, fmt.Errorf("payment.midtrans.server_key is required")
+		}
+		return NewMidtransGateway(cfg.Midtrans), nil
+	case GatewayFake:
+		if !cfg.AllowFake {
+			return nil, fmt.Errorf("payment gateway %q requires payment.allow_fake, it is for local development only", cfg.Gateway)
+		}
+		if cfg.Midtrans.ServerKey == "" {
+			return nil, fmt.Errorf("payment.midtrans.server_key is required")
+		}
+		return NewFakeGateway(cfg.Midtrans.ServerKey), nil
+	}
+	return nil, fmt.Errorf("unknown payment gateway %q", cfg.Gateway)
+}
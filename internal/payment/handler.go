@@ -0,0 +1,167 @@
+package payment
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/frahmantamala/jadiles/internal"
+	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// maxCallbackBodySize bounds gateway notifications, which are small JSON documents
+const maxCallbackBodySize = 64 << 10
+
+type ServiceAPI interface {
+	GetPaymentMethods(ctx context.Context) *v1.PaymentMethodsResponse
+	CreatePayment(ctx context.Context, parentID int64, bookingID int64, params *CreatePaymentParams) (*PaymentResponse, error)
+	GetPaymentStatus(ctx context.Context, parentID int64, paymentNumber string) (*v1.PaymentStatusResponse, error)
+	HandleCallback(ctx context.Context, notification *Notification, rawBody []byte) error
+	SimulateFakePayment(ctx context.Context, parentID int64, paymentNumber, outcome string) (*v1.PaymentStatusResponse, error)
+}
+
+type Handler struct {
+	service ServiceAPI
+}
+
+func NewHandler(service ServiceAPI) *Handler {
+	return &Handler{service: service}
+}
+
+// GetPaymentMethods handles GET /payments/methods
+func (h *Handler) GetPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, h.service.GetPaymentMethods(r.Context()))
+}
+
+// CreatePayment handles POST /bookings/{booking_id}/payments
+func (h *Handler) CreatePayment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated parent ID from JWT context
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	// Parse booking ID from URL
+	bookingID, err := strconv.ParseInt(chi.URLParam(r, "booking_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("booking_id must be a valid integer"))
+		return
+	}
+
+	// Parse and validate request
+	params, err := NewCreatePaymentParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.CreatePayment(ctx, parentID, bookingID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+// GetPaymentStatus handles GET /payments/{payment_number}/status
+func (h *Handler) GetPaymentStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated parent ID from JWT context
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	paymentNumber := chi.URLParam(r, "payment_number")
+	if paymentNumber == "" {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("payment_number is required"))
+		return
+	}
+
+	response, err := h.service.GetPaymentStatus(ctx, parentID, paymentNumber)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// SimulateFakePayment handles POST /payments/{payment_number}/fake/{outcome}, registered only
+// while the fake gateway is configured
+func (h *Handler) SimulateFakePayment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated parent ID from JWT context
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	paymentNumber := chi.URLParam(r, "payment_number")
+	if paymentNumber == "" {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("payment_number is required"))
+		return
+	}
+
+	response, err := h.service.SimulateFakePayment(ctx, parentID, paymentNumber, chi.URLParam(r, "outcome"))
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// PaymentCallback handles POST /payments/callback sent by the payment gateway
+func (h *Handler) PaymentCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Keep the raw body, it is stored as the gateway response
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackBodySize))
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("Invalid request body"))
+		return
+	}
+
+	params, err := NewCallbackParams(body)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := h.service.HandleCallback(ctx, params.ToNotification(), body); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, v1.SuccessResponse{
+		"data":    map[string]string{"order_id": params.OrderID},
+		"message": "Notification processed",
+	})
+}
@@ -0,0 +1,295 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+const (
+	midtransSandboxAPIURL     = "https://api.sandbox.midtrans.com"
+	midtransProductionAPIURL  = "https://api.midtrans.com"
+	midtransSandboxSnapURL    = "https://app.sandbox.midtrans.com"
+	midtransProductionSnapURL = "https://app.midtrans.com"
+
+	defaultMidtransTimeout = 15 * time.Second
+)
+
+// MidtransGateway creates payments through Midtrans Snap and manages them through the Core API
+type MidtransGateway struct {
+	serverKey string
+	apiURL    string
+	snapURL   string
+	client    *http.Client
+}
+
+// NewMidtransGateway creates a Midtrans gateway, using the sandbox unless is_production is set
+func NewMidtransGateway(cfg internal.MidtransConfig) *MidtransGateway {
+	timeout := cfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultMidtransTimeout
+	}
+
+	apiURL, snapURL := midtransSandboxAPIURL, midtransSandboxSnapURL
+	if cfg.IsProduction {
+		apiURL, snapURL = midtransProductionAPIURL, midtransProductionSnapURL
+	}
+
+	return &MidtransGateway{
+		serverKey: cfg.ServerKey,
+		apiURL:    apiURL,
+		snapURL:   snapURL,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the gateway name
+func (g *MidtransGateway) Name() string {
+	return GatewayMidtrans
+}
+
+type midtransSnapRequest struct {
+	TransactionDetails midtransTransactionDetails `json:"transaction_details"`
+	ItemDetails        []midtransItemDetail       `json:"item_details,omitempty"`
+	CustomerDetails    *midtransCustomerDetails   `json:"customer_details,omitempty"`
+	EnabledPayments    []string                   `json:"enabled_payments,omitempty"`
+	Expiry             *midtransExpiry            `json:"expiry,omitempty"`
+}
+
+type midtransTransactionDetails struct {
+	OrderID     string `json:"order_id"`
+	GrossAmount int64  `json:"gross_amount"`
+}
+
+type midtransItemDetail struct {
+	ID       string `json:"id"`
+	Price    int64  `json:"price"`
+	Quantity int    `json:"quantity"`
+	Name     string `json:"name"`
+}
+
+type midtransCustomerDetails struct {
+	FirstName string `json:"first_name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+}
+
+type midtransExpiry struct {
+	StartTime string `json:"start_time"`
+	Unit      string `json:"unit"`
+	Duration  int64  `json:"duration"`
+}
+
+type midtransSnapResponse struct {
+	Token         string   `json:"token"`
+	RedirectURL   string   `json:"redirect_url"`
+	ErrorMessages []string `json:"error_messages"`
+}
+
+type midtransStatusResponse struct {
+	StatusCode        string `json:"status_code"`
+	StatusMessage     string `json:"status_message"`
+	TransactionID     string `json:"transaction_id"`
+	OrderID           string `json:"order_id"`
+	TransactionStatus string `json:"transaction_status"`
+	FraudStatus       string `json:"fraud_status"`
+	GrossAmount       string `json:"gross_amount"`
+}
+
+type midtransRefundRequest struct {
+	RefundKey string `json:"refund_key,omitempty"`
+	Amount    int64  `json:"amount"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// CreateCharge creates a Snap transaction and returns its checkout URL
+func (g *MidtransGateway) CreateCharge(ctx context.Context, req *ChargeRequest) (*ChargeResult, error) {
+	amount := toMidtransAmount(req.Amount)
+
+	body := midtransSnapRequest{
+		TransactionDetails: midtransTransactionDetails{
+			OrderID:     req.OrderID,
+			GrossAmount: amount,
+		},
+		ItemDetails: []midtransItemDetail{{
+			ID:       req.OrderID,
+			Price:    amount,
+			Quantity: 1,
+			Name:     truncate(req.ItemName, 50),
+		}},
+		CustomerDetails: &midtransCustomerDetails{
+			FirstName: req.CustomerName,
+			Email:     req.CustomerEmail,
+			Phone:     req.CustomerPhone,
+		},
+		EnabledPayments: midtransEnabledPayments(req.Method, req.Option),
+	}
+
+	if !req.ExpiresAt.IsZero() {
+		now := time.Now()
+		minutes := int64(math.Ceil(req.ExpiresAt.Sub(now).Minutes()))
+		if minutes < 1 {
+			minutes = 1
+		}
+		body.Expiry = &midtransExpiry{
+			StartTime: now.Format("2006-01-02 15:04:05 -0700"),
+			Unit:      "minute",
+			Duration:  minutes,
+		}
+	}
+
+	raw, statusCode, err := g.do(ctx, http.MethodPost, g.snapURL+"/snap/v1/transactions", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp midtransSnapResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("midtrans: decode snap response: %w", err)
+	}
+	if statusCode >= http.StatusMultipleChoices || resp.RedirectURL == "" {
+		return nil, fmt.Errorf("midtrans: create transaction failed (%d): %v", statusCode, resp.ErrorMessages)
+	}
+
+	return &ChargeResult{
+		PaymentURL:  resp.RedirectURL,
+		Status:      StatusPending,
+		RawResponse: raw,
+	}, nil
+}
+
+// GetStatus retrieves the transaction status from the Core API
+func (g *MidtransGateway) GetStatus(ctx context.Context, orderID string) (*StatusResult, error) {
+	raw, _, err := g.do(ctx, http.MethodGet, fmt.Sprintf("%s/v2/%s/status", g.apiURL, orderID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp midtransStatusResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("midtrans: decode status response: %w", err)
+	}
+	if resp.TransactionStatus == "" {
+		return nil, fmt.Errorf("midtrans: get status failed (%s): %s", resp.StatusCode, resp.StatusMessage)
+	}
+
+	status, err := MapTransactionStatus(resp.TransactionStatus, resp.FraudStatus)
+	if err != nil {
+		return nil, fmt.Errorf("midtrans: %w", err)
+	}
+
+	return &StatusResult{
+		OrderID:       resp.OrderID,
+		TransactionID: resp.TransactionID,
+		Status:        status,
+		GrossAmount:   resp.GrossAmount,
+		RawResponse:   raw,
+	}, nil
+}
+
+// Refund refunds a settled transaction through the Core API
+func (g *MidtransGateway) Refund(ctx context.Context, req *RefundRequest) (*RefundResult, error) {
+	body := midtransRefundRequest{
+		RefundKey: req.RefundKey,
+		Amount:    toMidtransAmount(req.Amount),
+		Reason:    req.Reason,
+	}
+
+	raw, _, err := g.do(ctx, http.MethodPost, fmt.Sprintf("%s/v2/%s/refund", g.apiURL, req.OrderID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp midtransStatusResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("midtrans: decode refund response: %w", err)
+	}
+	if resp.StatusCode != "200" {
+		return nil, fmt.Errorf("midtrans: refund failed (%s): %s", resp.StatusCode, resp.StatusMessage)
+	}
+
+	return &RefundResult{
+		Status:      StatusRefunded,
+		RawResponse: raw,
+	}, nil
+}
+
+// VerifyNotification checks the notification signature_key against the server key
+func (g *MidtransGateway) VerifyNotification(n *Notification) error {
+	return verifyMidtransSignature(n, g.serverKey)
+}
+
+// do sends an authenticated JSON request and returns the raw response body
+func (g *MidtransGateway) do(ctx context.Context, method, url string, body interface{}) ([]byte, int, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("midtrans: encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, 0, fmt.Errorf("midtrans: build request: %w", err)
+	}
+	req.SetBasicAuth(g.serverKey, "")
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("midtrans: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("midtrans: read response: %w", err)
+	}
+
+	return raw, resp.StatusCode, nil
+}
+
+// midtransEnabledPayments restricts the Snap page to the method the parent picked
+func midtransEnabledPayments(method Method, option string) []string {
+	switch method {
+	case MethodBankTransfer:
+		if option != "" {
+			return []string{option + "_va"}
+		}
+		return []string{"bca_va", "bni_va", "bri_va", "permata_va"}
+	case MethodEWallet:
+		if option != "" {
+			return []string{option}
+		}
+		return []string{"gopay", "shopeepay"}
+	case MethodQRIS:
+		return []string{"other_qris"}
+	case MethodCreditCard:
+		return []string{"credit_card"}
+	}
+	return nil
+}
+
+// toMidtransAmount converts an amount to whole rupiah, as required by Midtrans
+func toMidtransAmount(amount float64) int64 {
+	return int64(math.Round(amount))
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
@@ -0,0 +1,307 @@
+package payment
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+var ErrInvalidSignature = errors.New("invalid notification signature")
+
+// Payment represents the core payment domain model
+type Payment struct {
+	ID              int64
+	BookingID       int64
+	PaymentNumber   string
+	Amount          float64
+	Method          Method
+	Gateway         string
+	TransactionID   *string
+	PaymentURL      *string
+	Status          Status
+	ExpiredAt       *time.Time
+	PaidAt          *time.Time
+	RefundedAt      *time.Time
+	RefundAmount    *float64
+	GatewayResponse *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Status represents the state of a payment
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusSuccess  Status = "success"
+	StatusFailed   Status = "failed"
+	StatusExpired  Status = "expired"
+	StatusRefunded Status = "refunded"
+)
+
+// allowedTransitions lists the statuses each payment status may move to.
+// Failed and refunded payments are final.
+// An expired payment can still settle at the gateway, it is recorded so the money can be refunded.
+var allowedTransitions = map[Status][]Status{
+	StatusPending: {StatusSuccess, StatusFailed, StatusExpired},
+	StatusSuccess: {StatusRefunded},
+	StatusExpired: {StatusSuccess},
+}
+
+// CanTransitionTo checks if a payment may move from this status to the given one
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, allowed := range allowedTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Method represents how a parent pays
+type Method string
+
+const (
+	MethodCreditCard   Method = "credit_card"
+	MethodBankTransfer Method = "bank_transfer"
+	MethodEWallet      Method = "e_wallet"
+	MethodQRIS         Method = "qris"
+)
+
+// IsValid checks if the payment method is supported
+func (m Method) IsValid() bool {
+	switch m {
+	case MethodCreditCard, MethodBankTransfer, MethodEWallet, MethodQRIS:
+		return true
+	}
+	return false
+}
+
+// IsExpired returns true if the payment can no longer be completed at the given time
+func (p *Payment) IsExpired(at time.Time) bool {
+	return p.ExpiredAt != nil && !at.Before(*p.ExpiredAt)
+}
+
+// IsActive returns true if the payment is still waiting to be completed
+func (p *Payment) IsActive(at time.Time) bool {
+	return p.Status == StatusPending && !p.IsExpired(at)
+}
+
+// Gateway is implemented by payment providers
+type Gateway interface {
+	// Name identifies the gateway, stored in payments.payment_gateway
+	Name() string
+	// CreateCharge starts a payment with the provider
+	CreateCharge(ctx context.Context, req *ChargeRequest) (*ChargeResult, error)
+	// GetStatus looks up the current state of a payment by its order ID (payment number)
+	GetStatus(ctx context.Context, orderID string) (*StatusResult, error)
+	// Refund returns part or all of a settled payment
+	Refund(ctx context.Context, req *RefundRequest) (*RefundResult, error)
+	// VerifyNotification checks that a callback was signed by the gateway
+	VerifyNotification(n *Notification) error
+}
+
+// Notification is a payment status callback sent by the gateway
+type Notification struct {
+	OrderID           string
+	StatusCode        string
+	TransactionStatus string
+	GrossAmount       string
+	TransactionID     string
+	FraudStatus       string
+	PaymentType       string
+	SignatureKey      string
+}
+
+// MidtransSignature computes the Midtrans notification signature:
+// SHA512(order_id + status_code + gross_amount + server_key), hex encoded
+func MidtransSignature(orderID, statusCode, grossAmount, serverKey string) string {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyMidtransSignature compares a notification's signature_key with the expected one in constant time
+func verifyMidtransSignature(n *Notification, serverKey string) error {
+	expected := MidtransSignature(n.OrderID, n.StatusCode, n.GrossAmount, serverKey)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(n.SignatureKey))) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// MatchesAmount checks that the notified gross amount equals the payment amount
+func (n *Notification) MatchesAmount(amount float64) bool {
+	gross, err := strconv.ParseFloat(n.GrossAmount, 64)
+	if err != nil {
+		return false
+	}
+	return math.Abs(gross-amount) < 0.005
+}
+
+// ChargeRequest contains the details needed to start a payment
+type ChargeRequest struct {
+	OrderID       string
+	Amount        float64
+	Method        Method
+	Option        string // e.g. bank code for bank transfers, wallet for e-wallets
+	CustomerName  string
+	CustomerEmail string
+	CustomerPhone string
+	ItemName      string
+	ExpiresAt     time.Time
+}
+
+// ChargeResult is returned by the gateway when a payment is started
+type ChargeResult struct {
+	TransactionID string
+	PaymentURL    string
+	Status        Status
+	RawResponse   []byte
+}
+
+// StatusResult is the gateway's view of a payment
+type StatusResult struct {
+	OrderID       string
+	TransactionID string
+	Status        Status
+	GrossAmount   string
+	RawResponse   []byte
+}
+
+// RefundRequest contains the details needed to refund a payment
+type RefundRequest struct {
+	OrderID   string
+	RefundKey string
+	Amount    float64
+	Reason    string
+}
+
+// RefundResult is returned by the gateway after a refund
+type RefundResult struct {
+	Status      Status
+	RawResponse []byte
+}
+
+// StatusUpdate describes a status change reported by the gateway
+type StatusUpdate struct {
+	PaymentID     int64
+	BookingID     int64
+	From          Status
+	To            Status
+	TransactionID string
+	RawResponse   []byte
+	At            time.Time
+}
+
+// NotificationResult describes what processing a notification changed
+type NotificationResult struct {
+	Duplicate bool   // The same transaction status was already received
+	Applied   bool   // The payment moved to the notified status
+	From      Status // Payment status before the notification
+	To        Status // Notified payment status
+	RefundID  int64  // Refund queued because the booking no longer takes the payment
+}
+
+// MapTransactionStatus converts a Midtrans transaction_status (and fraud_status) into a payment status
+func MapTransactionStatus(transactionStatus, fraudStatus string) (Status, error) {
+	switch transactionStatus {
+	case "capture":
+		if fraudStatus == "challenge" {
+			return StatusPending, nil
+		}
+		return StatusSuccess, nil
+	case "settlement":
+		return StatusSuccess, nil
+	case "pending", "authorize":
+		return StatusPending, nil
+	case "deny", "cancel", "failure":
+		return StatusFailed, nil
+	case "expire":
+		return StatusExpired, nil
+	case "refund", "partial_refund":
+		return StatusRefunded, nil
+	}
+	return "", fmt.Errorf("unknown transaction status %q", transactionStatus)
+}
+
+// MethodOption describes one payment method offered to parents
+type MethodOption struct {
+	Code           Method
+	Name           string
+	Icon           string
+	ProcessingTime string
+	Options        []string
+}
+
+// AvailableMethods lists the payment methods offered at checkout
+func AvailableMethods() []MethodOption {
+	return []MethodOption{
+		{
+			Code:           MethodBankTransfer,
+			Name:           "Virtual Account",
+			Icon:           "bank_transfer",
+			ProcessingTime: "Instant after transfer",
+			Options:        []string{"bca", "bni", "bri", "permata"},
+		},
+		{
+			Code:           MethodEWallet,
+			Name:           "E-Wallet",
+			Icon:           "e_wallet",
+			ProcessingTime: "Instant",
+			Options:        []string{"gopay", "shopeepay"},
+		},
+		{
+			Code:           MethodQRIS,
+			Name:           "QRIS",
+			Icon:           "qris",
+			ProcessingTime: "Instant",
+		},
+		{
+			Code:           MethodCreditCard,
+			Name:           "Credit Card",
+			Icon:           "credit_card",
+			ProcessingTime: "Instant",
+		},
+	}
+}
+
+// generatePaymentNumber generates a unique payment number, also used as the gateway order ID
+func generatePaymentNumber(at time.Time) string {
+	// Format: PAY-YYYYMMDD-HHMMSS-RANDOM
+	suffix := make([]byte, 5)
+	rand.Read(suffix) // Never fails since Go 1.24
+	return fmt.Sprintf("PAY-%s-%s", at.Format("20060102-150405"), strings.ToUpper(hex.EncodeToString(suffix)))
+}
+
+// BookingSummary contains the booking details needed to take a payment
+type BookingSummary struct {
+	ID            int64
+	BookingNumber string
+	ParentID      int64
+	Status        string
+	PaymentStatus string
+	TotalAmount   float64
+	ServiceName   string
+	ParentName    string
+	ParentEmail   string
+	ParentPhone   string
+	CreatedAt     time.Time
+}
+
+// IsPayable checks if the booking is awaiting payment, either still pending or already accepted by the vendor
+func (b *BookingSummary) IsPayable() bool {
+	if b.PaymentStatus != string(services.PaymentStatusUnpaid) {
+		return false
+	}
+	return b.Status == string(services.BookingStatusPending) || b.Status == string(services.BookingStatusConfirmed)
+}
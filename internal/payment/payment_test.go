@@ -0,0 +1,148 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMidtransSignature(t *testing.T) {
+	// SHA512("PAY-20261016-0001" + "200" + "150000.00" + "SB-Mid-server-test")
+	want := "d7b2c1946b060397d580aac9653018202781dbde76ba10b15238e2611cdbfc5daa9d95d5f977f07dc5c6822c1572d9b1444ad9989cf7498641195f76c1543d12"
+
+	if got := MidtransSignature("PAY-20261016-0001", "200", "150000.00", "SB-Mid-server-test"); got != want {
+		t.Errorf("MidtransSignature() = %s, want %s", got, want)
+	}
+}
+
+func TestVerifyNotification(t *testing.T) {
+	ctx := context.Background()
+	gateway := NewFakeGateway("SB-Mid-server-test")
+	if _, err := gateway.CreateCharge(ctx, &ChargeRequest{OrderID: "PAY-1", Amount: 150000}); err != nil {
+		t.Fatalf("create charge: %v", err)
+	}
+	if err := gateway.Settle("PAY-1"); err != nil {
+		t.Fatalf("settle: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		tamper  func(n *Notification)
+		wantErr bool
+	}{
+		{
+			name:   "signed by the gateway",
+			tamper: func(n *Notification) {},
+		},
+		{
+			name:   "upper case signature",
+			tamper: func(n *Notification) { n.SignatureKey = strings.ToUpper(n.SignatureKey) },
+		},
+		{
+			name:    "amount changed",
+			tamper:  func(n *Notification) { n.GrossAmount = "1.00" },
+			wantErr: true,
+		},
+		{
+			name:    "status code changed",
+			tamper:  func(n *Notification) { n.StatusCode = "201" },
+			wantErr: true,
+		},
+		{
+			name: "signed with another key",
+			tamper: func(n *Notification) {
+				n.SignatureKey = MidtransSignature(n.OrderID, n.StatusCode, n.GrossAmount, "other-key")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := gateway.Notify("PAY-1")
+			if err != nil {
+				t.Fatalf("notify: %v", err)
+			}
+			tt.tamper(n)
+
+			err = gateway.VerifyNotification(n)
+			if tt.wantErr && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("VerifyNotification() = %v, want %v", err, ErrInvalidSignature)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("VerifyNotification() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestMapTransactionStatus(t *testing.T) {
+	tests := []struct {
+		transactionStatus string
+		fraudStatus       string
+		want              Status
+		wantErr           bool
+	}{
+		{transactionStatus: "capture", fraudStatus: "accept", want: StatusSuccess},
+		{transactionStatus: "capture", fraudStatus: "challenge", want: StatusPending},
+		{transactionStatus: "settlement", want: StatusSuccess},
+		{transactionStatus: "pending", want: StatusPending},
+		{transactionStatus: "authorize", want: StatusPending},
+		{transactionStatus: "deny", want: StatusFailed},
+		{transactionStatus: "cancel", want: StatusFailed},
+		{transactionStatus: "failure", want: StatusFailed},
+		{transactionStatus: "expire", want: StatusExpired},
+		{transactionStatus: "refund", want: StatusRefunded},
+		{transactionStatus: "partial_refund", want: StatusRefunded},
+		{transactionStatus: "chargeback", wantErr: true},
+		{transactionStatus: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transactionStatus+"/"+tt.fraudStatus, func(t *testing.T) {
+			got, err := MapTransactionStatus(tt.transactionStatus, tt.fraudStatus)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("MapTransactionStatus() = %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MapTransactionStatus() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MapTransactionStatus() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from Status
+		to   Status
+		want bool
+	}{
+		{name: "pending settles", from: StatusPending, to: StatusSuccess, want: true},
+		{name: "pending fails", from: StatusPending, to: StatusFailed, want: true},
+		{name: "pending expires", from: StatusPending, to: StatusExpired, want: true},
+		{name: "late pending after success", from: StatusSuccess, to: StatusPending, want: false},
+		{name: "late expire after success", from: StatusSuccess, to: StatusExpired, want: false},
+		{name: "success is refunded", from: StatusSuccess, to: StatusRefunded, want: true},
+		{name: "expired settles at the gateway", from: StatusExpired, to: StatusSuccess, want: true},
+		{name: "expired does not go back to pending", from: StatusExpired, to: StatusPending, want: false},
+		{name: "failed is final", from: StatusFailed, to: StatusSuccess, want: false},
+		{name: "refunded is final", from: StatusRefunded, to: StatusSuccess, want: false},
+		{name: "same status is not a transition", from: StatusSuccess, to: StatusSuccess, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/payment"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"gorm.io/gorm"
+)
+
+// GetBookingForPayment retrieves the booking and parent details needed to take a payment
+func (r *Repository) GetBookingForPayment(ctx context.Context, bookingID int64) (*payment.BookingSummary, error) {
+	var row struct {
+		ID            int64
+		BookingNumber string
+		ParentID      int64
+		Status        string
+		PaymentStatus string
+		TotalAmount   float64
+		ServiceName   string
+		ParentName    string
+		ParentEmail   string
+		ParentPhone   string
+		CreatedAt     time.Time
+	}
+
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT
+			b.id, b.booking_number, b.parent_id, b.status, b.payment_status, b.total_amount, b.created_at,
+			s.name AS service_name,
+			u.full_name AS parent_name, u.email AS parent_email, u.phone AS parent_phone
+		FROM bookings b
+		JOIN services s ON s.id = b.service_id
+		JOIN users u ON u.id = b.parent_id
+		WHERE b.id = ?
+	`, bookingID).Scan(&row)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &payment.BookingSummary{
+		ID:            row.ID,
+		BookingNumber: row.BookingNumber,
+		ParentID:      row.ParentID,
+		Status:        row.Status,
+		PaymentStatus: row.PaymentStatus,
+		TotalAmount:   row.TotalAmount,
+		ServiceName:   row.ServiceName,
+		ParentName:    row.ParentName,
+		ParentEmail:   row.ParentEmail,
+		ParentPhone:   row.ParentPhone,
+		CreatedAt:     row.CreatedAt,
+	}, nil
+}
+
+// GetPendingPaymentByBookingID retrieves the pending payment of a booking
+func (r *Repository) GetPendingPaymentByBookingID(ctx context.Context, bookingID int64) (*payment.Payment, error) {
+	return r.getPayment(ctx, "booking_id = ? AND status = ?", bookingID, string(payment.StatusPending))
+}
+
+// GetSuccessfulPaymentByBookingID retrieves the settled payment of a booking
+func (r *Repository) GetSuccessfulPaymentByBookingID(ctx context.Context, bookingID int64) (*payment.Payment, error) {
+	return r.getPayment(ctx, "booking_id = ? AND status = ?", bookingID, string(payment.StatusSuccess))
+}
+
+// GetPaymentByID retrieves a payment
+func (r *Repository) GetPaymentByID(ctx context.Context, paymentID int64) (*payment.Payment, error) {
+	return r.getPayment(ctx, "id = ?", paymentID)
+}
+
+// GetPaymentByNumber retrieves a payment by its payment number
+func (r *Repository) GetPaymentByNumber(ctx context.Context, paymentNumber string) (*payment.Payment, error) {
+	return r.getPayment(ctx, "payment_number = ?", paymentNumber)
+}
+
+func (r *Repository) getPayment(ctx context.Context, query string, args ...interface{}) (*payment.Payment, error) {
+	var data datamodel.Payment
+	if err := r.db.WithContext(ctx).
+		Where(query, args...).
+		Order("created_at DESC").
+		First(&data).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+
+	return payment.FromDataModel(&data), nil
+}
+
+// CreatePayment stores a new pending payment.
+// Pending payments of the same booking that have passed their expiry are expired first.
+func (r *Repository) CreatePayment(ctx context.Context, p *payment.Payment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Expire stale pending payments so only one stays pending per booking
+		if err := tx.Model(&datamodel.Payment{}).
+			Where("booking_id = ? AND status = ? AND expired_at <= ?", p.BookingID, string(payment.StatusPending), p.CreatedAt).
+			Updates(map[string]interface{}{
+				"status":     string(payment.StatusExpired),
+				"updated_at": p.CreatedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		// 2. Insert the new payment
+		data := p.ToDataModel()
+		if err := tx.Create(data).Error; err != nil {
+			return err
+		}
+
+		p.ID = data.ID
+		return nil
+	})
+}
+
+// ApplyStatusUpdate moves a payment to a new status. A successful payment also confirms its booking,
+// or is queued for a refund if the booking no longer takes it.
+// Returns false if the payment was no longer in the expected status, and the ID of the queued refund, if any.
+func (r *Repository) ApplyStatusUpdate(ctx context.Context, update *payment.StatusUpdate) (bool, int64, error) {
+	applied := false
+	var refundID int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"status":     string(update.To),
+			"updated_at": update.At,
+		}
+		if update.TransactionID != "" {
+			updates["transaction_id"] = update.TransactionID
+		}
+		if len(update.RawResponse) > 0 {
+			updates["gateway_response"] = string(update.RawResponse)
+		}
+		if update.To == payment.StatusSuccess {
+			updates["paid_at"] = update.At
+		}
+
+		// 1. Update the payment if nobody changed it in the meantime
+		result := tx.Model(&datamodel.Payment{}).
+			Where("id = ? AND status = ?", update.PaymentID, string(update.From)).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+
+		if update.To != payment.StatusSuccess {
+			return nil
+		}
+
+		// 2. Confirm the booking the payment was made for
+		var data datamodel.Payment
+		if err := tx.First(&data, update.PaymentID).Error; err != nil {
+			return err
+		}
+
+		var err error
+		refundID, err = markBookingPaid(tx, &data, update.At)
+		return err
+	})
+	if err != nil {
+		return false, 0, err
+	}
+
+	return applied, refundID, nil
+}
+
+// ProcessNotification records a gateway notification and applies it to the payment atomically.
+// The payment row is locked so concurrent retries are serialized; a notification whose
+// (transaction_id, transaction_status) was already recorded is reported as a duplicate.
+// The status only changes when the transition is legal, and a successful payment confirms its booking.
+func (r *Repository) ProcessNotification(ctx context.Context, n *payment.Notification, to payment.Status, rawPayload []byte, at time.Time) (*payment.NotificationResult, error) {
+	result := &payment.NotificationResult{To: to}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the payment
+		var data datamodel.Payment
+		if err := tx.Raw(`
+			SELECT * FROM payments WHERE payment_number = ? FOR UPDATE
+		`, n.OrderID).Scan(&data).Error; err != nil {
+			return err
+		}
+		if data.ID == 0 {
+			return sql.ErrNoRows
+		}
+		result.From = payment.Status(data.Status)
+
+		// 2. Record the notification, skipping retries of one already received
+		notification := &datamodel.PaymentNotification{
+			PaymentID:         data.ID,
+			TransactionID:     n.TransactionID,
+			TransactionStatus: n.TransactionStatus,
+			StatusCode:        n.StatusCode,
+			GrossAmount:       n.GrossAmount,
+			Payload:           string(rawPayload),
+			CreatedAt:         at,
+		}
+		insert := tx.Exec(`
+			INSERT INTO payment_notifications
+				(payment_id, transaction_id, transaction_status, status_code, gross_amount, payload, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (transaction_id, transaction_status) DO NOTHING
+		`, notification.PaymentID, notification.TransactionID, notification.TransactionStatus,
+			notification.StatusCode, notification.GrossAmount, notification.Payload, notification.CreatedAt)
+		if insert.Error != nil {
+			return insert.Error
+		}
+		if insert.RowsAffected == 0 {
+			result.Duplicate = true
+			return nil
+		}
+
+		// 3. Keep the latest payload on the payment whether or not it changes the status
+		updates := map[string]interface{}{
+			"gateway_response": string(rawPayload),
+			"updated_at":       at,
+		}
+
+		legal := result.From.CanTransitionTo(to)
+		if legal {
+			updates["status"] = string(to)
+			updates["transaction_id"] = n.TransactionID
+			switch to {
+			case payment.StatusSuccess:
+				updates["paid_at"] = at
+			case payment.StatusRefunded:
+				updates["refunded_at"] = at
+			}
+		}
+
+		if err := tx.Model(&datamodel.Payment{}).
+			Where("id = ?", data.ID).
+			Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if !legal {
+			return nil
+		}
+		result.Applied = true
+
+		// 4. Mark the notification as the one that moved the payment
+		if err := tx.Model(&datamodel.PaymentNotification{}).
+			Where("transaction_id = ? AND transaction_status = ?", n.TransactionID, n.TransactionStatus).
+			Update("applied", true).Error; err != nil {
+			return err
+		}
+
+		// 5. Sync the booking with the payment
+		switch to {
+		case payment.StatusSuccess:
+			refundID, err := markBookingPaid(tx, &data, at)
+			if err != nil {
+				return err
+			}
+			result.RefundID = refundID
+			return nil
+		case payment.StatusRefunded:
+			return tx.Model(&datamodel.Booking{}).
+				Where("id = ? AND payment_status = ?", data.BookingID, string(services.PaymentStatusPaid)).
+				Updates(map[string]interface{}{
+					"payment_status": string(services.PaymentStatusRefunded),
+					"version":        gorm.Expr("version + 1"),
+					"updated_at":     at,
+				}).Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RecordNotification stores a notification without applying it, skipping retries of one already received
+func (r *Repository) RecordNotification(ctx context.Context, paymentID int64, n *payment.Notification, rawPayload []byte, at time.Time) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO payment_notifications
+			(payment_id, transaction_id, transaction_status, status_code, gross_amount, payload, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (transaction_id, transaction_status) DO NOTHING
+	`, paymentID, n.TransactionID, n.TransactionStatus, n.StatusCode, n.GrossAmount, string(rawPayload), at).Error
+}
+
+// markBookingPaid records a successful payment on its booking.
+// A pending booking is confirmed and the transition is added to its timeline;
+// a booking the vendor already confirmed only changes its payment status.
+// A booking that no longer waits for payment (cancelled after its hold expired, or already paid)
+// keeps its state and the payment is queued for a refund. Returns the ID of the queued refund, if any.
+func markBookingPaid(tx *gorm.DB, p *datamodel.Payment, at time.Time) (int64, error) {
+	// 1. Lock the booking so hold expiry cannot cancel it concurrently
+	var booking datamodel.Booking
+	if err := tx.Raw(`
+		SELECT * FROM bookings WHERE id = ? FOR UPDATE
+	`, p.BookingID).Scan(&booking).Error; err != nil {
+		return 0, err
+	}
+	if booking.ID == 0 {
+		return 0, sql.ErrNoRows
+	}
+
+	// 2. Refund payments the booking can no longer take
+	status := services.BookingStatus(booking.Status)
+	if booking.PaymentStatus != string(services.PaymentStatusUnpaid) ||
+		(status != services.BookingStatusPending && status != services.BookingStatusConfirmed) {
+		return queuePaymentRefund(tx, p, payment.LatePaymentRefundReason, at)
+	}
+
+	// 3. Confirm a pending booking through the state machine
+	updates := map[string]interface{}{
+		"payment_status": string(services.PaymentStatusPaid),
+		"version":        gorm.Expr("version + 1"),
+		"updated_at":     at,
+	}
+
+	var change *services.BookingStatusChange
+	if status == services.BookingStatusPending {
+		var err error
+		change, err = services.NewBookingStatusChange(
+			booking.ID, status, services.BookingStatusConfirmed,
+			services.ActorSystem, nil, services.PaymentReceivedReason, at,
+		)
+		if err != nil {
+			return 0, err
+		}
+		updates["status"] = string(change.ToStatus)
+	}
+
+	// 4. Mark the booking paid
+	if err := tx.Model(&datamodel.Booking{}).
+		Where("id = ?", booking.ID).
+		Updates(updates).Error; err != nil {
+		return 0, err
+	}
+
+	if change == nil {
+		return 0, nil
+	}
+
+	// 5. Record the confirmation in the booking's timeline
+	return 0, tx.Create(change.ToDataModel()).Error
+}
+
+// queuePaymentRefund queues a refund of the full amount of a settled payment
+func queuePaymentRefund(tx *gorm.DB, p *datamodel.Payment, reason string, at time.Time) (int64, error) {
+	refund := &datamodel.Refund{
+		BookingID:     p.BookingID,
+		PaymentID:     p.ID,
+		Amount:        p.Amount,
+		Reason:        reason,
+		Status:        string(payment.RefundStatusPending),
+		NextAttemptAt: at,
+		CreatedAt:     at,
+		UpdatedAt:     at,
+	}
+	if err := tx.Create(refund).Error; err != nil {
+		return 0, err
+	}
+	return refund.ID, nil
+}
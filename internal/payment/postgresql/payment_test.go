@@ -0,0 +1,116 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/payment"
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+func TestProcessNotificationSkipsDuplicates(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPaymentRepository(db)
+	f := newFixture(t, db)
+	ctx := context.Background()
+	now := time.Now()
+
+	// A pending payment of a pending booking, charged on the fake gateway
+	gateway := payment.NewFakeGateway("test-server-key")
+	bookingID := f.booking()
+	orderID := fmt.Sprintf("PAY-TEST-%d-%d", now.UnixNano(), fixtureSeq.Add(1))
+
+	charge, err := gateway.CreateCharge(ctx, &payment.ChargeRequest{OrderID: orderID, Amount: 100000})
+	if err != nil {
+		t.Fatalf("create charge: %v", err)
+	}
+	expiresAt := now.Add(15 * time.Minute)
+	if err := repo.CreatePayment(ctx, &payment.Payment{
+		BookingID:     bookingID,
+		PaymentNumber: orderID,
+		Amount:        100000,
+		Method:        payment.MethodQRIS,
+		Gateway:       gateway.Name(),
+		TransactionID: &charge.TransactionID,
+		Status:        payment.StatusPending,
+		ExpiredAt:     &expiresAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}); err != nil {
+		t.Fatalf("create payment: %v", err)
+	}
+
+	process := func(n *payment.Notification) *payment.NotificationResult {
+		t.Helper()
+		to, err := payment.MapTransactionStatus(n.TransactionStatus, n.FraudStatus)
+		if err != nil {
+			t.Fatalf("map transaction status: %v", err)
+		}
+		raw, err := json.Marshal(n)
+		if err != nil {
+			t.Fatalf("encode notification: %v", err)
+		}
+		result, err := repo.ProcessNotification(ctx, n, to, raw, time.Now())
+		if err != nil {
+			t.Fatalf("process notification: %v", err)
+		}
+		return result
+	}
+
+	// The parent pays and the gateway sends a pending notification after the settlement
+	pending, err := gateway.Notify(orderID)
+	if err != nil {
+		t.Fatalf("notify pending: %v", err)
+	}
+	if err := gateway.Settle(orderID); err != nil {
+		t.Fatalf("settle: %v", err)
+	}
+	settled, err := gateway.Notify(orderID)
+	if err != nil {
+		t.Fatalf("notify settlement: %v", err)
+	}
+
+	first := process(settled)
+	if first.Duplicate || !first.Applied || first.From != payment.StatusPending || first.To != payment.StatusSuccess {
+		t.Fatalf("first settlement = %+v, want applied pending -> success", first)
+	}
+
+	// The gateway retries the same settlement
+	retry := process(settled)
+	if !retry.Duplicate || retry.Applied {
+		t.Errorf("retried settlement = %+v, want a skipped duplicate", retry)
+	}
+
+	// The late pending notification is new, but may not move the payment back
+	late := process(pending)
+	if late.Duplicate || late.Applied {
+		t.Errorf("late pending = %+v, want recorded but not applied", late)
+	}
+
+	p, err := repo.GetPaymentByNumber(ctx, orderID)
+	if err != nil {
+		t.Fatalf("get payment: %v", err)
+	}
+	if p.Status != payment.StatusSuccess {
+		t.Errorf("payment status = %s, want %s", p.Status, payment.StatusSuccess)
+	}
+
+	var notifications int64
+	if err := db.Raw(`SELECT COUNT(*) FROM payment_notifications WHERE payment_id = ?`, p.ID).Scan(&notifications).Error; err != nil {
+		t.Fatalf("count notifications: %v", err)
+	}
+	if notifications != 2 {
+		t.Errorf("stored %d notifications, want 2", notifications)
+	}
+
+	booking, err := repo.GetBookingForPayment(ctx, bookingID)
+	if err != nil {
+		t.Fatalf("get booking: %v", err)
+	}
+	if booking.PaymentStatus != string(services.PaymentStatusPaid) {
+		t.Errorf("booking payment status = %s, want %s", booking.PaymentStatus, services.PaymentStatusPaid)
+	}
+}
@@ -0,0 +1,15 @@
+package postgresql
+
+import (
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
@@ -0,0 +1,125 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// These tests run against a real database. Point TEST_DATABASE_URL at a disposable Postgres database,
+// it is migrated to the latest schema before the first test:
+//
+//	TEST_DATABASE_URL=postgres://localhost:5432/jadiles_test?sslmode=disable go test ./internal/payment/postgresql/
+const testDatabaseURLEnv = "TEST_DATABASE_URL"
+
+var (
+	migrateOnce sync.Once
+	migrateErr  error
+	fixtureSeq  atomic.Int64
+)
+
+// openTestDB connects to the test database, skipping the test when none is configured
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	url := os.Getenv(testDatabaseURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseURLEnv)
+	}
+
+	migrateOnce.Do(func() {
+		migrateErr = migrateTestDB(url)
+	})
+	if migrateErr != nil {
+		t.Fatalf("migrate test database: %v", migrateErr)
+	}
+
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return db
+}
+
+func migrateTestDB(url string) error {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	goose.SetTableName("schema_migrations")
+	return goose.Up(db, "../../../db/migrations")
+}
+
+// fixture inserts the rows a test needs. Every row gets unique names, so tests share the database
+// without cleaning it up.
+type fixture struct {
+	t  *testing.T
+	db *gorm.DB
+}
+
+func newFixture(t *testing.T, db *gorm.DB) *fixture {
+	return &fixture{t: t, db: db}
+}
+
+// insert runs an INSERT ... RETURNING id and returns the new ID
+func (f *fixture) insert(query string, args ...interface{}) int64 {
+	f.t.Helper()
+
+	var id int64
+	if err := f.db.Raw(query+" RETURNING id", args...).Scan(&id).Error; err != nil {
+		f.t.Fatalf("insert fixture: %v", err)
+	}
+	return id
+}
+
+func (f *fixture) user(role string) int64 {
+	n := fixtureSeq.Add(1)
+	return f.insert(`
+		INSERT INTO users (email, password_hash, full_name, phone, role, email_verified)
+		VALUES (?, 'x', ?, '081200000000', ?, true)
+	`, fmt.Sprintf("%s-%d-%d@test.local", role, time.Now().UnixNano(), n), fmt.Sprintf("Test %s %d", role, n), role)
+}
+
+// booking creates a pending, unpaid single-session booking of 100000 and returns its ID
+func (f *fixture) booking() int64 {
+	parentID := f.user("parent")
+	childID := f.insert(`
+		INSERT INTO children (parent_id, name, date_of_birth)
+		VALUES (?, 'Test Child', '2018-01-01')
+	`, parentID)
+	vendorID := f.insert(`
+		INSERT INTO vendors (user_id, business_name, phone, address, status, verified)
+		VALUES (?, 'Test Vendor', '081200000000', 'Jl. Test 1', 'active', true)
+	`, f.user("vendor"))
+	categoryID := f.insert(`
+		INSERT INTO service_categories (name, slug)
+		VALUES ('Test Category', ?)
+	`, fmt.Sprintf("test-category-%d-%d", time.Now().UnixNano(), fixtureSeq.Add(1)))
+	serviceID := f.insert(`
+		INSERT INTO services (vendor_id, category_id, name, description, class_type, duration_minutes, price_per_session, status)
+		VALUES (?, ?, ?, 'Test service', 'small_group', 60, 100000, 'active')
+	`, vendorID, categoryID, fmt.Sprintf("Test Service %d", fixtureSeq.Add(1)))
+
+	return f.insert(`
+		INSERT INTO bookings (booking_number, parent_id, vendor_id, child_id, service_id, booking_type, total_sessions, total_amount)
+		VALUES (?, ?, ?, ?, ?, 'single', 1, 100000)
+	`, fmt.Sprintf("BK-TEST-%d-%d", time.Now().UnixNano(), fixtureSeq.Add(1)), parentID, vendorID, childID, serviceID)
+}
@@ -0,0 +1,130 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/payment"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"gorm.io/gorm"
+)
+
+// ListDueRefunds retrieves the IDs of pending refunds whose next attempt is due, oldest first
+func (r *Repository) ListDueRefunds(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&datamodel.Refund{}).
+		Where("status = ? AND next_attempt_at <= ?", string(payment.RefundStatusPending), now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ClaimRefund starts an attempt of a due pending refund, pushing its next attempt back by the lease
+// so concurrent workers skip it. Returns nil if the refund is not due or no longer pending.
+func (r *Repository) ClaimRefund(ctx context.Context, refundID int64, now time.Time, lease time.Duration) (*payment.Refund, error) {
+	var data datamodel.Refund
+	result := r.db.WithContext(ctx).Raw(`
+		UPDATE refunds
+		SET attempts = attempts + 1, next_attempt_at = ?, updated_at = ?
+		WHERE id = ? AND status = ? AND next_attempt_at <= ?
+		RETURNING *
+	`, now.Add(lease), now, refundID, string(payment.RefundStatusPending), now).Scan(&data)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if data.ID == 0 {
+		return nil, nil
+	}
+
+	return toDomainRefund(&data), nil
+}
+
+// CompleteRefund records a refund the gateway accepted on the refund, its payment and its booking.
+// The booking only counts as refunded once none of its payments remain settled.
+func (r *Repository) CompleteRefund(ctx context.Context, refund *payment.Refund, p *payment.Payment, rawResponse []byte, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Mark the refund done
+		if err := tx.Model(&datamodel.Refund{}).
+			Where("id = ?", refund.ID).
+			Updates(map[string]interface{}{
+				"status":       string(payment.RefundStatusSucceeded),
+				"last_error":   nil,
+				"processed_at": at,
+				"updated_at":   at,
+			}).Error; err != nil {
+			return err
+		}
+
+		// 2. Mark the payment refunded, only settled payments can be refunded
+		updates := map[string]interface{}{
+			"status":        string(payment.StatusRefunded),
+			"refund_amount": refund.Amount,
+			"refunded_at":   at,
+			"updated_at":    at,
+		}
+		if len(rawResponse) > 0 {
+			updates["gateway_response"] = string(rawResponse)
+		}
+
+		result := tx.Model(&datamodel.Payment{}).
+			Where("id = ? AND status = ?", p.ID, string(payment.StatusSuccess)).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return internal.ErrOptimisticLock
+		}
+
+		// 3. Mark the booking refunded unless another payment still pays for it
+		return tx.Model(&datamodel.Booking{}).
+			Where("id = ? AND payment_status = ?", p.BookingID, string(services.PaymentStatusPaid)).
+			Where("NOT EXISTS (SELECT 1 FROM payments WHERE booking_id = ? AND status = ?)", p.BookingID, string(payment.StatusSuccess)).
+			Updates(map[string]interface{}{
+				"payment_status": string(services.PaymentStatusRefunded),
+				"version":        gorm.Expr("version + 1"),
+				"updated_at":     at,
+			}).Error
+	})
+}
+
+// FailRefundAttempt records why an attempt failed. The refund is retried at nextAttemptAt,
+// or marked failed when nextAttemptAt is nil.
+func (r *Repository) FailRefundAttempt(ctx context.Context, refundID int64, reason string, nextAttemptAt *time.Time, at time.Time) error {
+	updates := map[string]interface{}{
+		"last_error": reason,
+		"updated_at": at,
+	}
+	if nextAttemptAt != nil {
+		updates["next_attempt_at"] = *nextAttemptAt
+	} else {
+		updates["status"] = string(payment.RefundStatusFailed)
+		updates["processed_at"] = at
+	}
+
+	return r.db.WithContext(ctx).Model(&datamodel.Refund{}).
+		Where("id = ? AND status = ?", refundID, string(payment.RefundStatusPending)).
+		Updates(updates).Error
+}
+
+func toDomainRefund(data *datamodel.Refund) *payment.Refund {
+	return &payment.Refund{
+		ID:            data.ID,
+		BookingID:     data.BookingID,
+		PaymentID:     data.PaymentID,
+		Amount:        data.Amount,
+		Reason:        data.Reason,
+		Status:        payment.RefundStatus(data.Status),
+		Attempts:      data.Attempts,
+		NextAttemptAt: data.NextAttemptAt,
+		LastError:     data.LastError,
+		ProcessedAt:   data.ProcessedAt,
+		CreatedAt:     data.CreatedAt,
+		UpdatedAt:     data.UpdatedAt,
+	}
+}
@@ -0,0 +1,155 @@
+package payment
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+// maxRefundBackoff caps the wait between two attempts of a refund
+const maxRefundBackoff = 6 * time.Hour
+
+// refundLease is how long an attempt keeps a refund claimed. A process that dies mid-attempt
+// leaves the refund due again after the lease, and the gateway dedupes the retry by its refund key.
+const refundLease = 5 * time.Minute
+
+// LatePaymentRefundReason is recorded on refunds of payments settled after their booking stopped waiting for one
+const LatePaymentRefundReason = "Payment received after the booking was closed"
+
+// RefundStatus represents the state of a queued refund
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed" // Out of attempts, needs manual follow-up
+)
+
+// Refund is money owed back to a parent for a payment. Refunds are queued in the same transaction
+// as the change that owes them, then sent to the gateway until it accepts them.
+type Refund struct {
+	ID            int64
+	BookingID     int64
+	PaymentID     int64
+	Amount        float64
+	Reason        string
+	Status        RefundStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	ProcessedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// RefundPolicy defines how failed refunds are retried
+type RefundPolicy struct {
+	MaxAttempts   int
+	RetryBackoff  time.Duration
+	SweepInterval time.Duration
+	BatchSize     int
+}
+
+// DefaultRefundPolicy returns the policy used when none is configured:
+// 8 attempts starting one minute apart, checked every minute
+func DefaultRefundPolicy() RefundPolicy {
+	return RefundPolicy{
+		MaxAttempts:   8,
+		RetryBackoff:  time.Minute,
+		SweepInterval: time.Minute,
+		BatchSize:     50,
+	}
+}
+
+// NewRefundPolicy builds a policy from config, falling back to defaults for unset values
+func NewRefundPolicy(cfg internal.RefundConfig) RefundPolicy {
+	policy := DefaultRefundPolicy()
+	if cfg.MaxAttempts > 0 {
+		policy.MaxAttempts = cfg.MaxAttempts
+	}
+	if cfg.RetryBackoff > 0 {
+		policy.RetryBackoff = cfg.RetryBackoff
+	}
+	if cfg.SweepInterval > 0 {
+		policy.SweepInterval = cfg.SweepInterval
+	}
+	if cfg.BatchSize > 0 {
+		policy.BatchSize = cfg.BatchSize
+	}
+	return policy
+}
+
+// NextAttemptAt returns when to retry a refund after its given number of failed attempts,
+// or nil once it ran out of attempts
+func (p RefundPolicy) NextAttemptAt(attempts int, at time.Time) *time.Time {
+	if attempts >= p.MaxAttempts {
+		return nil
+	}
+
+	backoff := p.RetryBackoff
+	for i := 1; i < attempts && backoff < maxRefundBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRefundBackoff {
+		backoff = maxRefundBackoff
+	}
+
+	next := at.Add(backoff)
+	return &next
+}
+
+// RefundRetrier retries queued refunds whose last attempt failed
+type RefundRetrier struct {
+	service *Service
+	policy  RefundPolicy
+}
+
+// NewRefundRetrier creates a new refund retrier
+func NewRefundRetrier(service *Service, policy RefundPolicy) *RefundRetrier {
+	return &RefundRetrier{
+		service: service,
+		policy:  policy,
+	}
+}
+
+// Run retries due refunds every SweepInterval until the context is cancelled
+func (r *RefundRetrier) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.policy.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RetryOnce(ctx); err != nil {
+			slog.ErrorContext(ctx, "Failed to retry refunds", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RetryOnce attempts one batch of due refunds. Returns the number of refunds attempted.
+func (r *RefundRetrier) RetryOnce(ctx context.Context) (int, error) {
+	ids, err := r.service.repo.ListDueRefunds(ctx, time.Now(), r.policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err := r.service.ProcessRefund(ctx, id); err != nil {
+			slog.WarnContext(ctx, "Refund attempt failed",
+				slog.Int64("refund_id", id),
+				slog.Any("error", err),
+			)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return len(ids), nil
+}
@@ -0,0 +1,487 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/services"
+	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+)
+
+const defaultPaymentExpiry = 15 * time.Minute
+
+type Repository interface {
+	GetBookingForPayment(ctx context.Context, bookingID int64) (*BookingSummary, error)
+	GetPendingPaymentByBookingID(ctx context.Context, bookingID int64) (*Payment, error)
+	GetSuccessfulPaymentByBookingID(ctx context.Context, bookingID int64) (*Payment, error)
+	GetPaymentByNumber(ctx context.Context, paymentNumber string) (*Payment, error)
+	CreatePayment(ctx context.Context, payment *Payment) error
+	ApplyStatusUpdate(ctx context.Context, update *StatusUpdate) (bool, int64, error)
+	ProcessNotification(ctx context.Context, n *Notification, to Status, rawPayload []byte, at time.Time) (*NotificationResult, error)
+	RecordNotification(ctx context.Context, paymentID int64, n *Notification, rawPayload []byte, at time.Time) error
+	GetPaymentByID(ctx context.Context, paymentID int64) (*Payment, error)
+	ListDueRefunds(ctx context.Context, now time.Time, limit int) ([]int64, error)
+	ClaimRefund(ctx context.Context, refundID int64, now time.Time, lease time.Duration) (*Refund, error)
+	CompleteRefund(ctx context.Context, refund *Refund, payment *Payment, rawResponse []byte, at time.Time) error
+	FailRefundAttempt(ctx context.Context, refundID int64, reason string, nextAttemptAt *time.Time, at time.Time) error
+}
+
+type Service struct {
+	repo         Repository
+	gateway      Gateway
+	expiry       time.Duration
+	holdPolicy   services.HoldPolicy
+	refundPolicy RefundPolicy
+}
+
+// NewService creates a payment service. Payments expire after the configured expiry,
+// but never later than the hold on the booking they pay for.
+func NewService(repo Repository, gateway Gateway, cfg internal.PaymentConfig, holdPolicy services.HoldPolicy) *Service {
+	expiry := cfg.Expiry
+	if expiry <= 0 {
+		expiry = defaultPaymentExpiry
+	}
+
+	return &Service{
+		repo:         repo,
+		gateway:      gateway,
+		expiry:       expiry,
+		holdPolicy:   holdPolicy,
+		refundPolicy: NewRefundPolicy(cfg.Refund),
+	}
+}
+
+// GetPaymentMethods lists the payment methods offered at checkout
+func (s *Service) GetPaymentMethods(ctx context.Context) *v1.PaymentMethodsResponse {
+	return ToV1PaymentMethods(AvailableMethods())
+}
+
+// CreatePayment starts a payment for a parent's pending booking.
+// An unexpired pending payment for the same booking is returned instead of creating a new one.
+func (s *Service) CreatePayment(ctx context.Context, parentID int64, bookingID int64, params *CreatePaymentParams) (*PaymentResponse, error) {
+	booking, err := s.repo.GetBookingForPayment(ctx, bookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Booking")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Verify the booking belongs to the parent
+	if booking.ParentID != parentID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	if !booking.IsPayable() {
+		return nil, internal.NewBusinessRuleError(
+			fmt.Sprintf("Booking with status %s (%s) cannot be paid", booking.Status, booking.PaymentStatus),
+			internal.ErrInvalidState,
+		)
+	}
+
+	// Unpaid bookings are held until the hold expires, whether or not the vendor confirmed them
+	now := time.Now()
+	holdExpiresAt := s.holdPolicy.ExpiresAt(booking.CreatedAt)
+	if !now.Before(holdExpiresAt) {
+		return nil, internal.NewBusinessRuleError("Booking hold has expired", internal.ErrInvalidState)
+	}
+
+	// Resume the existing payment if it can still be completed
+	existing, err := s.repo.GetPendingPaymentByBookingID(ctx, bookingID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, internal.NewInternalServerError(err)
+	}
+	if existing != nil && existing.IsActive(now) {
+		return ToPaymentResponse(existing, "Pending payment found"), nil
+	}
+
+	expiresAt := now.Add(s.expiry)
+	if expiresAt.After(holdExpiresAt) {
+		expiresAt = holdExpiresAt
+	}
+
+	payment := &Payment{
+		BookingID:     booking.ID,
+		PaymentNumber: generatePaymentNumber(now),
+		Amount:        booking.TotalAmount,
+		Method:        Method(params.PaymentMethod),
+		Gateway:       s.gateway.Name(),
+		Status:        StatusPending,
+		ExpiredAt:     &expiresAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	charge, err := s.gateway.CreateCharge(ctx, &ChargeRequest{
+		OrderID:       payment.PaymentNumber,
+		Amount:        payment.Amount,
+		Method:        payment.Method,
+		Option:        params.Option,
+		CustomerName:  booking.ParentName,
+		CustomerEmail: booking.ParentEmail,
+		CustomerPhone: booking.ParentPhone,
+		ItemName:      fmt.Sprintf("%s (%s)", booking.ServiceName, booking.BookingNumber),
+		ExpiresAt:     expiresAt,
+	})
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if charge.TransactionID != "" {
+		payment.TransactionID = &charge.TransactionID
+	}
+	if charge.PaymentURL != "" {
+		payment.PaymentURL = &charge.PaymentURL
+	}
+	if len(charge.RawResponse) > 0 {
+		raw := string(charge.RawResponse)
+		payment.GatewayResponse = &raw
+	}
+
+	if err := s.repo.CreatePayment(ctx, payment); err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToPaymentResponse(payment, "Payment created successfully"), nil
+}
+
+// GetPaymentStatus returns the status of a parent's payment, refreshing pending payments from the gateway
+func (s *Service) GetPaymentStatus(ctx context.Context, parentID int64, paymentNumber string) (*v1.PaymentStatusResponse, error) {
+	payment, err := s.repo.GetPaymentByNumber(ctx, paymentNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Payment")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	booking, err := s.repo.GetBookingForPayment(ctx, payment.BookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Booking")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Verify the payment belongs to the parent
+	if booking.ParentID != parentID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	// Callbacks can be delayed or lost, so ask the gateway while the payment is pending
+	if payment.Status == StatusPending {
+		result, err := s.gateway.GetStatus(ctx, payment.PaymentNumber)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to refresh payment status from gateway",
+				slog.String("payment_number", payment.PaymentNumber),
+				slog.Any("error", err),
+			)
+		} else if result.Status != payment.Status {
+			if err := s.applyStatus(ctx, payment, result.Status, result.TransactionID, result.RawResponse); err != nil {
+				return nil, err
+			}
+
+			if payment, err = s.repo.GetPaymentByNumber(ctx, paymentNumber); err != nil {
+				return nil, internal.NewInternalServerError(err)
+			}
+			if booking, err = s.repo.GetBookingForPayment(ctx, payment.BookingID); err != nil {
+				return nil, internal.NewInternalServerError(err)
+			}
+		}
+	}
+
+	return ToV1PaymentStatus(payment, booking), nil
+}
+
+// SimulateFakePayment ends a parent's pending payment on the fake gateway with the given outcome
+// (settle, fail or expire), then delivers the notification the gateway would send through HandleCallback.
+// Only the fake gateway supports it, which is itself only built in local development.
+func (s *Service) SimulateFakePayment(ctx context.Context, parentID int64, paymentNumber, outcome string) (*v1.PaymentStatusResponse, error) {
+	fake, ok := s.gateway.(*FakeGateway)
+	if !ok {
+		return nil, internal.NewNotFoundError("Fake payment gateway")
+	}
+
+	payment, err := s.repo.GetPaymentByNumber(ctx, paymentNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Payment")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	booking, err := s.repo.GetBookingForPayment(ctx, payment.BookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Booking")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Verify the payment belongs to the parent
+	if booking.ParentID != parentID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	var transition func(orderID string) error
+	switch outcome {
+	case "settle":
+		transition = fake.Settle
+	case "fail":
+		transition = fake.Fail
+	case "expire":
+		transition = fake.Expire
+	default:
+		return nil, internal.NewValidationError("outcome must be settle, fail or expire")
+	}
+
+	transactionID := ""
+	if payment.TransactionID != nil {
+		transactionID = *payment.TransactionID
+	}
+	fake.track(payment.PaymentNumber, transactionID, payment.Amount)
+
+	if err := transition(payment.PaymentNumber); err != nil {
+		return nil, internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+	}
+
+	notification, err := fake.Notify(payment.PaymentNumber)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+	body, err := newCallbackBody(notification)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+	if err := s.HandleCallback(ctx, notification, body); err != nil {
+		return nil, err
+	}
+
+	return s.GetPaymentStatus(ctx, parentID, paymentNumber)
+}
+
+// HandleCallback verifies and applies a payment notification sent by the gateway.
+// Gateways retry and reorder callbacks, so duplicates and stale statuses are acknowledged without effect.
+// The gateway retries every rejected callback, so notifications that can never be applied (unknown order,
+// unknown status, wrong amount) are recorded and acknowledged instead of failing.
+func (s *Service) HandleCallback(ctx context.Context, notification *Notification, rawBody []byte) error {
+	if err := s.gateway.VerifyNotification(notification); err != nil {
+		slog.WarnContext(ctx, "Rejected payment notification",
+			slog.String("order_id", notification.OrderID),
+			slog.Any("error", err),
+		)
+		return internal.NewForbiddenError("Invalid notification signature")
+	}
+
+	payment, err := s.repo.GetPaymentByNumber(ctx, notification.OrderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			slog.ErrorContext(ctx, "Payment notification for an unknown order",
+				slog.String("order_id", notification.OrderID),
+				slog.String("transaction_id", notification.TransactionID),
+				slog.String("transaction_status", notification.TransactionStatus),
+			)
+			return nil
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	status, err := MapTransactionStatus(notification.TransactionStatus, notification.FraudStatus)
+	if err != nil {
+		slog.ErrorContext(ctx, "Payment notification with an unknown transaction status",
+			slog.String("order_id", notification.OrderID),
+			slog.String("transaction_status", notification.TransactionStatus),
+			slog.Any("error", err),
+		)
+		return s.recordRejectedNotification(ctx, payment, notification, rawBody)
+	}
+
+	if !notification.MatchesAmount(payment.Amount) {
+		slog.ErrorContext(ctx, "Payment notification amount mismatch",
+			slog.String("order_id", notification.OrderID),
+			slog.String("gross_amount", notification.GrossAmount),
+			slog.Float64("amount", payment.Amount),
+		)
+		return s.recordRejectedNotification(ctx, payment, notification, rawBody)
+	}
+
+	result, err := s.repo.ProcessNotification(ctx, notification, status, rawBody, time.Now())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			slog.ErrorContext(ctx, "Payment notification for a payment or booking that no longer exists",
+				slog.String("order_id", notification.OrderID),
+			)
+			return nil
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	switch {
+	case result.Duplicate:
+		slog.InfoContext(ctx, "Duplicate payment notification",
+			slog.String("order_id", notification.OrderID),
+			slog.String("transaction_id", notification.TransactionID),
+			slog.String("transaction_status", notification.TransactionStatus),
+		)
+	case !result.Applied && result.From != result.To:
+		slog.WarnContext(ctx, "Ignored illegal payment status transition",
+			slog.String("order_id", notification.OrderID),
+			slog.String("from", string(result.From)),
+			slog.String("to", string(result.To)),
+		)
+	}
+	s.refundLatePayment(ctx, payment, result.RefundID)
+
+	return nil
+}
+
+// recordRejectedNotification keeps a notification that cannot be applied so it can be followed up by hand
+func (s *Service) recordRejectedNotification(ctx context.Context, payment *Payment, notification *Notification, rawBody []byte) error {
+	if err := s.repo.RecordNotification(ctx, payment.ID, notification, rawBody, time.Now()); err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	return nil
+}
+
+// applyStatus records a gateway status change. A successful payment confirms its booking.
+func (s *Service) applyStatus(ctx context.Context, payment *Payment, status Status, transactionID string, rawResponse []byte) error {
+	if status == payment.Status {
+		return nil
+	}
+
+	if !payment.Status.CanTransitionTo(status) {
+		slog.InfoContext(ctx, "Ignoring payment status change",
+			slog.String("payment_number", payment.PaymentNumber),
+			slog.String("from", string(payment.Status)),
+			slog.String("to", string(status)),
+		)
+		return nil
+	}
+
+	applied, refundID, err := s.repo.ApplyStatusUpdate(ctx, &StatusUpdate{
+		PaymentID:     payment.ID,
+		BookingID:     payment.BookingID,
+		From:          payment.Status,
+		To:            status,
+		TransactionID: transactionID,
+		RawResponse:   rawResponse,
+		At:            time.Now(),
+	})
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if !applied {
+		slog.InfoContext(ctx, "Payment status already changed",
+			slog.String("payment_number", payment.PaymentNumber),
+			slog.String("to", string(status)),
+		)
+	}
+	s.refundLatePayment(ctx, payment, refundID)
+
+	return nil
+}
+
+// refundLatePayment reports a payment settled for a booking that no longer takes it
+// and sends its queued refund. A failed attempt is left to the refund worker.
+func (s *Service) refundLatePayment(ctx context.Context, payment *Payment, refundID int64) {
+	if refundID == 0 {
+		return
+	}
+
+	slog.ErrorContext(ctx, "Payment settled for a booking that no longer takes it, refunding",
+		slog.String("payment_number", payment.PaymentNumber),
+		slog.Int64("booking_id", payment.BookingID),
+		slog.Int64("refund_id", refundID),
+		slog.Float64("amount", payment.Amount),
+	)
+
+	if err := s.ProcessRefund(ctx, refundID); err != nil {
+		slog.WarnContext(ctx, "Refund attempt failed, it will be retried",
+			slog.String("payment_number", payment.PaymentNumber),
+			slog.Int64("refund_id", refundID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// ProcessRefund sends a queued refund to the gateway. A failed attempt stays queued and is retried
+// with backoff by the refund worker, until the gateway accepts it or it runs out of attempts.
+// Refunds that are not due or already claimed by another attempt are skipped.
+func (s *Service) ProcessRefund(ctx context.Context, refundID int64) error {
+	refund, err := s.repo.ClaimRefund(ctx, refundID, time.Now(), refundLease)
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if refund == nil {
+		return nil
+	}
+
+	payment, err := s.repo.GetPaymentByID(ctx, refund.PaymentID)
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+
+	// Only settled payments can be refunded, anything else needs a person to look at it
+	if payment.Status != StatusSuccess || refund.Amount > payment.Amount {
+		return s.failRefund(ctx, refund, fmt.Errorf("payment %s with status %s and amount %.2f cannot be refunded %.2f",
+			payment.PaymentNumber, payment.Status, payment.Amount, refund.Amount), false)
+	}
+
+	// The refund key is stable across attempts so the gateway refunds once
+	result, err := s.gateway.Refund(ctx, &RefundRequest{
+		OrderID:   payment.PaymentNumber,
+		RefundKey: fmt.Sprintf("%s-refund-%d", payment.PaymentNumber, refund.ID),
+		Amount:    refund.Amount,
+		Reason:    refund.Reason,
+	})
+	if err != nil {
+		return s.failRefund(ctx, refund, err, true)
+	}
+
+	if err := s.repo.CompleteRefund(ctx, refund, payment, result.RawResponse, time.Now()); err != nil {
+		if errors.Is(err, internal.ErrOptimisticLock) {
+			return s.failRefund(ctx, refund, fmt.Errorf("payment %s changed while it was refunded", payment.PaymentNumber), false)
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	slog.InfoContext(ctx, "Refund completed",
+		slog.Int64("refund_id", refund.ID),
+		slog.Int64("booking_id", refund.BookingID),
+		slog.String("payment_number", payment.PaymentNumber),
+		slog.Float64("amount", refund.Amount),
+	)
+	return nil
+}
+
+// failRefund records a failed attempt and schedules the next one, if the failure can be retried
+// and attempts are left. Refunds that will not be retried are logged for manual follow-up.
+func (s *Service) failRefund(ctx context.Context, refund *Refund, cause error, retry bool) error {
+	now := time.Now()
+
+	var nextAttemptAt *time.Time
+	if retry {
+		nextAttemptAt = s.refundPolicy.NextAttemptAt(refund.Attempts, now)
+	}
+
+	if err := s.repo.FailRefundAttempt(ctx, refund.ID, cause.Error(), nextAttemptAt, now); err != nil {
+		return internal.NewInternalServerError(err)
+	}
+
+	if nextAttemptAt == nil {
+		slog.ErrorContext(ctx, "Refund failed and will not be retried",
+			slog.Int64("refund_id", refund.ID),
+			slog.Int64("booking_id", refund.BookingID),
+			slog.Float64("amount", refund.Amount),
+			slog.Int("attempts", refund.Attempts),
+			slog.Any("error", cause),
+		)
+	}
+
+	return internal.NewInternalServerError(cause)
+}
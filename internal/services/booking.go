@@ -1,8 +1,13 @@
 package services
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math"
 	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
 )
 
 // ================== Booking Domain Models ==================
@@ -48,10 +53,29 @@ type BookingStatus string
 const (
 	BookingStatusPending   BookingStatus = "pending"
 	BookingStatusConfirmed BookingStatus = "confirmed"
+	BookingStatusOngoing   BookingStatus = "ongoing"
 	BookingStatusCancelled BookingStatus = "cancelled"
 	BookingStatusCompleted BookingStatus = "completed"
 )
 
+// PaymentStatus represents the payment state of a booking
+type PaymentStatus string
+
+const (
+	PaymentStatusUnpaid   PaymentStatus = "unpaid"
+	PaymentStatusPaid     PaymentStatus = "paid"
+	PaymentStatusRefunded PaymentStatus = "refunded"
+)
+
+// CancelledBy represents who cancelled a booking
+type CancelledBy string
+
+const (
+	CancelledByParent CancelledBy = "parent"
+	CancelledByVendor CancelledBy = "vendor"
+	CancelledBySystem CancelledBy = "system"
+)
+
 // Booking represents a booking domain model
 type Booking struct {
 	ID             int64
@@ -64,28 +88,87 @@ type Booking struct {
 	TotalSessions  int
 	TotalAmount    float64
 	Status         BookingStatus
+	PaymentStatus  PaymentStatus
 	PreferredCoach *int64
 	ParentNotes    *string
 	Version        int // For optimistic locking
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 
+	// Sessions delivered so far, attended or not
+	CompletedSessions int
+
+	// Cancellation data
+	CancellationReason *string
+	CancelledBy        *CancelledBy
+	CancelledAt        *time.Time
+	RefundAmount       *float64
+
 	// Related data (not stored in bookings table)
 	Sessions []*BookingSession
 }
 
+// CanBeCancelled checks if the booking is still in a cancellable state
+func (b *Booking) CanBeCancelled() bool {
+	return b.Status.CanTransitionTo(BookingStatusCancelled)
+}
+
+// CanBeConfirmed checks if the vendor can still accept or reject the booking
+func (b *Booking) CanBeConfirmed() bool {
+	return b.Status.CanTransitionTo(BookingStatusConfirmed)
+}
+
+// RemainingSessions returns sessions that are still scheduled, ordered as stored
+func (b *Booking) RemainingSessions() []*BookingSession {
+	remaining := make([]*BookingSession, 0, len(b.Sessions))
+	for _, session := range b.Sessions {
+		if session.Status == SessionStatusScheduled {
+			remaining = append(remaining, session)
+		}
+	}
+	return remaining
+}
+
+// FirstRemainingSession returns the earliest session that is still scheduled
+func (b *Booking) FirstRemainingSession() *BookingSession {
+	var first *BookingSession
+	for _, session := range b.RemainingSessions() {
+		if first == nil || session.StartsAt().Before(first.StartsAt()) {
+			first = session
+		}
+	}
+	return first
+}
+
 // BookingSession represents a single session in a booking
 type BookingSession struct {
-	ID          int64
-	BookingID   int64
-	ScheduleID  int64
-	SessionDate time.Time
-	StartTime   string
-	EndTime     string
-	Status      SessionStatus
-	CoachID     *int64
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID            int64
+	BookingID     int64
+	ScheduleID    int64
+	SessionNumber int
+	SessionDate   time.Time
+	StartTime     string
+	EndTime       string
+	Status        SessionStatus
+	CoachID       *int64
+	Attended      bool
+	CoachNotes    *string
+	Rating        *int
+	CompletedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// StartsAt combines the session date and start time into a single timestamp
+func (s *BookingSession) StartsAt() time.Time {
+	start, err := time.Parse("15:04:05", s.StartTime)
+	if err != nil {
+		start, _ = time.Parse("15:04", s.StartTime)
+	}
+	return time.Date(
+		s.SessionDate.Year(), s.SessionDate.Month(), s.SessionDate.Day(),
+		start.Hour(), start.Minute(), start.Second(), 0, time.Local,
+	)
 }
 
 // SessionStatus represents session status
@@ -169,3 +252,434 @@ type BookingSessionInfo struct {
 	EndTime     string
 	CoachName   *string
 }
+
+// ================== Cancellation ==================
+
+// CancellationPolicy defines the refund tiers applied when a booking is cancelled
+type CancellationPolicy struct {
+	FullRefundWindow     time.Duration // Full refund when cancelled at least this long before the first remaining session
+	PartialRefundWindow  time.Duration // Partial refund when cancelled at least this long before the first remaining session
+	PartialRefundPercent float64       // Percentage (0-100) refunded in the partial tier
+}
+
+// DefaultCancellationPolicy returns the policy used when none is configured:
+// full refund more than 24h before the first session, 50% until it starts
+func DefaultCancellationPolicy() CancellationPolicy {
+	return CancellationPolicy{
+		FullRefundWindow:     24 * time.Hour,
+		PartialRefundWindow:  0,
+		PartialRefundPercent: 50,
+	}
+}
+
+// NewCancellationPolicy builds a policy from config, falling back to defaults for unset values
+func NewCancellationPolicy(cfg internal.CancellationConfig) CancellationPolicy {
+	policy := DefaultCancellationPolicy()
+	if cfg.FullRefundWindow > 0 {
+		policy.FullRefundWindow = cfg.FullRefundWindow
+	}
+	if cfg.PartialRefundWindow > 0 {
+		policy.PartialRefundWindow = cfg.PartialRefundWindow
+	}
+	if cfg.PartialRefundPercent > 0 {
+		policy.PartialRefundPercent = cfg.PartialRefundPercent
+	}
+	return policy
+}
+
+// RefundPercent returns the refund percentage for cancelling the given duration before the session
+func (p CancellationPolicy) RefundPercent(untilSession time.Duration) float64 {
+	switch {
+	case untilSession >= p.FullRefundWindow:
+		return 100
+	case untilSession >= p.PartialRefundWindow && untilSession > 0:
+		return p.PartialRefundPercent
+	}
+	return 0
+}
+
+// CalculateRefund returns the amount to refund when the booking is cancelled at the given time.
+// Only the remaining (not yet attended) sessions are refundable, and vendor cancellations
+// are always refunded in full.
+func (p CancellationPolicy) CalculateRefund(booking *Booking, cancelledBy CancelledBy, at time.Time) float64 {
+	if booking.PaymentStatus != PaymentStatusPaid || booking.TotalSessions == 0 {
+		return 0
+	}
+
+	remaining := booking.RemainingSessions()
+	if len(remaining) == 0 {
+		return 0
+	}
+
+	refundable := booking.TotalAmount * float64(len(remaining)) / float64(booking.TotalSessions)
+
+	percent := float64(100)
+	if cancelledBy == CancelledByParent {
+		percent = p.RefundPercent(booking.FirstRemainingSession().StartsAt().Sub(at))
+	}
+
+	return math.Round(refundable*percent) / 100
+}
+
+// CancelBookingRequest represents a booking cancellation request
+type CancelBookingRequest struct {
+	BookingID   int64
+	UserID      int64
+	CancelledBy CancelledBy
+	Reason      string
+}
+
+// Validate validates the cancel booking request
+func (r *CancelBookingRequest) Validate() error {
+	if r.BookingID == 0 {
+		return fmt.Errorf("booking_id is required")
+	}
+	switch r.CancelledBy {
+	case CancelledByParent, CancelledByVendor, CancelledBySystem:
+	default:
+		return fmt.Errorf("invalid cancelled_by: %s", r.CancelledBy)
+	}
+	if r.Reason == "" {
+		return fmt.Errorf("cancellation_reason is required")
+	}
+	if len(r.Reason) > 500 {
+		return fmt.Errorf("cancellation_reason must not exceed 500 characters")
+	}
+	return nil
+}
+
+// BookingCancellation represents the outcome of a cancellation
+type BookingCancellation struct {
+	BookingID         int64
+	Reason            string
+	CancelledBy       CancelledBy
+	CancelledByUserID *int64
+	CancelledAt       time.Time
+	RefundAmount      float64
+	RefundID          int64 // Refund queued with the cancellation, zero when nothing is refunded
+}
+
+// RefundStatus returns the refund state reported to clients
+func (c *BookingCancellation) RefundStatus() string {
+	if c.RefundAmount > 0 {
+		return "pending"
+	}
+	return "not_applicable"
+}
+
+// ================== Rescheduling ==================
+
+// ReschedulePolicy defines the vendor rules for moving a booked session
+type ReschedulePolicy struct {
+	Cutoff        time.Duration // No changes allowed within this window before a session
+	MaxPerBooking int           // Maximum number of reschedules across all sessions of a booking
+}
+
+// CheckCutoff verifies a session starting at the given time can still be moved
+func (p ReschedulePolicy) CheckCutoff(sessionStart, now time.Time) error {
+	if sessionStart.Sub(now) < p.Cutoff {
+		return fmt.Errorf("sessions cannot be rescheduled within %s of the start time", formatDuration(p.Cutoff))
+	}
+	return nil
+}
+
+// CheckLimit verifies the booking has reschedules left
+func (p ReschedulePolicy) CheckLimit(rescheduleCount int) error {
+	if rescheduleCount >= p.MaxPerBooking {
+		return fmt.Errorf("booking has reached the maximum of %d reschedules", p.MaxPerBooking)
+	}
+	return nil
+}
+
+// CanBeRescheduled checks if sessions of the booking can still be moved
+func (b *Booking) CanBeRescheduled() bool {
+	switch b.Status {
+	case BookingStatusPending, BookingStatusConfirmed, BookingStatusOngoing:
+		return true
+	}
+	return false
+}
+
+// FindSession returns the booking session with the given ID
+func (b *Booking) FindSession(sessionID int64) *BookingSession {
+	for _, session := range b.Sessions {
+		if session.ID == sessionID {
+			return session
+		}
+	}
+	return nil
+}
+
+// RescheduleSessionRequest represents a request to move a session to another slot
+type RescheduleSessionRequest struct {
+	BookingID     int64
+	SessionID     int64
+	RequestedBy   int64
+	NewScheduleID int64
+	NewDate       time.Time
+	Reason        *string
+}
+
+// Validate validates the reschedule request
+func (r *RescheduleSessionRequest) Validate() error {
+	if r.BookingID == 0 {
+		return fmt.Errorf("booking_id is required")
+	}
+	if r.SessionID == 0 {
+		return fmt.Errorf("session_id is required")
+	}
+	if r.NewScheduleID == 0 {
+		return fmt.Errorf("schedule_id is required")
+	}
+	if r.NewDate.IsZero() {
+		return fmt.Errorf("session_date is required")
+	}
+	if r.Reason != nil && len(*r.Reason) > 500 {
+		return fmt.Errorf("reason must not exceed 500 characters")
+	}
+	return nil
+}
+
+// SessionReschedule represents one entry in a session's reschedule history
+type SessionReschedule struct {
+	ID               int64
+	BookingID        int64
+	BookingSessionID int64
+	FromScheduleID   int64
+	FromSessionDate  time.Time
+	ToScheduleID     int64
+	ToSessionDate    time.Time
+	RescheduledBy    int64
+	Reason           *string
+	CreatedAt        time.Time
+}
+
+// RescheduleResult represents the outcome of a reschedule
+type RescheduleResult struct {
+	Session              *BookingSession
+	Reschedule           *SessionReschedule
+	RemainingReschedules int
+}
+
+// formatDuration renders a duration in whole hours when possible
+func formatDuration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return d.String()
+}
+
+// HoldExpiredReason is recorded on bookings released by the hold expiry worker
+const HoldExpiredReason = "Payment not completed before hold expired"
+
+// PaymentReceivedReason is recorded on pending bookings confirmed by their payment
+const PaymentReceivedReason = "Payment received"
+
+// HoldPolicy defines how long an unpaid booking keeps its slots reserved
+type HoldPolicy struct {
+	Window        time.Duration
+	SweepInterval time.Duration
+	BatchSize     int
+}
+
+// DefaultHoldPolicy returns the policy used when none is configured:
+// holds last 30 minutes and are swept every minute
+func DefaultHoldPolicy() HoldPolicy {
+	return HoldPolicy{
+		Window:        30 * time.Minute,
+		SweepInterval: time.Minute,
+		BatchSize:     100,
+	}
+}
+
+// NewHoldPolicy builds a policy from config, falling back to defaults for unset values
+func NewHoldPolicy(cfg internal.HoldConfig) HoldPolicy {
+	policy := DefaultHoldPolicy()
+	if cfg.Window > 0 {
+		policy.Window = cfg.Window
+	}
+	if cfg.SweepInterval > 0 {
+		policy.SweepInterval = cfg.SweepInterval
+	}
+	if cfg.BatchSize > 0 {
+		policy.BatchSize = cfg.BatchSize
+	}
+	return policy
+}
+
+// ExpiresAt returns when the hold on a booking created at the given time runs out
+func (p HoldPolicy) ExpiresAt(createdAt time.Time) time.Time {
+	return createdAt.Add(p.Window)
+}
+
+// IsHeld returns true if the booking is still waiting for payment, whether or not the vendor confirmed it
+func (b *Booking) IsHeld() bool {
+	if b.PaymentStatus != PaymentStatusUnpaid {
+		return false
+	}
+	return b.Status == BookingStatusPending || b.Status == BookingStatusConfirmed
+}
+
+// IsValid checks if the booking status is a known status
+func (s BookingStatus) IsValid() bool {
+	switch s {
+	case BookingStatusPending, BookingStatusConfirmed, BookingStatusOngoing, BookingStatusCancelled, BookingStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// BookingListFilter narrows down a parent's booking history
+type BookingListFilter struct {
+	ParentID  int64
+	Status    *BookingStatus
+	ChildID   *int64
+	ServiceID *int64
+	From      *time.Time // Only bookings with a session on or after this date
+	To        *time.Time // Only bookings with a session on or before this date
+	Upcoming  bool       // Only bookings with a scheduled session still to come
+	Cursor    *BookingCursor
+	Limit     int
+	Now       time.Time // Sessions from this time on are upcoming, the cursor's AsOf when paging
+}
+
+// BookingCursor marks the position after the last booking of a page.
+// Bookings with an upcoming session come first ordered by that session, the rest follow newest first.
+// Which sessions count as upcoming is decided at AsOf, the time the first page was listed, so bookings
+// whose session passes while the parent pages through the list keep their place.
+type BookingCursor struct {
+	Upcoming bool      `json:"u"`
+	SortAt   time.Time `json:"t"`
+	ID       int64     `json:"id"`
+	AsOf     time.Time `json:"n"`
+}
+
+// Encode serializes the cursor into an opaque string
+func (c *BookingCursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeBookingCursor parses a cursor produced by Encode
+func DecodeBookingCursor(value string) (*BookingCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	var cursor BookingCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID <= 0 || cursor.AsOf.IsZero() {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// BookingListItem is a booking in a list, with its session summary
+type BookingListItem struct {
+	Booking           *Booking
+	NextSession       *BookingSession
+	CompletedSessions int
+	Cursor            BookingCursor
+}
+
+// VendorBookingFilter narrows down the bookings a vendor received
+type VendorBookingFilter struct {
+	VendorID int64
+	Date     *time.Time // Only bookings with a session on this date
+	Status   *BookingStatus
+	Page     int
+	Limit    int
+	Now      time.Time
+}
+
+// Offset returns the number of bookings before the requested page
+func (f *VendorBookingFilter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
+
+// VendorBookingItem is a booking as shown in the vendor's inbox.
+// Session is the session on the filtered date, or the next scheduled one.
+type VendorBookingItem struct {
+	Booking          *Booking
+	Session          *BookingSession
+	ServiceName      string
+	ChildName        string
+	ChildDateOfBirth time.Time
+	ChildPhoto       *string
+	ParentName       string
+	ParentPhone      string
+	CoachName        *string
+}
+
+// CanCompleteSessions checks if sessions of the booking can be marked as delivered.
+// A vendor can confirm a booking before it is paid, but its sessions are only delivered once it is.
+func (b *Booking) CanCompleteSessions() error {
+	switch b.Status {
+	case BookingStatusConfirmed, BookingStatusOngoing:
+	default:
+		return fmt.Errorf("sessions of a booking with status %s cannot be completed", b.Status)
+	}
+	if b.PaymentStatus != PaymentStatusPaid {
+		return fmt.Errorf("sessions of a booking with payment status %s cannot be completed", b.PaymentStatus)
+	}
+	return nil
+}
+
+// AssignedCoachID returns the coach teaching the session, falling back to the coach chosen for the booking
+func (b *Booking) AssignedCoachID(session *BookingSession) *int64 {
+	if session.CoachID != nil {
+		return session.CoachID
+	}
+	return b.PreferredCoach
+}
+
+// CanBeCompleted checks if the session can be marked as attended or no-show at the given time.
+// Sessions can only be completed once they have started.
+func (s *BookingSession) CanBeCompleted(at time.Time) error {
+	if !s.Status.CanTransitionTo(SessionStatusCompleted) {
+		return fmt.Errorf("session with status %s cannot be completed", s.Status)
+	}
+	if at.Before(s.StartsAt()) {
+		return fmt.Errorf("session has not started yet")
+	}
+	return nil
+}
+
+// CompleteSessionRequest represents a vendor or coach recording the outcome of a session
+type CompleteSessionRequest struct {
+	SessionID   int64
+	CompletedBy int64 // User ID of the vendor or coach
+	Role        string
+	Attended    bool
+	CoachNotes  *string
+	Rating      *int
+}
+
+// Validate validates the session completion request
+func (r *CompleteSessionRequest) Validate() error {
+	if r.SessionID <= 0 {
+		return fmt.Errorf("session_id is required")
+	}
+	if r.Rating != nil && (*r.Rating < 1 || *r.Rating > 5) {
+		return fmt.Errorf("rating must be between 1 and 5")
+	}
+	if r.Rating != nil && !r.Attended {
+		return fmt.Errorf("rating can only be given for attended sessions")
+	}
+	return nil
+}
+
+// SessionStatus returns the status the session moves to
+func (r *CompleteSessionRequest) SessionStatus() SessionStatus {
+	if r.Attended {
+		return SessionStatusCompleted
+	}
+	return SessionStatusNoShow
+}
+
+// SessionCompletionResult is the outcome of completing a session
+type SessionCompletionResult struct {
+	Session           *BookingSession
+	BookingStatus     BookingStatus
+	CompletedSessions int
+}
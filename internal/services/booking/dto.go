@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/child"
 	"github.com/frahmantamala/jadiles/internal/core/common"
 	"github.com/frahmantamala/jadiles/internal/services"
 	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
@@ -101,6 +103,64 @@ func (p *CreateBookingParams) ToCreateBookingRequest(parentID int64) (*services.
 	}, nil
 }
 
+// CancelBookingParams represents the HTTP request body for cancelling a booking
+type CancelBookingParams struct {
+	CancellationReason string `json:"cancellation_reason" validate:"required,max=500"`
+}
+
+// NewCancelBookingParams parses booking cancellation request
+func NewCancelBookingParams(r *http.Request) (*CancelBookingParams, error) {
+	var req v1.CancelBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+
+	return &CancelBookingParams{
+		CancellationReason: req.CancellationReason,
+	}, nil
+}
+
+// Validate validates the cancellation parameters
+func (p *CancelBookingParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToCancelBookingRequest converts DTO to domain request
+func (p *CancelBookingParams) ToCancelBookingRequest(bookingID, userID int64, role string) *services.CancelBookingRequest {
+	cancelledBy := services.CancelledByParent
+	if role == "vendor" {
+		cancelledBy = services.CancelledByVendor
+	}
+
+	return &services.CancelBookingRequest{
+		BookingID:   bookingID,
+		UserID:      userID,
+		CancelledBy: cancelledBy,
+		Reason:      p.CancellationReason,
+	}
+}
+
+// ToV1BookingCancelled converts a cancellation outcome to OpenAPI v1 response
+func ToV1BookingCancelled(booking *services.Booking, cancellation *services.BookingCancellation) *v1.BookingCancelledResponse {
+	status := v1.BookingStatus(booking.Status)
+	refundAmount := cancellation.RefundAmount
+	refundStatus := cancellation.RefundStatus()
+	message := "Booking cancelled successfully"
+
+	resp := &v1.BookingCancelledResponse{Message: &message}
+	resp.Data.Booking = &struct {
+		RefundAmount *float64          `json:"refund_amount,omitempty"`
+		RefundStatus *string           `json:"refund_status,omitempty"`
+		Status       *v1.BookingStatus `json:"status,omitempty"`
+	}{
+		RefundAmount: &refundAmount,
+		RefundStatus: &refundStatus,
+		Status:       &status,
+	}
+
+	return resp
+}
+
 // BookingConfirmationResponse is a temporary response structure
 type BookingConfirmationResponse struct {
 	Data BookingConfirmationData `json:"data"`
@@ -189,6 +249,14 @@ func ToV1BookingDetail(booking *services.Booking, enrichment *BookingEnrichment)
 			Status:        &sessionStatus,
 		}
 
+		// Outcome recorded by the vendor or coach
+		if session.CompletedAt != nil {
+			bookingSession.Attended = &session.Attended
+			bookingSession.CoachNotes = session.CoachNotes
+			bookingSession.Rating = session.Rating
+			bookingSession.CompletedAt = session.CompletedAt
+		}
+
 		if session.Status == services.SessionStatusCompleted {
 			completedCount++
 		}
@@ -274,3 +342,674 @@ type BookingEnrichment struct {
 	ChildName    string
 	VendorName   string
 }
+
+// RescheduleSessionParams represents the HTTP request body for rescheduling a session
+type RescheduleSessionParams struct {
+	ScheduleID  int64   `json:"schedule_id" validate:"required,gt=0"`
+	SessionDate string  `json:"session_date" validate:"required"` // YYYY-MM-DD format
+	Reason      *string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+// NewRescheduleSessionParams parses session reschedule request
+func NewRescheduleSessionParams(r *http.Request) (*RescheduleSessionParams, error) {
+	var params RescheduleSessionParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the reschedule parameters
+func (p *RescheduleSessionParams) Validate(ctx context.Context) error {
+	if err := common.ValidateStruct(p); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse("2006-01-02", p.SessionDate); err != nil {
+		return internal.NewValidationError("invalid session_date format, expected YYYY-MM-DD")
+	}
+
+	return nil
+}
+
+// ToRescheduleSessionRequest converts DTO to domain request
+func (p *RescheduleSessionParams) ToRescheduleSessionRequest(bookingID, sessionID, parentID int64) (*services.RescheduleSessionRequest, error) {
+	sessionDate, err := time.Parse("2006-01-02", p.SessionDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.RescheduleSessionRequest{
+		BookingID:     bookingID,
+		SessionID:     sessionID,
+		RequestedBy:   parentID,
+		NewScheduleID: p.ScheduleID,
+		NewDate:       sessionDate,
+		Reason:        p.Reason,
+	}, nil
+}
+
+// RescheduleSessionResponse is the response for a rescheduled session
+type RescheduleSessionResponse struct {
+	Data    RescheduleSessionData `json:"data"`
+	Message string                `json:"message"`
+}
+
+type RescheduleSessionData struct {
+	Session              v1.BookingSession    `json:"session"`
+	Reschedule           SessionRescheduleDTO `json:"reschedule"`
+	RemainingReschedules int                  `json:"remaining_reschedules"`
+}
+
+// SessionRescheduleDTO represents one entry of reschedule history
+type SessionRescheduleDTO struct {
+	ID              int64              `json:"id"`
+	SessionID       int64              `json:"session_id"`
+	FromScheduleID  int64              `json:"from_schedule_id"`
+	FromSessionDate openapi_types.Date `json:"from_session_date"`
+	ToScheduleID    int64              `json:"to_schedule_id"`
+	ToSessionDate   openapi_types.Date `json:"to_session_date"`
+	Reason          *string            `json:"reason,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// SessionReschedulesResponse lists the reschedule history of a booking
+type SessionReschedulesResponse struct {
+	Data []SessionRescheduleDTO `json:"data"`
+}
+
+// ToRescheduleSessionResponse converts a reschedule result to response
+func ToRescheduleSessionResponse(result *services.RescheduleResult) *RescheduleSessionResponse {
+	session := result.Session
+	status := v1.SessionStatus(session.Status)
+	sessionDate := openapi_types.Date{Time: session.SessionDate}
+
+	return &RescheduleSessionResponse{
+		Data: RescheduleSessionData{
+			Session: v1.BookingSession{
+				Id:          &session.ID,
+				SessionDate: &sessionDate,
+				StartTime:   &session.StartTime,
+				EndTime:     &session.EndTime,
+				Status:      &status,
+			},
+			Reschedule:           toSessionRescheduleDTO(result.Reschedule),
+			RemainingReschedules: result.RemainingReschedules,
+		},
+		Message: "Session rescheduled successfully",
+	}
+}
+
+// ToSessionReschedulesResponse converts reschedule history to response
+func ToSessionReschedulesResponse(history []*services.SessionReschedule) *SessionReschedulesResponse {
+	data := make([]SessionRescheduleDTO, 0, len(history))
+	for _, h := range history {
+		data = append(data, toSessionRescheduleDTO(h))
+	}
+	return &SessionReschedulesResponse{Data: data}
+}
+
+func toSessionRescheduleDTO(h *services.SessionReschedule) SessionRescheduleDTO {
+	return SessionRescheduleDTO{
+		ID:              h.ID,
+		SessionID:       h.BookingSessionID,
+		FromScheduleID:  h.FromScheduleID,
+		FromSessionDate: openapi_types.Date{Time: h.FromSessionDate},
+		ToScheduleID:    h.ToScheduleID,
+		ToSessionDate:   openapi_types.Date{Time: h.ToSessionDate},
+		Reason:          h.Reason,
+		CreatedAt:       h.CreatedAt,
+	}
+}
+
+const (
+	defaultBookingListLimit = 20
+	maxBookingListLimit     = 100
+)
+
+// ListBookingsParams represents the query parameters for listing a parent's bookings
+type ListBookingsParams struct {
+	Status    string
+	ChildID   *int64
+	ServiceID *int64
+	From      string // YYYY-MM-DD format
+	To        string // YYYY-MM-DD format
+	Upcoming  bool
+	Cursor    string
+	Limit     int
+}
+
+// NewListBookingsParams parses the booking list query parameters
+func NewListBookingsParams(r *http.Request) (*ListBookingsParams, error) {
+	query := r.URL.Query()
+
+	params := &ListBookingsParams{
+		Status: query.Get("status"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Cursor: query.Get("cursor"),
+		Limit:  defaultBookingListLimit,
+	}
+
+	if childIDStr := query.Get("child_id"); childIDStr != "" {
+		childID, err := strconv.ParseInt(childIDStr, 10, 64)
+		if err != nil {
+			return nil, internal.NewValidationError("child_id must be a valid integer")
+		}
+		params.ChildID = &childID
+	}
+
+	if serviceIDStr := query.Get("service_id"); serviceIDStr != "" {
+		serviceID, err := strconv.ParseInt(serviceIDStr, 10, 64)
+		if err != nil {
+			return nil, internal.NewValidationError("service_id must be a valid integer")
+		}
+		params.ServiceID = &serviceID
+	}
+
+	if upcomingStr := query.Get("upcoming"); upcomingStr != "" {
+		upcoming, err := strconv.ParseBool(upcomingStr)
+		if err != nil {
+			return nil, internal.NewValidationError("upcoming must be true or false")
+		}
+		params.Upcoming = upcoming
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, internal.NewValidationError("limit must be a valid integer")
+		}
+		params.Limit = limit
+	}
+
+	return params, nil
+}
+
+// Validate validates the booking list parameters
+func (p *ListBookingsParams) Validate(ctx context.Context) error {
+	if p.Status != "" && !services.BookingStatus(p.Status).IsValid() {
+		return internal.NewValidationError(formatString("invalid status: %s", p.Status))
+	}
+
+	if p.Limit < 1 || p.Limit > maxBookingListLimit {
+		return internal.NewValidationError(formatString("limit must be between 1 and %d", maxBookingListLimit))
+	}
+
+	from, to, err := p.dateRange()
+	if err != nil {
+		return err
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return internal.NewValidationError("to must be on or after from")
+	}
+
+	if p.Cursor != "" {
+		if _, err := services.DecodeBookingCursor(p.Cursor); err != nil {
+			return internal.NewValidationError("cursor is invalid")
+		}
+	}
+
+	return nil
+}
+
+// ToBookingListFilter converts the parameters to a domain filter
+func (p *ListBookingsParams) ToBookingListFilter(parentID int64) (*services.BookingListFilter, error) {
+	from, to, err := p.dateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &services.BookingListFilter{
+		ParentID:  parentID,
+		ChildID:   p.ChildID,
+		ServiceID: p.ServiceID,
+		From:      from,
+		To:        to,
+		Upcoming:  p.Upcoming,
+		Limit:     p.Limit,
+	}
+
+	if p.Status != "" {
+		status := services.BookingStatus(p.Status)
+		filter.Status = &status
+	}
+
+	if p.Cursor != "" {
+		cursor, err := services.DecodeBookingCursor(p.Cursor)
+		if err != nil {
+			return nil, internal.NewValidationError("cursor is invalid")
+		}
+		filter.Cursor = cursor
+	}
+
+	return filter, nil
+}
+
+func (p *ListBookingsParams) dateRange() (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if p.From != "" {
+		date, err := time.Parse("2006-01-02", p.From)
+		if err != nil {
+			return nil, nil, internal.NewValidationError("from: invalid date format, expected YYYY-MM-DD")
+		}
+		from = &date
+	}
+
+	if p.To != "" {
+		date, err := time.Parse("2006-01-02", p.To)
+		if err != nil {
+			return nil, nil, internal.NewValidationError("to: invalid date format, expected YYYY-MM-DD")
+		}
+		to = &date
+	}
+
+	return from, to, nil
+}
+
+// BookingsListResponse is the booking list with cursor pagination
+type BookingsListResponse struct {
+	Data       []v1.Booking          `json:"data"`
+	Pagination BookingListPagination `json:"pagination"`
+}
+
+// BookingListPagination holds the cursor of the next page
+type BookingListPagination struct {
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
+	Limit      int     `json:"limit"`
+}
+
+// ToBookingsListResponse converts a page of bookings to response
+func ToBookingsListResponse(items []*services.BookingListItem, enrichments map[int64]*BookingEnrichment, nextCursor *string, limit int) *BookingsListResponse {
+	data := make([]v1.Booking, 0, len(items))
+	for _, item := range items {
+		data = append(data, ToV1Booking(item, enrichments[item.Booking.ID]))
+	}
+
+	return &BookingsListResponse{
+		Data: data,
+		Pagination: BookingListPagination{
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != nil,
+			Limit:      limit,
+		},
+	}
+}
+
+// ToV1Booking converts a booking list item to API booking
+func ToV1Booking(item *services.BookingListItem, enrichment *BookingEnrichment) v1.Booking {
+	b := item.Booking
+	bookingType := v1.BookingType(b.BookingType)
+	status := v1.BookingStatus(b.Status)
+	paymentStatus := v1.PaymentStatusEnum(b.PaymentStatus)
+	completedSessions := item.CompletedSessions
+
+	booking := v1.Booking{
+		Id:                &b.ID,
+		BookingNumber:     &b.BookingNumber,
+		BookingType:       &bookingType,
+		Status:            &status,
+		PaymentStatus:     &paymentStatus,
+		TotalAmount:       &b.TotalAmount,
+		TotalSessions:     &b.TotalSessions,
+		CompletedSessions: &completedSessions,
+		CreatedAt:         &b.CreatedAt,
+	}
+
+	if item.NextSession != nil {
+		sessionDate := openapi_types.Date{Time: item.NextSession.SessionDate}
+		booking.NextSession = &struct {
+			EndTime     *string             `json:"end_time,omitempty"`
+			SessionDate *openapi_types.Date `json:"session_date,omitempty"`
+			StartTime   *string             `json:"start_time,omitempty"`
+		}{
+			SessionDate: &sessionDate,
+			StartTime:   &item.NextSession.StartTime,
+			EndTime:     &item.NextSession.EndTime,
+		}
+	}
+
+	if enrichment == nil {
+		return booking
+	}
+
+	if enrichment.ServiceName != "" {
+		booking.Service = &struct {
+			Category *string `json:"category,omitempty"`
+			Id       *int64  `json:"id,omitempty"`
+			Name     *string `json:"name,omitempty"`
+		}{
+			Id:   &b.ServiceID,
+			Name: &enrichment.ServiceName,
+		}
+		if enrichment.CategoryName != "" {
+			booking.Service.Category = &enrichment.CategoryName
+		}
+	}
+
+	if enrichment.ChildName != "" {
+		booking.Child = &v1.Child{
+			Id:   &b.ChildID,
+			Name: &enrichment.ChildName,
+		}
+	}
+
+	if enrichment.VendorName != "" {
+		booking.Vendor = &struct {
+			BusinessName *string `json:"business_name,omitempty"`
+			Id           *int64  `json:"id,omitempty"`
+			Logo         *string `json:"logo,omitempty"`
+		}{
+			Id:           &b.VendorID,
+			BusinessName: &enrichment.VendorName,
+		}
+	}
+
+	return booking
+}
+
+// ListVendorBookingsParams represents the query parameters for a vendor's booking inbox
+type ListVendorBookingsParams struct {
+	Date   string // YYYY-MM-DD format
+	Status string
+	Page   int
+	Limit  int
+}
+
+// NewListVendorBookingsParams parses the vendor booking list query parameters
+func NewListVendorBookingsParams(r *http.Request) (*ListVendorBookingsParams, error) {
+	query := r.URL.Query()
+
+	params := &ListVendorBookingsParams{
+		Date:   query.Get("date"),
+		Status: query.Get("status"),
+		Page:   1,
+		Limit:  defaultBookingListLimit,
+	}
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil {
+			return nil, internal.NewValidationError("page must be a valid integer")
+		}
+		params.Page = page
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, internal.NewValidationError("limit must be a valid integer")
+		}
+		params.Limit = limit
+	}
+
+	return params, nil
+}
+
+// Validate validates the vendor booking list parameters
+func (p *ListVendorBookingsParams) Validate(ctx context.Context) error {
+	if p.Status != "" && !services.BookingStatus(p.Status).IsValid() {
+		return internal.NewValidationError(formatString("invalid status: %s", p.Status))
+	}
+
+	if p.Page < 1 {
+		return internal.NewValidationError("page must be at least 1")
+	}
+
+	if p.Limit < 1 || p.Limit > maxBookingListLimit {
+		return internal.NewValidationError(formatString("limit must be between 1 and %d", maxBookingListLimit))
+	}
+
+	if p.Date != "" {
+		if _, err := time.Parse("2006-01-02", p.Date); err != nil {
+			return internal.NewValidationError("date: invalid date format, expected YYYY-MM-DD")
+		}
+	}
+
+	return nil
+}
+
+// ToVendorBookingFilter converts the parameters to a domain filter
+func (p *ListVendorBookingsParams) ToVendorBookingFilter() (*services.VendorBookingFilter, error) {
+	filter := &services.VendorBookingFilter{
+		Page:  p.Page,
+		Limit: p.Limit,
+	}
+
+	if p.Date != "" {
+		date, err := time.Parse("2006-01-02", p.Date)
+		if err != nil {
+			return nil, internal.NewValidationError("date: invalid date format, expected YYYY-MM-DD")
+		}
+		filter.Date = &date
+	}
+
+	if p.Status != "" {
+		status := services.BookingStatus(p.Status)
+		filter.Status = &status
+	}
+
+	return filter, nil
+}
+
+// RejectBookingParams represents the HTTP request body for rejecting a booking
+type RejectBookingParams struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// NewRejectBookingParams parses booking rejection request
+func NewRejectBookingParams(r *http.Request) (*RejectBookingParams, error) {
+	var params RejectBookingParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the rejection parameters
+func (p *RejectBookingParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// VendorBookingsResponse is the vendor booking inbox with page pagination
+type VendorBookingsResponse struct {
+	Data       []v1.VendorBooking `json:"data"`
+	Pagination v1.Pagination      `json:"pagination"`
+}
+
+// ToVendorBookingsResponse converts a page of vendor bookings to response
+func ToVendorBookingsResponse(items []*services.VendorBookingItem, page, limit int, total int64) *VendorBookingsResponse {
+	data := make([]v1.VendorBooking, 0, len(items))
+	for _, item := range items {
+		data = append(data, ToV1VendorBooking(item))
+	}
+
+	totalCount := int(total)
+	totalPages := (totalCount + limit - 1) / limit
+
+	return &VendorBookingsResponse{
+		Data: data,
+		Pagination: v1.Pagination{
+			Page:       &page,
+			Limit:      &limit,
+			Total:      &totalCount,
+			TotalPages: &totalPages,
+		},
+	}
+}
+
+// ToV1VendorBooking converts a vendor booking item to API vendor booking
+func ToV1VendorBooking(item *services.VendorBookingItem) v1.VendorBooking {
+	b := item.Booking
+	childAge := (&child.Child{DateOfBirth: item.ChildDateOfBirth}).CalculateAge()
+
+	booking := v1.VendorBooking{
+		Id:            &b.ID,
+		BookingNumber: &b.BookingNumber,
+		ParentNotes:   b.ParentNotes,
+		Child: &struct {
+			Age   *int    `json:"age,omitempty"`
+			Name  *string `json:"name,omitempty"`
+			Photo *string `json:"photo,omitempty"`
+		}{
+			Age:   &childAge,
+			Name:  &item.ChildName,
+			Photo: item.ChildPhoto,
+		},
+		Parent: &struct {
+			FullName *string `json:"full_name,omitempty"`
+			Phone    *string `json:"phone,omitempty"`
+			Whatsapp *string `json:"whatsapp,omitempty"`
+		}{
+			FullName: &item.ParentName,
+			Phone:    &item.ParentPhone,
+		},
+		Service: &struct {
+			Name *string `json:"name,omitempty"`
+		}{
+			Name: &item.ServiceName,
+		},
+	}
+
+	if item.CoachName != nil {
+		booking.Coach = &struct {
+			FullName *string `json:"full_name,omitempty"`
+		}{
+			FullName: item.CoachName,
+		}
+	}
+
+	if item.Session != nil {
+		sessionDate := openapi_types.Date{Time: item.Session.SessionDate}
+		sessionStatus := v1.SessionStatus(item.Session.Status)
+		booking.Session = &struct {
+			EndTime       *string             `json:"end_time,omitempty"`
+			SessionDate   *openapi_types.Date `json:"session_date,omitempty"`
+			SessionNumber *int                `json:"session_number,omitempty"`
+			StartTime     *string             `json:"start_time,omitempty"`
+			Status        *v1.SessionStatus   `json:"status,omitempty"`
+		}{
+			SessionDate:   &sessionDate,
+			SessionNumber: &item.Session.SessionNumber,
+			StartTime:     &item.Session.StartTime,
+			EndTime:       &item.Session.EndTime,
+			Status:        &sessionStatus,
+		}
+	}
+
+	return booking
+}
+
+// CompleteSessionParams represents the HTTP request body for completing a session
+type CompleteSessionParams struct {
+	Attended   *bool   `json:"attended" validate:"required"`
+	CoachNotes *string `json:"coach_notes,omitempty" validate:"omitempty,max=1000"`
+	Rating     *int    `json:"rating,omitempty" validate:"omitempty,min=1,max=5"`
+}
+
+// NewCompleteSessionParams parses session completion request
+func NewCompleteSessionParams(r *http.Request) (*CompleteSessionParams, error) {
+	var params CompleteSessionParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the session completion parameters
+func (p *CompleteSessionParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToCompleteSessionRequest converts DTO to domain request
+func (p *CompleteSessionParams) ToCompleteSessionRequest(sessionID, userID int64, role string) *services.CompleteSessionRequest {
+	return &services.CompleteSessionRequest{
+		SessionID:   sessionID,
+		CompletedBy: userID,
+		Role:        role,
+		Attended:    *p.Attended,
+		CoachNotes:  p.CoachNotes,
+		Rating:      p.Rating,
+	}
+}
+
+// ToV1SessionCompleted converts a session completion to response
+func ToV1SessionCompleted(result *services.SessionCompletionResult) *v1.SessionCompletedResponse {
+	session := result.Session
+	sessionDate := openapi_types.Date{Time: session.SessionDate}
+	status := v1.SessionStatus(session.Status)
+
+	resp := &v1.SessionCompletedResponse{}
+	resp.Data.Session = &v1.BookingSession{
+		Id:            &session.ID,
+		SessionNumber: &session.SessionNumber,
+		SessionDate:   &sessionDate,
+		StartTime:     &session.StartTime,
+		EndTime:       &session.EndTime,
+		Status:        &status,
+		Attended:      &session.Attended,
+		CoachNotes:    session.CoachNotes,
+		Rating:        session.Rating,
+		CompletedAt:   session.CompletedAt,
+	}
+
+	message := "Session marked as attended"
+	if session.Status == services.SessionStatusNoShow {
+		message = "Session marked as no-show"
+	}
+	if result.BookingStatus == services.BookingStatusCompleted {
+		message += ", all sessions of the booking are done"
+	}
+	resp.Message = &message
+
+	return resp
+}
+
+// BookingDetailResponse is the booking detail with its status timeline
+type BookingDetailResponse struct {
+	Data BookingDetailWithTimeline `json:"data"`
+}
+
+// BookingDetailWithTimeline extends the API booking detail with the status timeline
+type BookingDetailWithTimeline struct {
+	v1.BookingDetail
+	Timeline []BookingStatusChangeDTO `json:"timeline"`
+}
+
+// BookingStatusChangeDTO is one entry of a booking's status timeline
+type BookingStatusChangeDTO struct {
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ActorType  string    `json:"actor_type"`
+	ActorID    *int64    `json:"actor_id,omitempty"`
+	Reason     *string   `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ToBookingDetailResponse converts a booking and its timeline to response
+func ToBookingDetailResponse(booking *services.Booking, enrichment *BookingEnrichment, history []*services.BookingStatusChange) *BookingDetailResponse {
+	timeline := make([]BookingStatusChangeDTO, 0, len(history))
+	for _, change := range history {
+		entry := BookingStatusChangeDTO{
+			ToStatus:  string(change.ToStatus),
+			ActorType: string(change.ActorType),
+			ActorID:   change.ActorID,
+			Reason:    change.Reason,
+			CreatedAt: change.CreatedAt,
+		}
+		if change.FromStatus != nil {
+			from := string(*change.FromStatus)
+			entry.FromStatus = &from
+		}
+		timeline = append(timeline, entry)
+	}
+
+	return &BookingDetailResponse{
+		Data: BookingDetailWithTimeline{
+			BookingDetail: ToV1BookingDetail(booking, enrichment).Data,
+			Timeline:      timeline,
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package booking
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+// HoldRepository defines data access needed to expire booking holds
+type HoldRepository interface {
+	ExpireHeldBookings(ctx context.Context, cutoff time.Time, limit int, now time.Time) ([]int64, error)
+}
+
+// HoldExpirer releases slots held by unpaid bookings once their hold window has passed
+type HoldExpirer struct {
+	repo   HoldRepository
+	policy services.HoldPolicy
+}
+
+// NewHoldExpirer creates a new hold expirer
+func NewHoldExpirer(repo HoldRepository, policy services.HoldPolicy) *HoldExpirer {
+	return &HoldExpirer{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+// Run sweeps expired holds every SweepInterval until the context is cancelled
+func (e *HoldExpirer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.policy.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := e.ExpireOnce(ctx); err != nil {
+			slog.ErrorContext(ctx, "Failed to expire booking holds", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireOnce cancels all bookings whose hold has expired, one batch at a time.
+// Returns the number of bookings expired.
+func (e *HoldExpirer) ExpireOnce(ctx context.Context) (int, error) {
+	total := 0
+
+	for {
+		now := time.Now()
+		ids, err := e.repo.ExpireHeldBookings(ctx, now.Add(-e.policy.Window), e.policy.BatchSize, now)
+		if err != nil {
+			return total, err
+		}
+
+		total += len(ids)
+		if len(ids) > 0 {
+			slog.InfoContext(ctx, "Expired booking holds",
+				slog.Int("count", len(ids)),
+				slog.Any("booking_ids", ids),
+			)
+		}
+
+		// A partial batch means nothing is left to expire
+		if len(ids) < e.policy.BatchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
@@ -65,6 +65,45 @@ func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, response)
 }
 
+// ListBookings handles GET /bookings
+func (h *Handler) ListBookings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated parent ID from JWT context
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	// Parse and validate query parameters
+	params, err := NewListBookingsParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	filter, err := params.ToBookingListFilter(parentID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.ListBookings(ctx, filter)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
 // GetBooking handles GET /bookings/{booking_id}
 func (h *Handler) GetBooking(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -94,3 +133,278 @@ func (h *Handler) GetBooking(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, response)
 }
+
+// CancelBooking handles POST /bookings/{booking_id}/cancel
+func (h *Handler) CancelBooking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated user from JWT context (parent or vendor)
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+	role, _ := internal.ExtractRole(ctx)
+
+	// Parse booking ID from URL
+	bookingIDStr := chi.URLParam(r, "booking_id")
+	bookingID, err := strconv.ParseInt(bookingIDStr, 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("booking_id must be a valid integer"))
+		return
+	}
+
+	// Parse and validate request
+	params, err := NewCancelBookingParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	// Cancel booking
+	response, err := h.service.CancelBooking(ctx, params.ToCancelBookingRequest(bookingID, userID, role))
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// RescheduleSession handles POST /bookings/{booking_id}/sessions/{session_id}/reschedule
+func (h *Handler) RescheduleSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated parent ID from JWT context
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	// Parse booking and session IDs from URL
+	bookingID, err := strconv.ParseInt(chi.URLParam(r, "booking_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("booking_id must be a valid integer"))
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(chi.URLParam(r, "session_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("session_id must be a valid integer"))
+		return
+	}
+
+	// Parse and validate request
+	params, err := NewRescheduleSessionParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	req, err := params.ToRescheduleSessionRequest(bookingID, sessionID, parentID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError(err.Error()))
+		return
+	}
+
+	// Reschedule session
+	response, err := h.service.RescheduleSession(ctx, req)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// GetSessionReschedules handles GET /bookings/{booking_id}/reschedules
+func (h *Handler) GetSessionReschedules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated parent ID from JWT context
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	// Parse booking ID from URL
+	bookingID, err := strconv.ParseInt(chi.URLParam(r, "booking_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("booking_id must be a valid integer"))
+		return
+	}
+
+	response, err := h.service.GetSessionReschedules(ctx, bookingID, parentID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ListVendorBookings handles GET /vendor/bookings
+func (h *Handler) ListVendorBookings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated vendor user from JWT context
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	// Parse and validate query parameters
+	params, err := NewListVendorBookingsParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	filter, err := params.ToVendorBookingFilter()
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.ListVendorBookings(ctx, userID, filter)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ConfirmBooking handles POST /vendor/bookings/{booking_id}/confirm
+func (h *Handler) ConfirmBooking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated vendor user from JWT context
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	// Parse booking ID from URL
+	bookingIDStr := chi.URLParam(r, "booking_id")
+	bookingID, err := strconv.ParseInt(bookingIDStr, 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("booking_id must be a valid integer"))
+		return
+	}
+
+	response, err := h.service.ConfirmBooking(ctx, bookingID, userID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// RejectBooking handles POST /vendor/bookings/{booking_id}/reject
+func (h *Handler) RejectBooking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated vendor user from JWT context
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	// Parse booking ID from URL
+	bookingIDStr := chi.URLParam(r, "booking_id")
+	bookingID, err := strconv.ParseInt(bookingIDStr, 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("booking_id must be a valid integer"))
+		return
+	}
+
+	// Parse and validate request
+	params, err := NewRejectBookingParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.RejectBooking(ctx, bookingID, userID, params.Reason)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// CompleteSession handles POST /vendor/sessions/{session_id}/complete
+func (h *Handler) CompleteSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated user from JWT context (vendor or coach)
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+	role, _ := internal.ExtractRole(ctx)
+
+	// Parse session ID from URL
+	sessionIDStr := chi.URLParam(r, "session_id")
+	sessionID, err := strconv.ParseInt(sessionIDStr, 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("session_id must be a valid integer"))
+		return
+	}
+
+	// Parse and validate request
+	params, err := NewCompleteSessionParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.CompleteSession(ctx, params.ToCompleteSessionRequest(sessionID, userID, role))
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/frahmantamala/jadiles/internal"
@@ -18,15 +19,38 @@ type Repository interface {
 	GetChildNameByID(ctx context.Context, childID int64) (string, error)
 	GetCoachNameByID(ctx context.Context, coachID int64) (string, error)
 	GetBookingEnrichment(ctx context.Context, serviceID, childID, vendorID int64) (*BookingEnrichment, error)
+	CancelBookingWithTransaction(ctx context.Context, booking *services.Booking, cancellation *services.BookingCancellation) error
+	GetVendorIDByUserID(ctx context.Context, userID int64) (int64, error)
+	GetReschedulePolicy(ctx context.Context, vendorID int64) (*services.ReschedulePolicy, error)
+	RescheduleSessionWithTransaction(ctx context.Context, booking *services.Booking, req *services.RescheduleSessionRequest, policy *services.ReschedulePolicy, now time.Time) (*services.RescheduleResult, error)
+	GetSessionReschedules(ctx context.Context, bookingID int64) ([]*services.SessionReschedule, error)
+	GetBookingStatusHistory(ctx context.Context, bookingID int64) ([]*services.BookingStatusChange, error)
+	ListParentBookings(ctx context.Context, filter *services.BookingListFilter) ([]*services.BookingListItem, error)
+	GetBookingEnrichments(ctx context.Context, bookings []*services.Booking) (map[int64]*BookingEnrichment, error)
+	ListVendorBookings(ctx context.Context, filter *services.VendorBookingFilter) ([]*services.VendorBookingItem, int64, error)
+	ConfirmBookingWithTransaction(ctx context.Context, booking *services.Booking, confirmedBy int64, at time.Time) error
+	GetBookingIDBySessionID(ctx context.Context, sessionID int64) (int64, error)
+	GetCoachByUserID(ctx context.Context, userID int64) (int64, int64, error)
+	CompleteSessionWithTransaction(ctx context.Context, bookingID int64, req *services.CompleteSessionRequest, at time.Time) (*services.SessionCompletionResult, error)
+	IsEmailVerified(ctx context.Context, userID int64) (bool, error)
+}
+
+// Refunder sends refunds queued with cancellations to the payment gateway
+type Refunder interface {
+	ProcessRefund(ctx context.Context, refundID int64) error
 }
 
 type ServiceUsecase struct {
-	repo Repository
+	repo               Repository
+	cancellationPolicy services.CancellationPolicy
+	refunder           Refunder
 }
 
-func NewService(repo Repository) *ServiceUsecase {
+func NewService(repo Repository, cancellationPolicy services.CancellationPolicy, refunder Refunder) *ServiceUsecase {
 	return &ServiceUsecase{
-		repo: repo,
+		repo:               repo,
+		cancellationPolicy: cancellationPolicy,
+		refunder:           refunder,
 	}
 }
 
@@ -42,9 +66,17 @@ func (s *ServiceUsecase) CreateBooking(ctx context.Context, req *services.Create
 		}
 	}
 
+	// Only parents who verified their email can book
+	verified, err := s.repo.IsEmailVerified(ctx, req.ParentID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+	if !verified {
+		return nil, internal.NewForbiddenError("Please verify your email address before booking")
+	}
+
 	const maxRetries = 3
 	var booking *services.Booking
-	var err error
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		booking, err = s.repo.CreateBookingWithTransaction(ctx, req)
@@ -139,8 +171,8 @@ func (s *ServiceUsecase) buildBookingConfirmation(ctx context.Context, booking *
 	}, nil
 }
 
-// GetBooking retrieves a booking by ID
-func (s *ServiceUsecase) GetBooking(ctx context.Context, bookingID int64, parentID int64) (*v1.BookingDetailResponse, error) {
+// GetBooking retrieves a booking by ID with its status timeline
+func (s *ServiceUsecase) GetBooking(ctx context.Context, bookingID int64, parentID int64) (*BookingDetailResponse, error) {
 	booking, err := s.repo.GetBookingByID(ctx, bookingID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -161,6 +193,404 @@ func (s *ServiceUsecase) GetBooking(ctx context.Context, bookingID int64, parent
 		enrichment = &BookingEnrichment{}
 	}
 
+	// Fetch the status timeline
+	history, err := s.repo.GetBookingStatusHistory(ctx, booking.ID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
 	// Convert to v1 response
+	return ToBookingDetailResponse(booking, enrichment, history), nil
+}
+
+// CancelBooking cancels a booking on behalf of its parent or vendor and computes the refund
+func (s *ServiceUsecase) CancelBooking(ctx context.Context, req *services.CancelBookingRequest) (*v1.BookingCancelledResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, req.BookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Booking not found")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Verify the caller owns the booking
+	if err := s.authorizeCancellation(ctx, booking, req); err != nil {
+		return nil, err
+	}
+
+	if !booking.CanBeCancelled() {
+		return nil, internal.NewBusinessRuleError(
+			fmt.Sprintf("Booking with status %s cannot be cancelled", booking.Status),
+			internal.ErrInvalidState,
+		)
+	}
+
+	now := time.Now()
+	cancellation := &services.BookingCancellation{
+		BookingID:         booking.ID,
+		Reason:            req.Reason,
+		CancelledBy:       req.CancelledBy,
+		CancelledByUserID: &req.UserID,
+		CancelledAt:       now,
+		RefundAmount:      s.cancellationPolicy.CalculateRefund(booking, req.CancelledBy, now),
+	}
+
+	if err := s.repo.CancelBookingWithTransaction(ctx, booking, cancellation); err != nil {
+		return nil, err
+	}
+
+	s.sendRefund(ctx, cancellation)
+
+	return ToV1BookingCancelled(booking, cancellation), nil
+}
+
+// sendRefund makes the first attempt of the refund queued with a cancellation.
+// The cancellation stands if it fails, the refund stays queued and the refund worker retries it.
+func (s *ServiceUsecase) sendRefund(ctx context.Context, cancellation *services.BookingCancellation) {
+	if cancellation.RefundID == 0 {
+		return
+	}
+
+	if err := s.refunder.ProcessRefund(ctx, cancellation.RefundID); err != nil {
+		slog.WarnContext(ctx, "Refund attempt failed, it will be retried",
+			slog.Int64("booking_id", cancellation.BookingID),
+			slog.Int64("refund_id", cancellation.RefundID),
+			slog.Float64("refund_amount", cancellation.RefundAmount),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// authorizeCancellation verifies the requester is the booking's parent or vendor
+func (s *ServiceUsecase) authorizeCancellation(ctx context.Context, booking *services.Booking, req *services.CancelBookingRequest) error {
+	switch req.CancelledBy {
+	case services.CancelledByParent:
+		if booking.ParentID != req.UserID {
+			return internal.NewForbiddenError("Access denied")
+		}
+	case services.CancelledByVendor:
+		vendorID, err := s.repo.GetVendorIDByUserID(ctx, req.UserID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return internal.NewForbiddenError("Access denied")
+			}
+			return internal.NewInternalServerError(err)
+		}
+		if booking.VendorID != vendorID {
+			return internal.NewForbiddenError("Access denied")
+		}
+	}
+	return nil
+}
+
+// RescheduleSession moves one session of a parent's booking to another schedule slot
+func (s *ServiceUsecase) RescheduleSession(ctx context.Context, req *services.RescheduleSessionRequest) (*RescheduleSessionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, req.BookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Booking not found")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Verify the booking belongs to the parent
+	if booking.ParentID != req.RequestedBy {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	if !booking.CanBeRescheduled() {
+		return nil, internal.NewBusinessRuleError(
+			fmt.Sprintf("Booking with status %s cannot be rescheduled", booking.Status),
+			internal.ErrInvalidState,
+		)
+	}
+
+	session := booking.FindSession(req.SessionID)
+	if session == nil {
+		return nil, internal.NewNotFoundError("Booking session")
+	}
+
+	policy, err := s.repo.GetReschedulePolicy(ctx, booking.VendorID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// The current slot must still be outside the vendor's cutoff window
+	now := time.Now()
+	if err := policy.CheckCutoff(session.StartsAt(), now); err != nil {
+		return nil, internal.NewBusinessRuleError(err.Error(), internal.ErrBusinessRule)
+	}
+
+	result, err := s.repo.RescheduleSessionWithTransaction(ctx, booking, req, policy, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToRescheduleSessionResponse(result), nil
+}
+
+// GetSessionReschedules retrieves the reschedule history of a parent's booking
+func (s *ServiceUsecase) GetSessionReschedules(ctx context.Context, bookingID int64, parentID int64) (*SessionReschedulesResponse, error) {
+	booking, err := s.repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Booking not found")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Verify the booking belongs to the parent
+	if booking.ParentID != parentID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	history, err := s.repo.GetSessionReschedules(ctx, bookingID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToSessionReschedulesResponse(history), nil
+}
+
+// ListBookings lists a parent's bookings, upcoming first, one page at a time
+func (s *ServiceUsecase) ListBookings(ctx context.Context, filter *services.BookingListFilter) (*BookingsListResponse, error) {
+	// Later pages keep ordering bookings as of the first page
+	filter.Now = time.Now()
+	if filter.Cursor != nil {
+		filter.Now = filter.Cursor.AsOf
+	}
+
+	// The repository returns one extra row to tell whether another page exists
+	items, err := s.repo.ListParentBookings(ctx, filter)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	var nextCursor *string
+	if len(items) > filter.Limit {
+		items = items[:filter.Limit]
+		cursor := items[len(items)-1].Cursor.Encode()
+		nextCursor = &cursor
+	}
+
+	// Fetch service, child and vendor names for the whole page at once
+	bookings := make([]*services.Booking, 0, len(items))
+	for _, item := range items {
+		bookings = append(bookings, item.Booking)
+	}
+	enrichments, err := s.repo.GetBookingEnrichments(ctx, bookings)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to enrich booking list", slog.Any("error", err))
+		enrichments = map[int64]*BookingEnrichment{}
+	}
+
+	return ToBookingsListResponse(items, enrichments, nextCursor, filter.Limit), nil
+}
+
+// ListVendorBookings lists the bookings received by the vendor owned by the user
+func (s *ServiceUsecase) ListVendorBookings(ctx context.Context, userID int64, filter *services.VendorBookingFilter) (*VendorBookingsResponse, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.VendorID = vendorID
+	filter.Now = time.Now()
+
+	items, total, err := s.repo.ListVendorBookings(ctx, filter)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToVendorBookingsResponse(items, filter.Page, filter.Limit, total), nil
+}
+
+// ConfirmBooking accepts a pending booking on behalf of its vendor
+func (s *ServiceUsecase) ConfirmBooking(ctx context.Context, bookingID int64, userID int64) (*v1.BookingDetailResponse, error) {
+	booking, err := s.getVendorBooking(ctx, bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !booking.CanBeConfirmed() {
+		return nil, internal.NewBusinessRuleError(
+			fmt.Sprintf("Booking with status %s cannot be confirmed", booking.Status),
+			internal.ErrInvalidState,
+		)
+	}
+
+	if err := s.repo.ConfirmBookingWithTransaction(ctx, booking, userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	enrichment, err := s.repo.GetBookingEnrichment(ctx, booking.ServiceID, booking.ChildID, booking.VendorID)
+	if err != nil {
+		enrichment = &BookingEnrichment{}
+	}
+
 	return ToV1BookingDetail(booking, enrichment), nil
 }
+
+// RejectBooking declines a pending booking on behalf of its vendor.
+// The booking is cancelled by the vendor, so anything already paid is refunded in full.
+func (s *ServiceUsecase) RejectBooking(ctx context.Context, bookingID int64, userID int64, reason string) (*v1.BookingCancelledResponse, error) {
+	booking, err := s.getVendorBooking(ctx, bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !booking.CanBeConfirmed() {
+		return nil, internal.NewBusinessRuleError(
+			fmt.Sprintf("Booking with status %s cannot be rejected", booking.Status),
+			internal.ErrInvalidState,
+		)
+	}
+
+	now := time.Now()
+	cancellation := &services.BookingCancellation{
+		BookingID:         booking.ID,
+		Reason:            reason,
+		CancelledBy:       services.CancelledByVendor,
+		CancelledByUserID: &userID,
+		CancelledAt:       now,
+		RefundAmount:      s.cancellationPolicy.CalculateRefund(booking, services.CancelledByVendor, now),
+	}
+
+	if err := s.repo.CancelBookingWithTransaction(ctx, booking, cancellation); err != nil {
+		return nil, err
+	}
+
+	s.sendRefund(ctx, cancellation)
+
+	return ToV1BookingCancelled(booking, cancellation), nil
+}
+
+// getVendorBooking retrieves a booking and verifies it was made with the vendor owned by the user
+func (s *ServiceUsecase) getVendorBooking(ctx context.Context, bookingID int64, userID int64) (*services.Booking, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Booking")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if booking.VendorID != vendorID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	return booking, nil
+}
+
+// resolveVendorID finds the vendor owned by the user
+func (s *ServiceUsecase) resolveVendorID(ctx context.Context, userID int64) (int64, error) {
+	vendorID, err := s.repo.GetVendorIDByUserID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, internal.NewForbiddenError("Vendor profile not found")
+		}
+		return 0, internal.NewInternalServerError(err)
+	}
+	return vendorID, nil
+}
+
+// CompleteSession records whether the child attended a session, on behalf of the vendor or the session's coach
+func (s *ServiceUsecase) CompleteSession(ctx context.Context, req *services.CompleteSessionRequest) (*v1.SessionCompletedResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	bookingID, err := s.repo.GetBookingIDBySessionID(ctx, req.SessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Session")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Booking")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	session := booking.FindSession(req.SessionID)
+	if session == nil {
+		return nil, internal.NewNotFoundError("Session")
+	}
+
+	// Verify the caller runs the session
+	if err := s.authorizeCompletion(ctx, booking, session, req); err != nil {
+		return nil, err
+	}
+
+	if err := booking.CanCompleteSessions(); err != nil {
+		return nil, internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+	}
+
+	now := time.Now()
+	if err := session.CanBeCompleted(now); err != nil {
+		return nil, internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+	}
+
+	result, err := s.repo.CompleteSessionWithTransaction(ctx, booking.ID, req, now)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Session completed",
+		slog.Int64("booking_id", booking.ID),
+		slog.Int64("session_id", req.SessionID),
+		slog.String("session_status", string(result.Session.Status)),
+		slog.String("booking_status", string(result.BookingStatus)),
+	)
+
+	return ToV1SessionCompleted(result), nil
+}
+
+// authorizeCompletion verifies the requester is the booking's vendor or the coach assigned to the session
+func (s *ServiceUsecase) authorizeCompletion(ctx context.Context, booking *services.Booking, session *services.BookingSession, req *services.CompleteSessionRequest) error {
+	switch req.Role {
+	case "vendor":
+		vendorID, err := s.resolveVendorID(ctx, req.CompletedBy)
+		if err != nil {
+			return err
+		}
+		if booking.VendorID != vendorID {
+			return internal.NewForbiddenError("Access denied")
+		}
+	case "coach":
+		coachID, vendorID, err := s.repo.GetCoachByUserID(ctx, req.CompletedBy)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return internal.NewForbiddenError("Coach profile not found")
+			}
+			return internal.NewInternalServerError(err)
+		}
+		if booking.VendorID != vendorID {
+			return internal.NewForbiddenError("Access denied")
+		}
+		// Any coach of the vendor may cover a session nobody is assigned to
+		if assigned := booking.AssignedCoachID(session); assigned != nil && *assigned != coachID {
+			return internal.NewForbiddenError("Session is assigned to another coach")
+		}
+	default:
+		return internal.NewForbiddenError("Access denied")
+	}
+	return nil
+}
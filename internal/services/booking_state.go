@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+)
+
+// ================== Booking State Machine ==================
+
+// bookingTransitions lists the statuses each booking status can move to.
+// Completed and cancelled bookings are final.
+var bookingTransitions = map[BookingStatus][]BookingStatus{
+	BookingStatusPending:   {BookingStatusConfirmed, BookingStatusCancelled},
+	BookingStatusConfirmed: {BookingStatusOngoing, BookingStatusCompleted, BookingStatusCancelled},
+	BookingStatusOngoing:   {BookingStatusCompleted, BookingStatusCancelled},
+}
+
+// sessionTransitions lists the statuses each session status can move to
+var sessionTransitions = map[SessionStatus][]SessionStatus{
+	SessionStatusScheduled: {SessionStatusCompleted, SessionStatusNoShow, SessionStatusCancelled},
+}
+
+// CanTransitionTo checks if a booking can move from this status to the given one
+func (s BookingStatus) CanTransitionTo(to BookingStatus) bool {
+	for _, allowed := range bookingTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns an error if a booking cannot move from this status to the given one
+func (s BookingStatus) ValidateTransition(to BookingStatus) error {
+	if !s.CanTransitionTo(to) {
+		return fmt.Errorf("booking cannot move from %s to %s", s, to)
+	}
+	return nil
+}
+
+// IsFinal checks if no further transition is possible
+func (s BookingStatus) IsFinal() bool {
+	return len(bookingTransitions[s]) == 0
+}
+
+// CanTransitionTo checks if a session can move from this status to the given one
+func (s SessionStatus) CanTransitionTo(to SessionStatus) bool {
+	for _, allowed := range sessionTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns an error if a session cannot move from this status to the given one
+func (s SessionStatus) ValidateTransition(to SessionStatus) error {
+	if !s.CanTransitionTo(to) {
+		return fmt.Errorf("session cannot move from %s to %s", s, to)
+	}
+	return nil
+}
+
+// ActorType identifies who changed the status of a booking
+type ActorType string
+
+const (
+	ActorParent ActorType = "parent"
+	ActorVendor ActorType = "vendor"
+	ActorCoach  ActorType = "coach"
+	ActorAdmin  ActorType = "admin"
+	ActorSystem ActorType = "system"
+)
+
+// BookingStatusChange is an entry of a booking's status timeline.
+// FromStatus is nil for the entry recorded when the booking is created.
+type BookingStatusChange struct {
+	ID         int64
+	BookingID  int64
+	FromStatus *BookingStatus
+	ToStatus   BookingStatus
+	ActorType  ActorType
+	ActorID    *int64 // User ID, nil for system changes
+	Reason     *string
+	CreatedAt  time.Time
+}
+
+// NewBookingCreated records the initial status of a new booking
+func NewBookingCreated(bookingID int64, status BookingStatus, actorType ActorType, actorID *int64, at time.Time) *BookingStatusChange {
+	return &BookingStatusChange{
+		BookingID: bookingID,
+		ToStatus:  status,
+		ActorType: actorType,
+		ActorID:   actorID,
+		CreatedAt: at,
+	}
+}
+
+// NewBookingStatusChange records a transition, rejecting it if the state machine does not allow it
+func NewBookingStatusChange(bookingID int64, from, to BookingStatus, actorType ActorType, actorID *int64, reason string, at time.Time) (*BookingStatusChange, error) {
+	if err := from.ValidateTransition(to); err != nil {
+		return nil, err
+	}
+
+	change := &BookingStatusChange{
+		BookingID:  bookingID,
+		FromStatus: &from,
+		ToStatus:   to,
+		ActorType:  actorType,
+		ActorID:    actorID,
+		CreatedAt:  at,
+	}
+	if reason != "" {
+		change.Reason = &reason
+	}
+	return change, nil
+}
+
+// ToDataModel converts the status change to its history row
+func (c *BookingStatusChange) ToDataModel() *datamodel.BookingStatusHistory {
+	data := &datamodel.BookingStatusHistory{
+		ID:        c.ID,
+		BookingID: c.BookingID,
+		ToStatus:  string(c.ToStatus),
+		ActorType: string(c.ActorType),
+		ActorID:   c.ActorID,
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt,
+	}
+	if c.FromStatus != nil {
+		from := string(*c.FromStatus)
+		data.FromStatus = &from
+	}
+	return data
+}
+
+// ActorTypeFromRole maps an authenticated user's role to the actor recorded in the timeline
+func ActorTypeFromRole(role string) ActorType {
+	switch role {
+	case "parent":
+		return ActorParent
+	case "vendor":
+		return ActorVendor
+	case "coach":
+		return ActorCoach
+	case "admin":
+		return ActorAdmin
+	}
+	return ActorSystem
+}
@@ -0,0 +1,60 @@
+package services
+
+import "testing"
+
+func TestBookingCanCompleteSessions(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        BookingStatus
+		paymentStatus PaymentStatus
+		wantErr       bool
+	}{
+		{
+			name:          "paid and confirmed",
+			status:        BookingStatusConfirmed,
+			paymentStatus: PaymentStatusPaid,
+		},
+		{
+			name:          "paid and ongoing",
+			status:        BookingStatusOngoing,
+			paymentStatus: PaymentStatusPaid,
+		},
+		{
+			name:          "confirmed by the vendor before payment",
+			status:        BookingStatusConfirmed,
+			paymentStatus: PaymentStatusUnpaid,
+			wantErr:       true,
+		},
+		{
+			name:          "refunded",
+			status:        BookingStatusOngoing,
+			paymentStatus: PaymentStatusRefunded,
+			wantErr:       true,
+		},
+		{
+			name:          "pending",
+			status:        BookingStatusPending,
+			paymentStatus: PaymentStatusPaid,
+			wantErr:       true,
+		},
+		{
+			name:          "cancelled",
+			status:        BookingStatusCancelled,
+			paymentStatus: PaymentStatusPaid,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := &Booking{Status: tt.status, PaymentStatus: tt.paymentStatus}
+			err := booking.CanCompleteSessions()
+			if tt.wantErr && err == nil {
+				t.Errorf("CanCompleteSessions() = nil, want an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("CanCompleteSessions() = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,582 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/common"
+	"github.com/frahmantamala/jadiles/internal/services"
+	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+)
+
+const (
+	defaultServiceListLimit = 20
+	maxServiceListLimit     = 100
+)
+
+// ListVendorServicesParams represents the query parameters for a vendor's service list
+type ListVendorServicesParams struct {
+	Page  int
+	Limit int
+}
+
+// NewListVendorServicesParams parses the vendor service list query parameters
+func NewListVendorServicesParams(r *http.Request) (*ListVendorServicesParams, error) {
+	query := r.URL.Query()
+
+	params := &ListVendorServicesParams{
+		Page:  1,
+		Limit: defaultServiceListLimit,
+	}
+
+	if pageStr := query.Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil {
+			return nil, internal.NewValidationError("page must be a valid integer")
+		}
+		params.Page = page
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, internal.NewValidationError("limit must be a valid integer")
+		}
+		params.Limit = limit
+	}
+
+	return params, nil
+}
+
+// Validate validates the vendor service list parameters
+func (p *ListVendorServicesParams) Validate(ctx context.Context) error {
+	if p.Page < 1 {
+		return internal.NewValidationError("page must be at least 1")
+	}
+
+	if p.Limit < 1 || p.Limit > maxServiceListLimit {
+		return internal.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", maxServiceListLimit))
+	}
+
+	return nil
+}
+
+// CreateServiceParams represents the HTTP request body for creating a service.
+// Business rules are checked by services.Service.Validate, the tags only bound optional fields.
+type CreateServiceParams struct {
+	CategoryID      int64    `json:"category_id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	AgeMin          *int     `json:"age_min,omitempty" validate:"omitempty,min=0,max=100"`
+	AgeMax          *int     `json:"age_max,omitempty" validate:"omitempty,min=0,max=100"`
+	SkillLevel      *string  `json:"skill_level,omitempty"`
+	ClassType       string   `json:"class_type"`
+	MaxParticipants *int     `json:"max_participants,omitempty" validate:"omitempty,min=1"`
+	DurationMinutes int      `json:"duration_minutes"`
+	PricePerSession float64  `json:"price_per_session"`
+	TrialPrice      *float64 `json:"trial_price,omitempty" validate:"omitempty,gte=0"`
+	Package4Price   *float64 `json:"package_4_price,omitempty" validate:"omitempty,gt=0"`
+	Package8Price   *float64 `json:"package_8_price,omitempty" validate:"omitempty,gt=0"`
+	Package12Price  *float64 `json:"package_12_price,omitempty" validate:"omitempty,gt=0"`
+	Requirements    *string  `json:"requirements,omitempty" validate:"omitempty,max=2000"`
+	WhatWillLearn   *string  `json:"what_will_learn,omitempty" validate:"omitempty,max=2000"`
+}
+
+// NewCreateServiceParams parses the create service request
+func NewCreateServiceParams(r *http.Request) (*CreateServiceParams, error) {
+	var params CreateServiceParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the create service parameters
+func (p *CreateServiceParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToService converts DTO to a new draft service of the vendor.
+// Services without a skill level are open to all levels.
+func (p *CreateServiceParams) ToService(vendorID int64) *services.Service {
+	service := &services.Service{
+		VendorID:        vendorID,
+		CategoryID:      p.CategoryID,
+		Name:            p.Name,
+		Description:     p.Description,
+		SkillLevel:      services.SkillLevelAllLevels,
+		ClassType:       services.ClassType(p.ClassType),
+		DurationMinutes: p.DurationMinutes,
+		PricePerSession: p.PricePerSession,
+		TrialPrice:      p.TrialPrice,
+		Package4Price:   p.Package4Price,
+		Package8Price:   p.Package8Price,
+		Package12Price:  p.Package12Price,
+		Requirements:    p.Requirements,
+		WhatWillLearn:   p.WhatWillLearn,
+		Status:          services.ServiceStatusDraft,
+		Version:         1,
+	}
+	if p.AgeMin != nil {
+		service.AgeMin = *p.AgeMin
+	}
+	if p.AgeMax != nil {
+		service.AgeMax = *p.AgeMax
+	}
+	if p.SkillLevel != nil {
+		service.SkillLevel = services.SkillLevel(*p.SkillLevel)
+	}
+	if p.MaxParticipants != nil {
+		service.MaxParticipants = *p.MaxParticipants
+	}
+	return service
+}
+
+// UpdateServiceParams represents the HTTP request body for updating a service.
+// Only the fields present in the body are changed.
+type UpdateServiceParams struct {
+	CategoryID      *int64   `json:"category_id,omitempty"`
+	Name            *string  `json:"name,omitempty"`
+	Description     *string  `json:"description,omitempty"`
+	AgeMin          *int     `json:"age_min,omitempty" validate:"omitempty,min=0,max=100"`
+	AgeMax          *int     `json:"age_max,omitempty" validate:"omitempty,min=0,max=100"`
+	SkillLevel      *string  `json:"skill_level,omitempty"`
+	ClassType       *string  `json:"class_type,omitempty"`
+	MaxParticipants *int     `json:"max_participants,omitempty" validate:"omitempty,min=1"`
+	DurationMinutes *int     `json:"duration_minutes,omitempty"`
+	PricePerSession *float64 `json:"price_per_session,omitempty"`
+	TrialPrice      *float64 `json:"trial_price,omitempty" validate:"omitempty,gte=0"`
+	Package4Price   *float64 `json:"package_4_price,omitempty" validate:"omitempty,gt=0"`
+	Package8Price   *float64 `json:"package_8_price,omitempty" validate:"omitempty,gt=0"`
+	Package12Price  *float64 `json:"package_12_price,omitempty" validate:"omitempty,gt=0"`
+	Requirements    *string  `json:"requirements,omitempty" validate:"omitempty,max=2000"`
+	WhatWillLearn   *string  `json:"what_will_learn,omitempty" validate:"omitempty,max=2000"`
+	Status          *string  `json:"status,omitempty" validate:"omitempty,oneof=draft active inactive"`
+}
+
+// NewUpdateServiceParams parses the update service request
+func NewUpdateServiceParams(r *http.Request) (*UpdateServiceParams, error) {
+	var params UpdateServiceParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the update service parameters
+func (p *UpdateServiceParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ApplyTo copies the fields present in the request onto the service.
+// The status is left alone, lifecycle changes go through the service state machine.
+func (p *UpdateServiceParams) ApplyTo(service *services.Service) {
+	if p.CategoryID != nil {
+		service.CategoryID = *p.CategoryID
+	}
+	if p.Name != nil {
+		service.Name = *p.Name
+	}
+	if p.Description != nil {
+		service.Description = *p.Description
+	}
+	if p.AgeMin != nil {
+		service.AgeMin = *p.AgeMin
+	}
+	if p.AgeMax != nil {
+		service.AgeMax = *p.AgeMax
+	}
+	if p.SkillLevel != nil {
+		service.SkillLevel = services.SkillLevel(*p.SkillLevel)
+	}
+	if p.ClassType != nil {
+		service.ClassType = services.ClassType(*p.ClassType)
+	}
+	if p.MaxParticipants != nil {
+		service.MaxParticipants = *p.MaxParticipants
+	}
+	if p.DurationMinutes != nil {
+		service.DurationMinutes = *p.DurationMinutes
+	}
+	if p.PricePerSession != nil {
+		service.PricePerSession = *p.PricePerSession
+	}
+	if p.TrialPrice != nil {
+		service.TrialPrice = p.TrialPrice
+	}
+	if p.Package4Price != nil {
+		service.Package4Price = p.Package4Price
+	}
+	if p.Package8Price != nil {
+		service.Package8Price = p.Package8Price
+	}
+	if p.Package12Price != nil {
+		service.Package12Price = p.Package12Price
+	}
+	if p.Requirements != nil {
+		service.Requirements = p.Requirements
+	}
+	if p.WhatWillLearn != nil {
+		service.WhatWillLearn = p.WhatWillLearn
+	}
+}
+
+// ServiceDeletedResponse is the response for a deleted service
+type ServiceDeletedResponse struct {
+	Data    ServiceDeletedData `json:"data"`
+	Message string             `json:"message"`
+}
+
+type ServiceDeletedData struct {
+	ID       int64 `json:"id"`
+	Archived bool  `json:"archived"` // True if the service had bookings and was archived instead of removed
+}
+
+// ToVendorServicesResponse converts a page of a vendor's services to response
+func ToVendorServicesResponse(items []*services.Service, page, limit int, total int64) *v1.VendorServicesResponse {
+	data := make([]v1.Service, 0, len(items))
+	for _, item := range items {
+		data = append(data, ToV1Service(item))
+	}
+
+	totalCount := int(total)
+	totalPages := (totalCount + limit - 1) / limit
+
+	resp := &v1.VendorServicesResponse{}
+	resp.Data.Services = &data
+	resp.Data.Pagination = &v1.Pagination{
+		Page:       &page,
+		Limit:      &limit,
+		Total:      &totalCount,
+		TotalPages: &totalPages,
+	}
+	return resp
+}
+
+// ToServiceCreatedResponse converts a created service to response
+func ToServiceCreatedResponse(s *services.Service) *v1.ServiceCreatedResponse {
+	service := ToV1Service(s)
+	resp := &v1.ServiceCreatedResponse{}
+	resp.Data.Service = &service
+	return resp
+}
+
+// ToServiceUpdatedResponse converts an updated service to response
+func ToServiceUpdatedResponse(s *services.Service) *v1.ServiceUpdatedResponse {
+	service := ToV1Service(s)
+	resp := &v1.ServiceUpdatedResponse{}
+	resp.Data.Service = &service
+	return resp
+}
+
+// ToV1Service converts domain Service to v1.Service
+func ToV1Service(s *services.Service) v1.Service {
+	id := s.ID
+	name := s.Name
+	description := s.Description
+	ageRange := s.GetAgeRangeDisplay()
+	skillLevel := v1.SkillLevel(s.SkillLevel)
+	classType := v1.ClassType(s.ClassType)
+	durationMinutes := s.DurationMinutes
+	pricePerSession := s.PricePerSession
+	isFeatured := s.IsFeatured
+	status := v1.ServiceStatus(s.Status)
+
+	service := v1.Service{
+		Id:              &id,
+		Name:            &name,
+		Description:     &description,
+		AgeRange:        &ageRange,
+		SkillLevel:      &skillLevel,
+		ClassType:       &classType,
+		DurationMinutes: &durationMinutes,
+		PricePerSession: &pricePerSession,
+		TrialPrice:      s.TrialPrice,
+		Package4Price:   s.Package4Price,
+		Package8Price:   s.Package8Price,
+		Package12Price:  s.Package12Price,
+		Requirements:    s.Requirements,
+		WhatWillLearn:   s.WhatWillLearn,
+		IsFeatured:      &isFeatured,
+		Status:          &status,
+	}
+
+	if s.AgeMin > 0 {
+		ageMin := s.AgeMin
+		service.AgeMin = &ageMin
+	}
+	if s.AgeMax > 0 {
+		ageMax := s.AgeMax
+		service.AgeMax = &ageMax
+	}
+	if s.MaxParticipants > 0 {
+		maxParticipants := s.MaxParticipants
+		service.MaxParticipants = &maxParticipants
+	}
+
+	return service
+}
+
+// AddScheduleParams represents the HTTP request body for adding a weekly schedule to a service
+type AddScheduleParams struct {
+	CoachID        *int64 `json:"coach_id,omitempty" validate:"omitempty,gt=0"`
+	DayOfWeek      *int   `json:"day_of_week" validate:"required,min=0,max=6"`
+	StartTime      string `json:"start_time" validate:"required"` // HH:MM format
+	EndTime        string `json:"end_time" validate:"required"`   // HH:MM format
+	AvailableSlots int    `json:"available_slots" validate:"required,gt=0"`
+}
+
+// NewAddScheduleParams parses the add schedule request
+func NewAddScheduleParams(r *http.Request) (*AddScheduleParams, error) {
+	var params AddScheduleParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the add schedule parameters
+func (p *AddScheduleParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToSchedule converts DTO to a new active schedule of the service
+func (p *AddScheduleParams) ToSchedule(serviceID int64) (*services.Schedule, error) {
+	startTime, err := services.NormalizeClock(p.StartTime)
+	if err != nil {
+		return nil, internal.NewValidationError("start_time must be in HH:MM format")
+	}
+	endTime, err := services.NormalizeClock(p.EndTime)
+	if err != nil {
+		return nil, internal.NewValidationError("end_time must be in HH:MM format")
+	}
+
+	return &services.Schedule{
+		ServiceID:      serviceID,
+		CoachID:        p.CoachID,
+		DayOfWeek:      *p.DayOfWeek,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		AvailableSlots: p.AvailableSlots,
+		IsActive:       true,
+	}, nil
+}
+
+// UpdateScheduleParams represents the HTTP request body for changing a schedule.
+// Changes that leave booked sessions without a seat are rejected unless force is set.
+type UpdateScheduleParams struct {
+	CoachID        *int64 `json:"coach_id,omitempty" validate:"omitempty,gt=0"`
+	AvailableSlots *int   `json:"available_slots,omitempty" validate:"omitempty,gt=0"`
+	IsActive       *bool  `json:"is_active,omitempty"`
+	Force          bool   `json:"force,omitempty"`
+}
+
+// NewUpdateScheduleParams parses the update schedule request
+func NewUpdateScheduleParams(r *http.Request) (*UpdateScheduleParams, error) {
+	var params UpdateScheduleParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the update schedule parameters
+func (p *UpdateScheduleParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToScheduleUpdate converts DTO to domain update
+func (p *UpdateScheduleParams) ToScheduleUpdate() *services.ScheduleUpdate {
+	return &services.ScheduleUpdate{
+		CoachID:        p.CoachID,
+		AvailableSlots: p.AvailableSlots,
+		IsActive:       p.IsActive,
+	}
+}
+
+// AddScheduleExceptionParams represents the HTTP request body for closing a day.
+// Without service_id the day is closed for every service of the vendor.
+type AddScheduleExceptionParams struct {
+	ExceptionDate string `json:"exception_date" validate:"required"` // YYYY-MM-DD format
+	Reason        string `json:"reason" validate:"required,max=500"`
+	ServiceID     *int64 `json:"service_id,omitempty" validate:"omitempty,gt=0"`
+	Force         bool   `json:"force,omitempty"`
+}
+
+// NewAddScheduleExceptionParams parses the add schedule exception request
+func NewAddScheduleExceptionParams(r *http.Request) (*AddScheduleExceptionParams, error) {
+	var params AddScheduleExceptionParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the add schedule exception parameters
+func (p *AddScheduleExceptionParams) Validate(ctx context.Context) error {
+	if err := common.ValidateStruct(p); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse("2006-01-02", p.ExceptionDate); err != nil {
+		return internal.NewValidationError("invalid exception_date format, expected YYYY-MM-DD")
+	}
+
+	return nil
+}
+
+// ToScheduleExceptionRequest converts DTO to domain request
+func (p *AddScheduleExceptionParams) ToScheduleExceptionRequest(vendorID int64) (*services.ScheduleExceptionRequest, error) {
+	date, err := time.Parse("2006-01-02", p.ExceptionDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.ScheduleExceptionRequest{
+		VendorID:  vendorID,
+		ServiceID: p.ServiceID,
+		Date:      date,
+		Reason:    p.Reason,
+	}, nil
+}
+
+// AffectedBookingDTO is a booked session affected by a schedule change
+type AffectedBookingDTO struct {
+	BookingID     int64  `json:"booking_id"`
+	BookingNumber string `json:"booking_number"`
+	BookingStatus string `json:"booking_status"`
+	SessionID     int64  `json:"session_id"`
+	ScheduleID    int64  `json:"schedule_id"`
+	SessionDate   string `json:"session_date"`
+	StartTime     string `json:"start_time"`
+	EndTime       string `json:"end_time"`
+	ChildName     string `json:"child_name"`
+}
+
+// ScheduleExceptionDTO is a closed day in API responses
+type ScheduleExceptionDTO struct {
+	ID            int64   `json:"id"`
+	ServiceID     *int64  `json:"service_id,omitempty"`
+	ExceptionDate string  `json:"exception_date"`
+	Reason        *string `json:"reason,omitempty"`
+	IsClosed      bool    `json:"is_closed"`
+}
+
+// ScheduleChangeResponse is the response for an applied schedule change.
+// Affected bookings are listed when the change was forced through.
+type ScheduleChangeResponse struct {
+	Data    ScheduleChangeData `json:"data"`
+	Message string             `json:"message"`
+}
+
+type ScheduleChangeData struct {
+	Schedule         *v1.Schedule          `json:"schedule,omitempty"`
+	Exception        *ScheduleExceptionDTO `json:"exception,omitempty"`
+	AffectedBookings []AffectedBookingDTO  `json:"affected_bookings"`
+}
+
+// OverbookingResponse is the 409 response for a schedule change that was not applied
+// because it would leave booked sessions without a seat
+type OverbookingResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Data struct {
+		AffectedBookings []AffectedBookingDTO `json:"affected_bookings"`
+	} `json:"data"`
+}
+
+// ToScheduleCreatedResponse converts a created schedule to response
+func ToScheduleCreatedResponse(schedule *services.Schedule) *v1.ScheduleCreatedResponse {
+	v1Schedule := ToV1Schedule(schedule)
+	resp := &v1.ScheduleCreatedResponse{}
+	resp.Data.Schedule = &v1Schedule
+	return resp
+}
+
+// ToScheduleChangeResponse converts an applied schedule change to response
+func ToScheduleChangeResponse(result *services.ScheduleChangeResult, message string) *ScheduleChangeResponse {
+	resp := &ScheduleChangeResponse{
+		Data: ScheduleChangeData{
+			AffectedBookings: ToAffectedBookingDTOs(result.Affected),
+		},
+		Message: message,
+	}
+	if result.Schedule != nil {
+		schedule := ToV1Schedule(result.Schedule)
+		resp.Data.Schedule = &schedule
+	}
+	if result.Exception != nil {
+		resp.Data.Exception = &ScheduleExceptionDTO{
+			ID:            result.Exception.ID,
+			ServiceID:     result.Exception.ServiceID,
+			ExceptionDate: result.Exception.Date.Format("2006-01-02"),
+			Reason:        result.Exception.Reason,
+			IsClosed:      result.Exception.IsClosed,
+		}
+	}
+	return resp
+}
+
+// ToOverbookingResponse converts a rejected schedule change to response
+func ToOverbookingResponse(result *services.ScheduleChangeResult) *OverbookingResponse {
+	resp := &OverbookingResponse{}
+	resp.Error.Code = "SCHEDULE_OVERBOOKED"
+	resp.Error.Message = fmt.Sprintf(
+		"This change affects %d booked session(s). Reschedule or cancel them, or resend with force=true to apply it anyway",
+		len(result.Affected),
+	)
+	resp.Data.AffectedBookings = ToAffectedBookingDTOs(result.Affected)
+	return resp
+}
+
+// ToAffectedBookingDTOs converts affected sessions to DTOs
+func ToAffectedBookingDTOs(affected []*services.AffectedSession) []AffectedBookingDTO {
+	dtos := make([]AffectedBookingDTO, 0, len(affected))
+	for _, a := range affected {
+		dtos = append(dtos, AffectedBookingDTO{
+			BookingID:     a.BookingID,
+			BookingNumber: a.BookingNumber,
+			BookingStatus: string(a.BookingStatus),
+			SessionID:     a.SessionID,
+			ScheduleID:    a.ScheduleID,
+			SessionDate:   a.SessionDate.Format("2006-01-02"),
+			StartTime:     a.StartTime,
+			EndTime:       a.EndTime,
+			ChildName:     a.ChildName,
+		})
+	}
+	return dtos
+}
+
+// ToV1Schedule converts domain Schedule to v1.Schedule
+func ToV1Schedule(s *services.Schedule) v1.Schedule {
+	id := s.ID
+	dayOfWeek := s.DayOfWeek
+	dayName := services.GetDayName(s.DayOfWeek)
+	startTime := s.StartTime
+	endTime := s.EndTime
+	availableSlots := s.AvailableSlots
+
+	schedule := v1.Schedule{
+		Id:             &id,
+		DayOfWeek:      &dayOfWeek,
+		DayName:        &dayName,
+		StartTime:      &startTime,
+		EndTime:        &endTime,
+		AvailableSlots: &availableSlots,
+	}
+	if s.CoachID != nil {
+		schedule.Coach = &struct {
+			Id   *int64  `json:"id,omitempty"`
+			Name *string `json:"name,omitempty"`
+		}{Id: s.CoachID}
+	}
+	return schedule
+}
@@ -0,0 +1,269 @@
+package catalog
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// Handler handles HTTP requests for the vendor catalog capability
+type Handler struct {
+	service *ServiceUsecase
+}
+
+// NewHandler creates a new catalog handler
+func NewHandler(service *ServiceUsecase) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListServices handles GET /vendor/services
+func (h *Handler) ListServices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	params, err := NewListVendorServicesParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.ListServices(ctx, userID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// CreateService handles POST /vendor/services
+func (h *Handler) CreateService(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	params, err := NewCreateServiceParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.CreateService(ctx, userID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+// UpdateService handles PUT /vendor/services/{id}
+func (h *Handler) UpdateService(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	serviceID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("id must be a valid integer"))
+		return
+	}
+
+	params, err := NewUpdateServiceParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.UpdateService(ctx, userID, serviceID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// DeleteService handles DELETE /vendor/services/{id}
+func (h *Handler) DeleteService(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	serviceID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("id must be a valid integer"))
+		return
+	}
+
+	response, err := h.service.DeleteService(ctx, userID, serviceID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// AddSchedule handles POST /vendor/services/{service_id}/schedules
+func (h *Handler) AddSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	serviceID, err := strconv.ParseInt(chi.URLParam(r, "service_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("service_id must be a valid integer"))
+		return
+	}
+
+	params, err := NewAddScheduleParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.AddSchedule(ctx, userID, serviceID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+// UpdateSchedule handles PUT /vendor/services/{service_id}/schedules/{schedule_id}
+func (h *Handler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	serviceID, err := strconv.ParseInt(chi.URLParam(r, "service_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("service_id must be a valid integer"))
+		return
+	}
+
+	scheduleID, err := strconv.ParseInt(chi.URLParam(r, "schedule_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("schedule_id must be a valid integer"))
+		return
+	}
+
+	params, err := NewUpdateScheduleParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	result, err := h.service.UpdateSchedule(ctx, userID, serviceID, scheduleID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	h.renderScheduleChange(w, r, result, http.StatusOK, "Schedule updated successfully")
+}
+
+// AddScheduleException handles POST /vendor/schedule-exceptions
+func (h *Handler) AddScheduleException(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	params, err := NewAddScheduleExceptionParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	result, err := h.service.AddScheduleException(ctx, userID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	h.renderScheduleChange(w, r, result, http.StatusCreated, "Day closed successfully")
+}
+
+// renderScheduleChange renders an applied change, or a 409 listing the bookings that kept it from being applied
+func (h *Handler) renderScheduleChange(w http.ResponseWriter, r *http.Request, result *services.ScheduleChangeResult, status int, message string) {
+	if !result.Applied {
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, ToOverbookingResponse(result))
+		return
+	}
+
+	render.Status(r, status)
+	render.JSON(w, r, ToScheduleChangeResponse(result, message))
+}
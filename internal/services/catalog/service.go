@@ -0,0 +1,341 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/services"
+	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+)
+
+// Repository defines the data access interface for the vendor catalog capability
+type Repository interface {
+	GetVendorIDByUserID(ctx context.Context, userID int64) (int64, error)
+	ListVendorServices(ctx context.Context, vendorID int64, page, limit int) ([]*services.Service, int64, error)
+	GetVendorService(ctx context.Context, serviceID int64) (*services.Service, error)
+	IsActiveCategory(ctx context.Context, categoryID int64) (bool, error)
+	CreateService(ctx context.Context, service *services.Service) error
+	UpdateService(ctx context.Context, service *services.Service) error
+	DeleteService(ctx context.Context, service *services.Service, at time.Time) (bool, error)
+	IsVendorCoach(ctx context.Context, coachID, vendorID int64) (bool, error)
+	GetScheduleByID(ctx context.Context, scheduleID int64) (*services.Schedule, error)
+	CreateSchedule(ctx context.Context, schedule *services.Schedule) error
+	UpdateSchedule(ctx context.Context, schedule *services.Schedule, force bool, now time.Time) (*services.ScheduleChangeResult, error)
+	CreateScheduleException(ctx context.Context, req *services.ScheduleExceptionRequest, force bool, now time.Time) (*services.ScheduleChangeResult, error)
+}
+
+// ServiceUsecase handles vendors managing their own services
+type ServiceUsecase struct {
+	repo Repository
+}
+
+// NewService creates a new catalog service
+func NewService(repo Repository) *ServiceUsecase {
+	return &ServiceUsecase{
+		repo: repo,
+	}
+}
+
+// ListServices lists the services of the authenticated vendor
+func (s *ServiceUsecase) ListServices(ctx context.Context, userID int64, params *ListVendorServicesParams) (*v1.VendorServicesResponse, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.repo.ListVendorServices(ctx, vendorID, params.Page, params.Limit)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToVendorServicesResponse(items, params.Page, params.Limit, total), nil
+}
+
+// CreateService creates a draft service for the authenticated vendor.
+// Drafts are not listed in search until the vendor activates them.
+func (s *ServiceUsecase) CreateService(ctx context.Context, userID int64, params *CreateServiceParams) (*v1.ServiceCreatedResponse, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	service := params.ToService(vendorID)
+	if err := s.validateService(ctx, service, true); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	service.CreatedAt = now
+	service.UpdatedAt = now
+
+	if err := s.repo.CreateService(ctx, service); err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToServiceCreatedResponse(service), nil
+}
+
+// UpdateService applies a vendor's changes to one of their services, including lifecycle changes
+func (s *ServiceUsecase) UpdateService(ctx context.Context, userID, serviceID int64, params *UpdateServiceParams) (*v1.ServiceUpdatedResponse, error) {
+	service, err := s.getVendorService(ctx, userID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryChanged := params.CategoryID != nil && *params.CategoryID != service.CategoryID
+	params.ApplyTo(service)
+
+	if params.Status != nil {
+		to := services.ServiceStatus(*params.Status)
+		if to != service.Status {
+			if err := service.Status.ValidateTransition(to); err != nil {
+				return nil, internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+			}
+			service.Status = to
+		}
+	}
+
+	if err := s.validateService(ctx, service, categoryChanged); err != nil {
+		return nil, err
+	}
+
+	service.UpdatedAt = time.Now()
+	if err := s.repo.UpdateService(ctx, service); err != nil {
+		if errors.Is(err, internal.ErrOptimisticLock) {
+			return nil, internal.NewConflictError("Service was modified, please try again", internal.ErrConflict)
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToServiceUpdatedResponse(service), nil
+}
+
+// DeleteService deletes one of the vendor's services.
+// Services with bookings are archived instead so the bookings keep their service.
+func (s *ServiceUsecase) DeleteService(ctx context.Context, userID, serviceID int64) (*ServiceDeletedResponse, error) {
+	service, err := s.getVendorService(ctx, userID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := s.repo.DeleteService(ctx, service, time.Now())
+	if err != nil {
+		if errors.Is(err, internal.ErrOptimisticLock) {
+			return nil, internal.NewConflictError("Service was modified, please try again", internal.ErrConflict)
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	message := "Service deleted successfully"
+	if archived {
+		message = "Service has bookings and was archived"
+	}
+
+	return &ServiceDeletedResponse{
+		Data: ServiceDeletedData{
+			ID:       service.ID,
+			Archived: archived,
+		},
+		Message: message,
+	}, nil
+}
+
+// AddSchedule adds a weekly schedule to one of the vendor's services
+func (s *ServiceUsecase) AddSchedule(ctx context.Context, userID, serviceID int64, params *AddScheduleParams) (*v1.ScheduleCreatedResponse, error) {
+	service, err := s.getVendorService(ctx, userID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := params.ToSchedule(service.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateSchedule(ctx, schedule, service); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+
+	if err := s.repo.CreateSchedule(ctx, schedule); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToScheduleCreatedResponse(schedule), nil
+}
+
+// UpdateSchedule changes the coach, capacity or active flag of a schedule.
+// Changes that overbook upcoming sessions are reported back unapplied unless forced.
+func (s *ServiceUsecase) UpdateSchedule(ctx context.Context, userID, serviceID, scheduleID int64, params *UpdateScheduleParams) (*services.ScheduleChangeResult, error) {
+	service, err := s.getVendorService(ctx, userID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.repo.GetScheduleByID(ctx, scheduleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Schedule")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+	if schedule.ServiceID != service.ID {
+		return nil, internal.NewNotFoundError("Schedule")
+	}
+
+	update := params.ToScheduleUpdate()
+	if update.CoachID != nil {
+		schedule.CoachID = update.CoachID
+	}
+	if update.AvailableSlots != nil {
+		schedule.AvailableSlots = *update.AvailableSlots
+	}
+	if update.IsActive != nil {
+		schedule.IsActive = *update.IsActive
+	}
+
+	if err := s.validateSchedule(ctx, schedule, service); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.UpdateSchedule(ctx, schedule, params.Force, time.Now())
+	if err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return result, nil
+}
+
+// AddScheduleException closes a day for all of the vendor's services, or for one of them.
+// Closing a day with booked sessions is reported back unapplied unless forced.
+func (s *ServiceUsecase) AddScheduleException(ctx context.Context, userID int64, params *AddScheduleExceptionParams) (*services.ScheduleChangeResult, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.ServiceID != nil {
+		if _, err := s.getVendorService(ctx, userID, *params.ServiceID); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := params.ToScheduleExceptionRequest(vendorID)
+	if err != nil {
+		return nil, internal.NewValidationError("invalid exception_date format, expected YYYY-MM-DD")
+	}
+
+	now := time.Now()
+	if err := req.Validate(now); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	result, err := s.repo.CreateScheduleException(ctx, req, params.Force, now)
+	if err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return result, nil
+}
+
+// validateSchedule checks the schedule against the domain rules and its coach against the vendor's staff
+func (s *ServiceUsecase) validateSchedule(ctx context.Context, schedule *services.Schedule, service *services.Service) error {
+	if err := schedule.Validate(); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+	if err := schedule.ValidateForService(service); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+
+	if schedule.CoachID == nil {
+		return nil
+	}
+
+	ok, err := s.repo.IsVendorCoach(ctx, *schedule.CoachID, service.VendorID)
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if !ok {
+		return internal.NewValidationError(fmt.Sprintf("coach %d does not work for this vendor", *schedule.CoachID))
+	}
+
+	return nil
+}
+
+// validateService checks the service against the domain rules, and its category if it was set or changed
+func (s *ServiceUsecase) validateService(ctx context.Context, service *services.Service, checkCategory bool) error {
+	if err := service.Validate(); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+	if err := service.ValidateAgeRange(); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+
+	if !checkCategory {
+		return nil
+	}
+
+	active, err := s.repo.IsActiveCategory(ctx, service.CategoryID)
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if !active {
+		return internal.NewValidationError(fmt.Sprintf("category %d does not exist", service.CategoryID))
+	}
+
+	return nil
+}
+
+// getVendorService loads a service and verifies it belongs to the authenticated vendor.
+// Archived services are treated as deleted.
+func (s *ServiceUsecase) getVendorService(ctx context.Context, userID, serviceID int64) (*services.Service, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := s.repo.GetVendorService(ctx, serviceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Service")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if service.VendorID != vendorID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	if service.IsArchived() {
+		return nil, internal.NewNotFoundError("Service")
+	}
+
+	return service, nil
+}
+
+// resolveVendorID finds the vendor profile of the authenticated user
+func (s *ServiceUsecase) resolveVendorID(ctx context.Context, userID int64) (int64, error) {
+	vendorID, err := s.repo.GetVendorIDByUserID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, internal.NewForbiddenError("Vendor profile not found")
+		}
+		return 0, internal.NewInternalServerError(err)
+	}
+	return vendorID, nil
+}
@@ -0,0 +1,483 @@
+package coach
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/child"
+	"github.com/frahmantamala/jadiles/internal/core/common"
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+// CertificationParams is a certification in coach requests
+type CertificationParams struct {
+	Name    string `json:"name" validate:"required,max=255"`
+	Issuer  string `json:"issuer,omitempty" validate:"max=255"`
+	Year    int    `json:"year,omitempty"`
+	FileURL string `json:"file_url,omitempty" validate:"omitempty,url"`
+}
+
+// AddCoachParams represents the HTTP request body for adding a coach.
+// Without a password a temporary one is generated and returned once.
+type AddCoachParams struct {
+	FullName        string                `json:"full_name" validate:"required,min=2,max=255"`
+	Email           string                `json:"email" validate:"required,email,max=255"`
+	Phone           string                `json:"phone" validate:"required"`
+	Password        *string               `json:"password,omitempty" validate:"omitempty,min=8,max=72"`
+	Bio             *string               `json:"bio,omitempty" validate:"omitempty,max=2000"`
+	ExperienceYears *int                  `json:"experience_years,omitempty" validate:"omitempty,min=0,max=80"`
+	Education       *string               `json:"education,omitempty" validate:"omitempty,max=500"`
+	Certifications  []CertificationParams `json:"certifications,omitempty" validate:"omitempty,max=20,dive"`
+	Specializations []string              `json:"specializations,omitempty" validate:"omitempty,max=20,dive,required,max=100"`
+	Photo           *string               `json:"photo,omitempty" validate:"omitempty,url"`
+}
+
+// NewAddCoachParams parses the add coach request
+func NewAddCoachParams(r *http.Request) (*AddCoachParams, error) {
+	var params AddCoachParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the add coach parameters
+func (p *AddCoachParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToCoach converts DTO to a new active coach of the vendor
+func (p *AddCoachParams) ToCoach(vendorID int64) *services.Coach {
+	coach := &services.Coach{
+		VendorID:        vendorID,
+		FullName:        p.FullName,
+		Email:           p.Email,
+		Phone:           p.Phone,
+		Bio:             p.Bio,
+		Education:       p.Education,
+		Certifications:  toCertifications(p.Certifications),
+		Specializations: p.Specializations,
+		Photo:           p.Photo,
+		Status:          services.CoachStatusActive,
+	}
+	if p.ExperienceYears != nil {
+		coach.ExperienceYears = *p.ExperienceYears
+	}
+	return coach
+}
+
+// UpdateCoachParams represents the HTTP request body for updating a coach.
+// Only the fields present in the body are changed; the login email cannot be changed here.
+type UpdateCoachParams struct {
+	FullName        *string                `json:"full_name,omitempty" validate:"omitempty,min=2,max=255"`
+	Phone           *string                `json:"phone,omitempty"`
+	Bio             *string                `json:"bio,omitempty" validate:"omitempty,max=2000"`
+	ExperienceYears *int                   `json:"experience_years,omitempty" validate:"omitempty,min=0,max=80"`
+	Education       *string                `json:"education,omitempty" validate:"omitempty,max=500"`
+	Certifications  *[]CertificationParams `json:"certifications,omitempty" validate:"omitempty,max=20,dive"`
+	Specializations *[]string              `json:"specializations,omitempty" validate:"omitempty,max=20,dive,required,max=100"`
+	Photo           *string                `json:"photo,omitempty" validate:"omitempty,url"`
+	IsFeatured      *bool                  `json:"is_featured,omitempty"`
+	Status          *string                `json:"status,omitempty" validate:"omitempty,oneof=active inactive"`
+}
+
+// NewUpdateCoachParams parses the update coach request
+func NewUpdateCoachParams(r *http.Request) (*UpdateCoachParams, error) {
+	var params UpdateCoachParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the update coach parameters
+func (p *UpdateCoachParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ApplyTo copies the fields present in the request onto the coach
+func (p *UpdateCoachParams) ApplyTo(coach *services.Coach) {
+	if p.FullName != nil {
+		coach.FullName = *p.FullName
+	}
+	if p.Phone != nil {
+		coach.Phone = *p.Phone
+	}
+	if p.Bio != nil {
+		coach.Bio = p.Bio
+	}
+	if p.ExperienceYears != nil {
+		coach.ExperienceYears = *p.ExperienceYears
+	}
+	if p.Education != nil {
+		coach.Education = p.Education
+	}
+	if p.Certifications != nil {
+		coach.Certifications = toCertifications(*p.Certifications)
+	}
+	if p.Specializations != nil {
+		coach.Specializations = *p.Specializations
+	}
+	if p.Photo != nil {
+		coach.Photo = p.Photo
+	}
+	if p.IsFeatured != nil {
+		coach.IsFeatured = *p.IsFeatured
+	}
+	if p.Status != nil {
+		coach.Status = services.CoachStatus(*p.Status)
+	}
+}
+
+// AssignCoachParams represents the HTTP request body for assigning a coach to a service
+type AssignCoachParams struct {
+	CoachID   int64 `json:"coach_id" validate:"required,gt=0"`
+	IsPrimary bool  `json:"is_primary,omitempty"`
+}
+
+// NewAssignCoachParams parses the assign coach request
+func NewAssignCoachParams(r *http.Request) (*AssignCoachParams, error) {
+	var params AssignCoachParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the assign coach parameters
+func (p *AssignCoachParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+func toCertifications(params []CertificationParams) []services.Certification {
+	certifications := make([]services.Certification, 0, len(params))
+	for _, c := range params {
+		certifications = append(certifications, services.Certification{
+			Name:    c.Name,
+			Issuer:  c.Issuer,
+			Year:    c.Year,
+			FileURL: c.FileURL,
+		})
+	}
+	return certifications
+}
+
+// CoachDTO is a coach in vendor API responses
+type CoachDTO struct {
+	ID              int64                    `json:"id"`
+	UserID          int64                    `json:"user_id"`
+	FullName        string                   `json:"full_name"`
+	Email           string                   `json:"email"`
+	Phone           string                   `json:"phone"`
+	Bio             *string                  `json:"bio,omitempty"`
+	ExperienceYears int                      `json:"experience_years"`
+	Education       *string                  `json:"education,omitempty"`
+	Certifications  []services.Certification `json:"certifications"`
+	Specializations []string                 `json:"specializations"`
+	Photo           *string                  `json:"photo,omitempty"`
+	IsFeatured      bool                     `json:"is_featured"`
+	Status          string                   `json:"status"`
+	CreatedAt       time.Time                `json:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+}
+
+// CoachesResponse is the response for a vendor's coach list
+type CoachesResponse struct {
+	Data []CoachDTO `json:"data"`
+}
+
+// CoachResponse is the response for a single coach
+type CoachResponse struct {
+	Data    CoachData `json:"data"`
+	Message string    `json:"message,omitempty"`
+}
+
+type CoachData struct {
+	Coach             CoachDTO `json:"coach"`
+	TemporaryPassword *string  `json:"temporary_password,omitempty"` // Only set when the account was just created without a password
+}
+
+// ServiceCoachDTO is a coach assigned to a service
+type ServiceCoachDTO struct {
+	ID              int64                    `json:"id"`
+	FullName        string                   `json:"full_name"`
+	Photo           *string                  `json:"photo,omitempty"`
+	ExperienceYears int                      `json:"experience_years"`
+	Certifications  []services.Certification `json:"certifications"`
+	Specializations []string                 `json:"specializations"`
+	IsPrimary       bool                     `json:"is_primary"`
+}
+
+// ServiceCoachesResponse is the response for a service's coach list
+type ServiceCoachesResponse struct {
+	Data    []ServiceCoachDTO `json:"data"`
+	Message string            `json:"message,omitempty"`
+}
+
+// ToCoachesResponse converts a vendor's coaches to response
+func ToCoachesResponse(coaches []*services.Coach) *CoachesResponse {
+	data := make([]CoachDTO, 0, len(coaches))
+	for _, c := range coaches {
+		data = append(data, ToCoachDTO(c))
+	}
+	return &CoachesResponse{Data: data}
+}
+
+// ToCoachResponse converts a coach to response
+func ToCoachResponse(coach *services.Coach, message string) *CoachResponse {
+	return &CoachResponse{
+		Data:    CoachData{Coach: ToCoachDTO(coach)},
+		Message: message,
+	}
+}
+
+// ToServiceCoachesResponse converts a service's coaches to response
+func ToServiceCoachesResponse(coaches []*services.CoachDetail, message string) *ServiceCoachesResponse {
+	data := make([]ServiceCoachDTO, 0, len(coaches))
+	for _, c := range coaches {
+		data = append(data, ServiceCoachDTO{
+			ID:              c.ID,
+			FullName:        c.FullName,
+			Photo:           c.Photo,
+			ExperienceYears: c.ExperienceYears,
+			Certifications:  nonNilCertifications(c.Certifications),
+			Specializations: nonNilStrings(c.Specializations),
+			IsPrimary:       c.IsPrimary,
+		})
+	}
+	return &ServiceCoachesResponse{Data: data, Message: message}
+}
+
+// ToCoachDTO converts domain Coach to DTO
+func ToCoachDTO(c *services.Coach) CoachDTO {
+	return CoachDTO{
+		ID:              c.ID,
+		UserID:          c.UserID,
+		FullName:        c.FullName,
+		Email:           c.Email,
+		Phone:           c.Phone,
+		Bio:             c.Bio,
+		ExperienceYears: c.ExperienceYears,
+		Education:       c.Education,
+		Certifications:  nonNilCertifications(c.Certifications),
+		Specializations: nonNilStrings(c.Specializations),
+		Photo:           c.Photo,
+		IsFeatured:      c.IsFeatured,
+		Status:          string(c.Status),
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func nonNilCertifications(certifications []services.Certification) []services.Certification {
+	if certifications == nil {
+		return []services.Certification{}
+	}
+	return certifications
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+const defaultCoachSessionDays = 7
+
+// ListSessionsParams represents the query parameters for a coach's session list
+type ListSessionsParams struct {
+	From string // YYYY-MM-DD format, defaults to today
+	To   string // YYYY-MM-DD format, defaults to a week after from
+}
+
+// NewListSessionsParams parses the coach session list query parameters
+func NewListSessionsParams(r *http.Request) *ListSessionsParams {
+	query := r.URL.Query()
+	return &ListSessionsParams{
+		From: query.Get("from"),
+		To:   query.Get("to"),
+	}
+}
+
+// Validate validates the coach session list parameters
+func (p *ListSessionsParams) Validate(ctx context.Context) error {
+	if p.From != "" {
+		if _, err := time.Parse("2006-01-02", p.From); err != nil {
+			return internal.NewValidationError("invalid from format, expected YYYY-MM-DD")
+		}
+	}
+	if p.To != "" {
+		if _, err := time.Parse("2006-01-02", p.To); err != nil {
+			return internal.NewValidationError("invalid to format, expected YYYY-MM-DD")
+		}
+	}
+	return nil
+}
+
+// ToCoachSessionFilter converts the parameters to a domain filter
+func (p *ListSessionsParams) ToCoachSessionFilter(coachID int64, now time.Time) *services.CoachSessionFilter {
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if p.From != "" {
+		from, _ = time.Parse("2006-01-02", p.From)
+	}
+
+	to := from.AddDate(0, 0, defaultCoachSessionDays-1)
+	if p.To != "" {
+		to, _ = time.Parse("2006-01-02", p.To)
+	}
+
+	return &services.CoachSessionFilter{
+		CoachID: coachID,
+		From:    from,
+		To:      to,
+	}
+}
+
+// SessionChildDTO is the child attending a session
+type SessionChildDTO struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Nickname     *string `json:"nickname,omitempty"`
+	Age          int     `json:"age"`
+	Gender       *string `json:"gender,omitempty"`
+	SpecialNeeds *string `json:"special_needs,omitempty"`
+}
+
+// CoachSessionDTO is a session in the coach's schedule
+type CoachSessionDTO struct {
+	ID            int64           `json:"id"`
+	BookingID     int64           `json:"booking_id"`
+	BookingNumber string          `json:"booking_number"`
+	ServiceID     int64           `json:"service_id"`
+	ServiceName   string          `json:"service_name"`
+	ClassType     string          `json:"class_type"`
+	SessionNumber int             `json:"session_number"`
+	TotalSessions int             `json:"total_sessions"`
+	SessionDate   string          `json:"session_date"`
+	StartTime     string          `json:"start_time"`
+	EndTime       string          `json:"end_time"`
+	Status        string          `json:"status"`
+	Attended      bool            `json:"attended"`
+	CoachNotes    *string         `json:"coach_notes,omitempty"`
+	ParentNotes   *string         `json:"parent_notes,omitempty"`
+	Child         SessionChildDTO `json:"child"`
+}
+
+// CoachSessionsResponse is the response for a coach's session list
+type CoachSessionsResponse struct {
+	Data CoachSessionsData `json:"data"`
+}
+
+type CoachSessionsData struct {
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	Sessions []CoachSessionDTO `json:"sessions"`
+}
+
+// RosterEntryDTO is a child booked into a class
+type RosterEntryDTO struct {
+	SessionID     int64           `json:"session_id"`
+	BookingNumber string          `json:"booking_number"`
+	SessionNumber int             `json:"session_number"`
+	TotalSessions int             `json:"total_sessions"`
+	Status        string          `json:"status"`
+	Attended      bool            `json:"attended"`
+	ParentNotes   *string         `json:"parent_notes,omitempty"`
+	Child         SessionChildDTO `json:"child"`
+}
+
+// RosterResponse is the response for the roster of a class
+type RosterResponse struct {
+	Data RosterData `json:"data"`
+}
+
+type RosterData struct {
+	ServiceID    int64            `json:"service_id"`
+	ServiceName  string           `json:"service_name"`
+	ClassType    string           `json:"class_type"`
+	SessionDate  string           `json:"session_date"`
+	StartTime    string           `json:"start_time"`
+	EndTime      string           `json:"end_time"`
+	Participants []RosterEntryDTO `json:"participants"`
+	Total        int              `json:"total"`
+}
+
+// ToCoachSessionsResponse converts a coach's sessions to response
+func ToCoachSessionsResponse(filter *services.CoachSessionFilter, sessions []*services.CoachSession) *CoachSessionsResponse {
+	data := make([]CoachSessionDTO, 0, len(sessions))
+	for _, s := range sessions {
+		data = append(data, CoachSessionDTO{
+			ID:            s.SessionID,
+			BookingID:     s.BookingID,
+			BookingNumber: s.BookingNumber,
+			ServiceID:     s.ServiceID,
+			ServiceName:   s.ServiceName,
+			ClassType:     string(s.ClassType),
+			SessionNumber: s.SessionNumber,
+			TotalSessions: s.TotalSessions,
+			SessionDate:   s.SessionDate.Format("2006-01-02"),
+			StartTime:     s.StartTime,
+			EndTime:       s.EndTime,
+			Status:        string(s.Status),
+			Attended:      s.Attended,
+			CoachNotes:    s.CoachNotes,
+			ParentNotes:   s.ParentNotes,
+			Child:         toSessionChildDTO(s),
+		})
+	}
+
+	return &CoachSessionsResponse{
+		Data: CoachSessionsData{
+			From:     filter.From.Format("2006-01-02"),
+			To:       filter.To.Format("2006-01-02"),
+			Sessions: data,
+		},
+	}
+}
+
+// ToRosterResponse converts the children booked into a class to response
+func ToRosterResponse(session *services.CoachSession, roster []*services.CoachSession) *RosterResponse {
+	participants := make([]RosterEntryDTO, 0, len(roster))
+	for _, s := range roster {
+		participants = append(participants, RosterEntryDTO{
+			SessionID:     s.SessionID,
+			BookingNumber: s.BookingNumber,
+			SessionNumber: s.SessionNumber,
+			TotalSessions: s.TotalSessions,
+			Status:        string(s.Status),
+			Attended:      s.Attended,
+			ParentNotes:   s.ParentNotes,
+			Child:         toSessionChildDTO(s),
+		})
+	}
+
+	return &RosterResponse{
+		Data: RosterData{
+			ServiceID:    session.ServiceID,
+			ServiceName:  session.ServiceName,
+			ClassType:    string(session.ClassType),
+			SessionDate:  session.SessionDate.Format("2006-01-02"),
+			StartTime:    session.StartTime,
+			EndTime:      session.EndTime,
+			Participants: participants,
+			Total:        len(participants),
+		},
+	}
+}
+
+// toSessionChildDTO builds the child of a session, with the age worked out from the birth date
+func toSessionChildDTO(s *services.CoachSession) SessionChildDTO {
+	c := &child.Child{DateOfBirth: s.ChildBirthDate}
+	return SessionChildDTO{
+		ID:           s.ChildID,
+		Name:         s.ChildName,
+		Nickname:     s.ChildNickname,
+		Age:          c.CalculateAge(),
+		Gender:       s.ChildGender,
+		SpecialNeeds: s.SpecialNeeds,
+	}
+}
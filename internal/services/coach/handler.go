@@ -0,0 +1,309 @@
+package coach
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// Handler handles HTTP requests for the coach capability
+type Handler struct {
+	service *ServiceUsecase
+}
+
+// NewHandler creates a new coach handler
+func NewHandler(service *ServiceUsecase) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListCoaches handles GET /vendor/coaches
+func (h *Handler) ListCoaches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	response, err := h.service.ListCoaches(ctx, userID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// AddCoach handles POST /vendor/coaches
+func (h *Handler) AddCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	params, err := NewAddCoachParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.AddCoach(ctx, userID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+// GetCoach handles GET /vendor/coaches/{coach_id}
+func (h *Handler) GetCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	coachID, err := strconv.ParseInt(chi.URLParam(r, "coach_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("coach_id must be a valid integer"))
+		return
+	}
+
+	response, err := h.service.GetCoach(ctx, userID, coachID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// UpdateCoach handles PUT /vendor/coaches/{coach_id}
+func (h *Handler) UpdateCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	coachID, err := strconv.ParseInt(chi.URLParam(r, "coach_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("coach_id must be a valid integer"))
+		return
+	}
+
+	params, err := NewUpdateCoachParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.UpdateCoach(ctx, userID, coachID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// DeleteCoach handles DELETE /vendor/coaches/{coach_id}
+func (h *Handler) DeleteCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	coachID, err := strconv.ParseInt(chi.URLParam(r, "coach_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("coach_id must be a valid integer"))
+		return
+	}
+
+	response, err := h.service.DeleteCoach(ctx, userID, coachID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ListServiceCoaches handles GET /vendor/services/{service_id}/coaches
+func (h *Handler) ListServiceCoaches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	serviceID, err := strconv.ParseInt(chi.URLParam(r, "service_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("service_id must be a valid integer"))
+		return
+	}
+
+	response, err := h.service.ListServiceCoaches(ctx, userID, serviceID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// AssignCoach handles POST /vendor/services/{service_id}/coaches
+func (h *Handler) AssignCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	serviceID, err := strconv.ParseInt(chi.URLParam(r, "service_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("service_id must be a valid integer"))
+		return
+	}
+
+	params, err := NewAssignCoachParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.AssignCoach(ctx, userID, serviceID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// UnassignCoach handles DELETE /vendor/services/{service_id}/coaches/{coach_id}
+func (h *Handler) UnassignCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	serviceID, err := strconv.ParseInt(chi.URLParam(r, "service_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("service_id must be a valid integer"))
+		return
+	}
+
+	coachID, err := strconv.ParseInt(chi.URLParam(r, "coach_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("coach_id must be a valid integer"))
+		return
+	}
+
+	response, err := h.service.UnassignCoach(ctx, userID, serviceID, coachID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ListSessions handles GET /coach/sessions
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	params := NewListSessionsParams(r)
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.ListSessions(ctx, userID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// GetSessionRoster handles GET /coach/sessions/{session_id}/roster
+func (h *Handler) GetSessionRoster(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(chi.URLParam(r, "session_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("session_id must be a valid integer"))
+		return
+	}
+
+	response, err := h.service.GetSessionRoster(ctx, userID, sessionID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
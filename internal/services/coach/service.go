@@ -0,0 +1,410 @@
+package coach
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"github.com/frahmantamala/jadiles/internal/user"
+)
+
+// Repository defines the data access interface for the coach capability
+type Repository interface {
+	GetVendorIDByUserID(ctx context.Context, userID int64) (int64, error)
+	GetVendorService(ctx context.Context, serviceID int64) (*services.Service, error)
+	ListVendorCoaches(ctx context.Context, vendorID int64) ([]*services.Coach, error)
+	GetCoachByID(ctx context.Context, coachID int64) (*services.Coach, error)
+	IsEmailRegistered(ctx context.Context, email string) (bool, error)
+	CreateCoach(ctx context.Context, coach *services.Coach, account *datamodel.User) error
+	UpdateCoach(ctx context.Context, coach *services.Coach, now time.Time) error
+	ListServiceCoaches(ctx context.Context, serviceID int64) ([]*services.CoachDetail, error)
+	AssignServiceCoach(ctx context.Context, serviceID, coachID int64, isPrimary bool) error
+	UnassignServiceCoach(ctx context.Context, serviceID, coachID int64) error
+	GetCoachByUserID(ctx context.Context, userID int64) (int64, int64, error)
+	ListCoachSessions(ctx context.Context, filter *services.CoachSessionFilter) ([]*services.CoachSession, error)
+	GetCoachSession(ctx context.Context, sessionID int64) (*services.CoachSession, error)
+	ListSessionRoster(ctx context.Context, session *services.CoachSession) ([]*services.CoachSession, error)
+}
+
+// PasswordHasher hashes the password of provisioned coach accounts
+type PasswordHasher interface {
+	HashPassword(password string) (string, error)
+}
+
+// ServiceUsecase handles vendors managing their coaches, and coaches viewing the sessions they teach
+type ServiceUsecase struct {
+	repo   Repository
+	hasher PasswordHasher
+}
+
+// NewService creates a new coach service
+func NewService(repo Repository, hasher PasswordHasher) *ServiceUsecase {
+	return &ServiceUsecase{
+		repo:   repo,
+		hasher: hasher,
+	}
+}
+
+// ListCoaches lists the coaches of the authenticated vendor
+func (s *ServiceUsecase) ListCoaches(ctx context.Context, userID int64) (*CoachesResponse, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	coaches, err := s.repo.ListVendorCoaches(ctx, vendorID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToCoachesResponse(coaches), nil
+}
+
+// GetCoach returns one of the vendor's coaches
+func (s *ServiceUsecase) GetCoach(ctx context.Context, userID, coachID int64) (*CoachResponse, error) {
+	coach, err := s.getVendorCoach(ctx, userID, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToCoachResponse(coach, ""), nil
+}
+
+// AddCoach provisions a coach profile together with a user account of role coach,
+// so the coach can log in and see their own sessions
+func (s *ServiceUsecase) AddCoach(ctx context.Context, userID int64, params *AddCoachParams) (*CoachResponse, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	coach := params.ToCoach(vendorID)
+	coach.Email = strings.ToLower(strings.TrimSpace(coach.Email))
+	if err := coach.Validate(); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	account := &user.User{
+		Email:    coach.Email,
+		FullName: coach.FullName,
+		Phone:    coach.Phone,
+		Role:     user.RoleCoach,
+		Status:   user.StatusActive,
+	}
+	if err := account.Validate(); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	registered, err := s.repo.IsEmailRegistered(ctx, coach.Email)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+	if registered {
+		return nil, internal.NewConflictError("Email is already registered", internal.ErrConflict)
+	}
+
+	var temporaryPassword *string
+	password := ""
+	if params.Password != nil {
+		password = *params.Password
+	} else {
+		password, err = generateTemporaryPassword()
+		if err != nil {
+			return nil, internal.NewInternalServerError(err)
+		}
+		temporaryPassword = &password
+	}
+	if err := account.ValidatePassword(password); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	passwordHash, err := s.hasher.HashPassword(password)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	now := time.Now()
+	coach.CreatedAt = now
+	coach.UpdatedAt = now
+
+	accountDM := &datamodel.User{
+		Email:        account.Email,
+		PasswordHash: passwordHash,
+		FullName:     account.FullName,
+		Phone:        account.Phone,
+		Role:         string(account.Role),
+		Status:       string(account.Status),
+		Version:      1,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := s.repo.CreateCoach(ctx, coach, accountDM); err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	resp := ToCoachResponse(coach, "Coach added successfully")
+	resp.Data.TemporaryPassword = temporaryPassword
+	return resp, nil
+}
+
+// UpdateCoach applies a vendor's changes to one of their coaches, including (de)activation
+func (s *ServiceUsecase) UpdateCoach(ctx context.Context, userID, coachID int64, params *UpdateCoachParams) (*CoachResponse, error) {
+	coach, err := s.getVendorCoach(ctx, userID, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	params.ApplyTo(coach)
+	if err := s.validateCoach(coach); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCoach(ctx, coach, time.Now()); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToCoachResponse(coach, "Coach updated successfully"), nil
+}
+
+// DeleteCoach deactivates one of the vendor's coaches.
+// The profile is kept so past sessions still show who taught them; the coach can no longer log in.
+func (s *ServiceUsecase) DeleteCoach(ctx context.Context, userID, coachID int64) (*CoachResponse, error) {
+	coach, err := s.getVendorCoach(ctx, userID, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !coach.IsActive() {
+		return ToCoachResponse(coach, "Coach is already inactive"), nil
+	}
+
+	coach.Status = services.CoachStatusInactive
+	if err := s.repo.UpdateCoach(ctx, coach, time.Now()); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToCoachResponse(coach, "Coach deactivated successfully"), nil
+}
+
+// ListServiceCoaches lists the coaches assigned to one of the vendor's services
+func (s *ServiceUsecase) ListServiceCoaches(ctx context.Context, userID, serviceID int64) (*ServiceCoachesResponse, error) {
+	if _, err := s.getVendorService(ctx, userID, serviceID); err != nil {
+		return nil, err
+	}
+
+	coaches, err := s.repo.ListServiceCoaches(ctx, serviceID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToServiceCoachesResponse(coaches, ""), nil
+}
+
+// AssignCoach assigns one of the vendor's active coaches to one of their services
+func (s *ServiceUsecase) AssignCoach(ctx context.Context, userID, serviceID int64, params *AssignCoachParams) (*ServiceCoachesResponse, error) {
+	if _, err := s.getVendorService(ctx, userID, serviceID); err != nil {
+		return nil, err
+	}
+
+	coach, err := s.getVendorCoach(ctx, userID, params.CoachID)
+	if err != nil {
+		return nil, err
+	}
+	if !coach.IsActive() {
+		return nil, internal.NewBusinessRuleError("Inactive coaches cannot be assigned to services", internal.ErrInvalidState)
+	}
+
+	if err := s.repo.AssignServiceCoach(ctx, serviceID, coach.ID, params.IsPrimary); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	coaches, err := s.repo.ListServiceCoaches(ctx, serviceID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToServiceCoachesResponse(coaches, "Coach assigned successfully"), nil
+}
+
+// UnassignCoach removes a coach from one of the vendor's services
+func (s *ServiceUsecase) UnassignCoach(ctx context.Context, userID, serviceID, coachID int64) (*ServiceCoachesResponse, error) {
+	if _, err := s.getVendorService(ctx, userID, serviceID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UnassignServiceCoach(ctx, serviceID, coachID); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	coaches, err := s.repo.ListServiceCoaches(ctx, serviceID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToServiceCoachesResponse(coaches, "Coach removed from service"), nil
+}
+
+// ListSessions lists the sessions the authenticated coach teaches within a date range
+func (s *ServiceUsecase) ListSessions(ctx context.Context, userID int64, params *ListSessionsParams) (*CoachSessionsResponse, error) {
+	coachID, err := s.resolveCoachID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := params.ToCoachSessionFilter(coachID, time.Now())
+	if err := filter.Validate(); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	sessions, err := s.repo.ListCoachSessions(ctx, filter)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToCoachSessionsResponse(filter, sessions), nil
+}
+
+// GetSessionRoster lists every child booked into the class of one of the coach's sessions
+func (s *ServiceUsecase) GetSessionRoster(ctx context.Context, userID, sessionID int64) (*RosterResponse, error) {
+	coachID, err := s.resolveCoachID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.repo.GetCoachSession(ctx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Session")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if session.CoachID == nil || *session.CoachID != coachID {
+		return nil, internal.NewForbiddenError("Session is assigned to another coach")
+	}
+
+	roster, err := s.repo.ListSessionRoster(ctx, session)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToRosterResponse(session, roster), nil
+}
+
+// validateCoach checks the coach profile and the contact details mirrored on its user account
+func (s *ServiceUsecase) validateCoach(coach *services.Coach) error {
+	if err := coach.Validate(); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+
+	account := &user.User{FullName: coach.FullName, Phone: coach.Phone}
+	if err := account.ValidateFullName(); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+	if err := account.ValidatePhone(); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+
+	return nil
+}
+
+// getVendorCoach loads a coach and verifies they work for the authenticated vendor
+func (s *ServiceUsecase) getVendorCoach(ctx context.Context, userID, coachID int64) (*services.Coach, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	coach, err := s.repo.GetCoachByID(ctx, coachID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Coach")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if coach.VendorID != vendorID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	return coach, nil
+}
+
+// getVendorService loads a service and verifies it belongs to the authenticated vendor
+func (s *ServiceUsecase) getVendorService(ctx context.Context, userID, serviceID int64) (*services.Service, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := s.repo.GetVendorService(ctx, serviceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Service")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if service.VendorID != vendorID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	if service.IsArchived() {
+		return nil, internal.NewNotFoundError("Service")
+	}
+
+	return service, nil
+}
+
+// resolveVendorID finds the vendor profile of the authenticated user
+func (s *ServiceUsecase) resolveVendorID(ctx context.Context, userID int64) (int64, error) {
+	vendorID, err := s.repo.GetVendorIDByUserID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, internal.NewForbiddenError("Vendor profile not found")
+		}
+		return 0, internal.NewInternalServerError(err)
+	}
+	return vendorID, nil
+}
+
+// resolveCoachID finds the active coach profile of the authenticated user
+func (s *ServiceUsecase) resolveCoachID(ctx context.Context, userID int64) (int64, error) {
+	coachID, _, err := s.repo.GetCoachByUserID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, internal.NewForbiddenError("Coach profile not found")
+		}
+		return 0, internal.NewInternalServerError(err)
+	}
+	return coachID, nil
+}
+
+// generateTemporaryPassword creates a random password for coach accounts added without one
+func generateTemporaryPassword() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate temporary password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
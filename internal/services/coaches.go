@@ -0,0 +1,137 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// ================== Coach Management ==================
+
+// CoachStatus represents whether a coach can be assigned to services and log in
+type CoachStatus string
+
+const (
+	CoachStatusActive   CoachStatus = "active"
+	CoachStatusInactive CoachStatus = "inactive"
+)
+
+// IsValid checks if the coach status is valid
+func (s CoachStatus) IsValid() bool {
+	return s == CoachStatusActive || s == CoachStatusInactive
+}
+
+// Coach is a member of a vendor's staff, with a linked user account of role coach
+type Coach struct {
+	ID              int64
+	UserID          int64
+	VendorID        int64
+	FullName        string
+	Email           string // From the linked user account
+	Phone           string // From the linked user account
+	Bio             *string
+	ExperienceYears int
+	Education       *string
+	Certifications  []Certification // JSONB
+	Specializations []string        // JSONB
+	Photo           *string
+	IsFeatured      bool
+	Status          CoachStatus
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Validate validates coach profile fields
+func (c *Coach) Validate() error {
+	if c.VendorID <= 0 {
+		return fmt.Errorf("vendor_id is required")
+	}
+
+	if len(c.FullName) < 2 {
+		return fmt.Errorf("full_name must be at least 2 characters")
+	}
+	if len(c.FullName) > 255 {
+		return fmt.Errorf("full_name must not exceed 255 characters")
+	}
+
+	if c.ExperienceYears < 0 || c.ExperienceYears > 80 {
+		return fmt.Errorf("experience_years must be between 0 and 80")
+	}
+
+	for i, cert := range c.Certifications {
+		if cert.Name == "" {
+			return fmt.Errorf("certifications[%d].name is required", i)
+		}
+		if cert.Year != 0 && (cert.Year < 1950 || cert.Year > time.Now().Year()) {
+			return fmt.Errorf("certifications[%d].year is not a valid year", i)
+		}
+	}
+
+	for i, specialization := range c.Specializations {
+		if specialization == "" {
+			return fmt.Errorf("specializations[%d] must not be empty", i)
+		}
+	}
+
+	if !c.Status.IsValid() {
+		return fmt.Errorf("invalid status: %s", c.Status)
+	}
+
+	return nil
+}
+
+// IsActive checks if the coach can be assigned to services and schedules
+func (c *Coach) IsActive() bool {
+	return c.Status == CoachStatusActive
+}
+
+// CoachSession is a booked session as seen by the coach teaching it
+type CoachSession struct {
+	SessionID      int64
+	SessionNumber  int
+	SessionDate    time.Time
+	StartTime      string
+	EndTime        string
+	Status         SessionStatus
+	Attended       bool
+	CoachNotes     *string
+	ScheduleID     *int64
+	CoachID        *int64 // The session's coach, falling back to the coach chosen for the booking
+	BookingID      int64
+	BookingNumber  string
+	BookingStatus  BookingStatus
+	TotalSessions  int
+	ParentNotes    *string
+	ServiceID      int64
+	ServiceName    string
+	ClassType      ClassType
+	ChildID        int64
+	ChildName      string
+	ChildNickname  *string
+	ChildBirthDate time.Time
+	ChildGender    *string
+	SpecialNeeds   *string
+}
+
+// CoachSessionFilter selects the sessions of a coach within a date range, both ends included
+type CoachSessionFilter struct {
+	CoachID int64
+	From    time.Time
+	To      time.Time
+}
+
+// Validate validates the coach session filter
+func (f *CoachSessionFilter) Validate() error {
+	if f.CoachID <= 0 {
+		return fmt.Errorf("coach_id is required")
+	}
+	if f.To.Before(f.From) {
+		return fmt.Errorf("to must not be before from")
+	}
+	if f.To.Sub(f.From) > MaxCoachSessionRange {
+		return fmt.Errorf("date range must not exceed %d days", int(MaxCoachSessionRange.Hours()/24))
+	}
+	return nil
+}
+
+// MaxCoachSessionRange bounds how many days of sessions a coach can list at once
+const MaxCoachSessionRange = 31 * 24 * time.Hour
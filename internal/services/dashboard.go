@@ -0,0 +1,123 @@
+package services
+
+import "time"
+
+// ================== Vendor Dashboard ==================
+
+// DashboardPeriod holds the date ranges a vendor dashboard is computed over.
+// Weeks run Monday to Sunday; all ranges include both ends.
+type DashboardPeriod struct {
+	Now        time.Time
+	Today      time.Time
+	WeekStart  time.Time
+	WeekEnd    time.Time
+	MonthStart time.Time
+	MonthEnd   time.Time
+}
+
+// NewDashboardPeriod works out the current day, week and month
+func NewDashboardPeriod(now time.Time) DashboardPeriod {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	return DashboardPeriod{
+		Now:        now,
+		Today:      today,
+		WeekStart:  weekStart,
+		WeekEnd:    weekStart.AddDate(0, 0, 6),
+		MonthStart: monthStart,
+		MonthEnd:   monthStart.AddDate(0, 1, -1),
+	}
+}
+
+// VendorDashboard is the at-a-glance summary a vendor sees when opening the app
+type VendorDashboard struct {
+	Period          DashboardPeriod
+	Overview        DashboardOverview
+	WeekSessions    []*DashboardSession // Includes today's sessions
+	PendingBookings []*PendingBookingSummary
+	Revenue         DashboardRevenue
+	Occupancy       []*ScheduleOccupancy
+	LatestReviews   []*DashboardReview
+}
+
+// DashboardOverview holds the vendor's running totals
+type DashboardOverview struct {
+	TotalServices   int
+	ActiveCoaches   int
+	PendingBookings int
+	RatingAvg       float64
+	TotalReviews    int
+}
+
+// DashboardSession is a booked session on the vendor's calendar
+type DashboardSession struct {
+	SessionID     int64
+	BookingID     int64
+	BookingNumber string
+	ServiceName   string
+	ChildName     string
+	CoachName     *string
+	SessionDate   time.Time
+	StartTime     string
+	EndTime       string
+	Status        SessionStatus
+}
+
+// IsOn checks if the session takes place on the given day
+func (s *DashboardSession) IsOn(day time.Time) bool {
+	return s.SessionDate.Format("2006-01-02") == day.Format("2006-01-02")
+}
+
+// PendingBookingSummary is a booking awaiting the vendor's confirmation
+type PendingBookingSummary struct {
+	BookingID        int64
+	BookingNumber    string
+	ServiceName      string
+	ChildName        string
+	BookingType      BookingType
+	TotalAmount      float64
+	FirstSessionDate *time.Time
+	CreatedAt        time.Time
+}
+
+// DashboardRevenue splits the month's booking value by payment status
+type DashboardRevenue struct {
+	Paid          float64
+	Outstanding   float64
+	Refunded      float64
+	TotalBookings int
+	NewCustomers  int // Parents booking the vendor for the first time this month
+}
+
+// ScheduleOccupancy compares a schedule's seats this week against the sessions booked into them
+type ScheduleOccupancy struct {
+	ScheduleID     int64
+	ServiceID      int64
+	ServiceName    string
+	DayOfWeek      int
+	StartTime      string
+	EndTime        string
+	AvailableSlots int
+	BookedSeats    int
+}
+
+// Rate returns the share of seats taken as a percentage
+func (o *ScheduleOccupancy) Rate() float64 {
+	if o.AvailableSlots <= 0 {
+		return 0
+	}
+	return float64(o.BookedSeats) / float64(o.AvailableSlots) * 100
+}
+
+// DashboardReview is a recent review of one of the vendor's services
+type DashboardReview struct {
+	ID          int64
+	ServiceName string
+	ParentName  string
+	Rating      int
+	ReviewText  *string
+	Responded   bool
+	CreatedAt   time.Time
+}
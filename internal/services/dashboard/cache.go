@@ -0,0 +1,58 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goRedis "github.com/redis/go-redis/v9"
+)
+
+// RedisCache keeps rendered dashboards in Redis for a short time
+type RedisCache struct {
+	client goRedis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedisCache creates a dashboard cache
+func NewRedisCache(client goRedis.UniversalClient, ttl time.Duration) *RedisCache {
+	return &RedisCache{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// Get returns the cached dashboard of a vendor, or nil if there is none
+func (c *RedisCache) Get(ctx context.Context, vendorID int64) (*DashboardResponse, error) {
+	payload, err := c.client.Get(ctx, cacheKey(vendorID)).Bytes()
+	if err != nil {
+		if err == goRedis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cached dashboard: %w", err)
+	}
+
+	var resp DashboardResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode cached dashboard: %w", err)
+	}
+	return &resp, nil
+}
+
+// Set caches the dashboard of a vendor
+func (c *RedisCache) Set(ctx context.Context, vendorID int64, resp *DashboardResponse) error {
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to encode dashboard: %w", err)
+	}
+
+	if err := c.client.Set(ctx, cacheKey(vendorID), payload, c.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache dashboard: %w", err)
+	}
+	return nil
+}
+
+func cacheKey(vendorID int64) string {
+	return fmt.Sprintf("vendor_dashboard:%d", vendorID)
+}
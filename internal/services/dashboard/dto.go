@@ -0,0 +1,233 @@
+package dashboard
+
+import (
+	"math"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+// DashboardResponse is the response for the vendor dashboard
+type DashboardResponse struct {
+	Data DashboardData `json:"data"`
+}
+
+type DashboardData struct {
+	GeneratedAt     time.Time              `json:"generated_at"`
+	Overview        OverviewDTO            `json:"overview"`
+	Today           SessionsSummaryDTO     `json:"today"`
+	ThisWeek        SessionsSummaryDTO     `json:"this_week"`
+	PendingBookings []PendingBookingDTO    `json:"pending_bookings"`
+	ThisMonth       RevenueDTO             `json:"this_month"`
+	Occupancy       []ScheduleOccupancyDTO `json:"occupancy"`
+	LatestReviews   []ReviewDTO            `json:"latest_reviews"`
+}
+
+// OverviewDTO holds the vendor's running totals
+type OverviewDTO struct {
+	TotalServices   int     `json:"total_services"`
+	ActiveCoaches   int     `json:"active_coaches"`
+	PendingBookings int     `json:"pending_bookings"`
+	RatingAvg       float64 `json:"rating_avg"`
+	TotalReviews    int     `json:"total_reviews"`
+}
+
+// SessionsSummaryDTO counts and lists the sessions of a day or week
+type SessionsSummaryDTO struct {
+	From              string       `json:"from"`
+	To                string       `json:"to"`
+	TotalSessions     int          `json:"total_sessions"`
+	CompletedSessions int          `json:"completed_sessions"`
+	UpcomingSessions  int          `json:"upcoming_sessions"`
+	Sessions          []SessionDTO `json:"sessions"`
+}
+
+// SessionDTO is a session on the vendor's calendar
+type SessionDTO struct {
+	ID            int64   `json:"id"`
+	BookingID     int64   `json:"booking_id"`
+	BookingNumber string  `json:"booking_number"`
+	ServiceName   string  `json:"service_name"`
+	ChildName     string  `json:"child_name"`
+	CoachName     *string `json:"coach_name,omitempty"`
+	SessionDate   string  `json:"session_date"`
+	StartTime     string  `json:"start_time"`
+	EndTime       string  `json:"end_time"`
+	Status        string  `json:"status"`
+}
+
+// PendingBookingDTO is a booking awaiting confirmation
+type PendingBookingDTO struct {
+	ID               int64     `json:"id"`
+	BookingNumber    string    `json:"booking_number"`
+	ServiceName      string    `json:"service_name"`
+	ChildName        string    `json:"child_name"`
+	BookingType      string    `json:"booking_type"`
+	TotalAmount      float64   `json:"total_amount"`
+	FirstSessionDate *string   `json:"first_session_date,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// RevenueDTO splits the month's booking value by payment status
+type RevenueDTO struct {
+	Month         string  `json:"month"` // YYYY-MM format
+	Revenue       float64 `json:"revenue"`
+	Outstanding   float64 `json:"outstanding"`
+	Refunded      float64 `json:"refunded"`
+	TotalBookings int     `json:"total_bookings"`
+	NewCustomers  int     `json:"new_customers"`
+}
+
+// ScheduleOccupancyDTO is the share of a schedule's seats booked this week
+type ScheduleOccupancyDTO struct {
+	ScheduleID     int64   `json:"schedule_id"`
+	ServiceID      int64   `json:"service_id"`
+	ServiceName    string  `json:"service_name"`
+	DayOfWeek      int     `json:"day_of_week"`
+	DayName        string  `json:"day_name"`
+	StartTime      string  `json:"start_time"`
+	EndTime        string  `json:"end_time"`
+	AvailableSlots int     `json:"available_slots"`
+	BookedSeats    int     `json:"booked_seats"`
+	OccupancyRate  float64 `json:"occupancy_rate"` // Percentage, one decimal
+}
+
+// ReviewDTO is a recent review
+type ReviewDTO struct {
+	ID          int64     `json:"id"`
+	ServiceName string    `json:"service_name"`
+	ParentName  string    `json:"parent_name"`
+	Rating      int       `json:"rating"`
+	ReviewText  *string   `json:"review_text,omitempty"`
+	Responded   bool      `json:"responded"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ToDashboardResponse converts the vendor dashboard to response
+func ToDashboardResponse(d *services.VendorDashboard) *DashboardResponse {
+	period := d.Period
+
+	todaySessions := make([]*services.DashboardSession, 0)
+	for _, s := range d.WeekSessions {
+		if s.IsOn(period.Today) {
+			todaySessions = append(todaySessions, s)
+		}
+	}
+
+	pending := make([]PendingBookingDTO, 0, len(d.PendingBookings))
+	for _, b := range d.PendingBookings {
+		dto := PendingBookingDTO{
+			ID:            b.BookingID,
+			BookingNumber: b.BookingNumber,
+			ServiceName:   b.ServiceName,
+			ChildName:     b.ChildName,
+			BookingType:   string(b.BookingType),
+			TotalAmount:   b.TotalAmount,
+			CreatedAt:     b.CreatedAt,
+		}
+		if b.FirstSessionDate != nil {
+			date := b.FirstSessionDate.Format("2006-01-02")
+			dto.FirstSessionDate = &date
+		}
+		pending = append(pending, dto)
+	}
+
+	occupancy := make([]ScheduleOccupancyDTO, 0, len(d.Occupancy))
+	for _, o := range d.Occupancy {
+		occupancy = append(occupancy, ScheduleOccupancyDTO{
+			ScheduleID:     o.ScheduleID,
+			ServiceID:      o.ServiceID,
+			ServiceName:    o.ServiceName,
+			DayOfWeek:      o.DayOfWeek,
+			DayName:        services.GetDayName(o.DayOfWeek),
+			StartTime:      o.StartTime,
+			EndTime:        o.EndTime,
+			AvailableSlots: o.AvailableSlots,
+			BookedSeats:    o.BookedSeats,
+			OccupancyRate:  math.Round(o.Rate()*10) / 10,
+		})
+	}
+
+	reviews := make([]ReviewDTO, 0, len(d.LatestReviews))
+	for _, r := range d.LatestReviews {
+		reviews = append(reviews, ReviewDTO{
+			ID:          r.ID,
+			ServiceName: r.ServiceName,
+			ParentName:  r.ParentName,
+			Rating:      r.Rating,
+			ReviewText:  r.ReviewText,
+			Responded:   r.Responded,
+			CreatedAt:   r.CreatedAt,
+		})
+	}
+
+	return &DashboardResponse{
+		Data: DashboardData{
+			GeneratedAt: period.Now,
+			Overview: OverviewDTO{
+				TotalServices:   d.Overview.TotalServices,
+				ActiveCoaches:   d.Overview.ActiveCoaches,
+				PendingBookings: d.Overview.PendingBookings,
+				RatingAvg:       d.Overview.RatingAvg,
+				TotalReviews:    d.Overview.TotalReviews,
+			},
+			Today:           toSessionsSummary(period.Today, period.Today, todaySessions, period.Now),
+			ThisWeek:        toSessionsSummary(period.WeekStart, period.WeekEnd, d.WeekSessions, period.Now),
+			PendingBookings: pending,
+			ThisMonth: RevenueDTO{
+				Month:         period.MonthStart.Format("2006-01"),
+				Revenue:       d.Revenue.Paid,
+				Outstanding:   d.Revenue.Outstanding,
+				Refunded:      d.Revenue.Refunded,
+				TotalBookings: d.Revenue.TotalBookings,
+				NewCustomers:  d.Revenue.NewCustomers,
+			},
+			Occupancy:     occupancy,
+			LatestReviews: reviews,
+		},
+	}
+}
+
+// toSessionsSummary counts the sessions of a range; upcoming ones are still scheduled and have not started yet
+func toSessionsSummary(from, to time.Time, sessions []*services.DashboardSession, now time.Time) SessionsSummaryDTO {
+	summary := SessionsSummaryDTO{
+		From:          from.Format("2006-01-02"),
+		To:            to.Format("2006-01-02"),
+		TotalSessions: len(sessions),
+		Sessions:      make([]SessionDTO, 0, len(sessions)),
+	}
+
+	for _, s := range sessions {
+		switch s.Status {
+		case services.SessionStatusCompleted:
+			summary.CompletedSessions++
+		case services.SessionStatusScheduled:
+			if startsAfter(s, now) {
+				summary.UpcomingSessions++
+			}
+		}
+
+		summary.Sessions = append(summary.Sessions, SessionDTO{
+			ID:            s.SessionID,
+			BookingID:     s.BookingID,
+			BookingNumber: s.BookingNumber,
+			ServiceName:   s.ServiceName,
+			ChildName:     s.ChildName,
+			CoachName:     s.CoachName,
+			SessionDate:   s.SessionDate.Format("2006-01-02"),
+			StartTime:     s.StartTime,
+			EndTime:       s.EndTime,
+			Status:        string(s.Status),
+		})
+	}
+
+	return summary
+}
+
+func startsAfter(s *services.DashboardSession, now time.Time) bool {
+	start, err := time.ParseInLocation("2006-01-02 15:04:05", s.SessionDate.Format("2006-01-02")+" "+s.StartTime, now.Location())
+	if err != nil {
+		return true
+	}
+	return start.After(now)
+}
@@ -0,0 +1,40 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/go-chi/render"
+)
+
+// Handler handles HTTP requests for the dashboard capability
+type Handler struct {
+	service *ServiceUsecase
+}
+
+// NewHandler creates a new dashboard handler
+func NewHandler(service *ServiceUsecase) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetDashboard handles GET /vendor/dashboard
+func (h *Handler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	response, err := h.service.GetDashboard(ctx, userID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
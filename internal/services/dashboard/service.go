@@ -0,0 +1,73 @@
+package dashboard
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+// Repository defines the data access interface for the dashboard capability
+type Repository interface {
+	GetVendorIDByUserID(ctx context.Context, userID int64) (int64, error)
+	GetVendorDashboard(ctx context.Context, vendorID int64, period services.DashboardPeriod) (*services.VendorDashboard, error)
+}
+
+// Cache stores rendered dashboards between requests
+type Cache interface {
+	Get(ctx context.Context, vendorID int64) (*DashboardResponse, error)
+	Set(ctx context.Context, vendorID int64, resp *DashboardResponse) error
+}
+
+// ServiceUsecase handles the vendor dashboard
+type ServiceUsecase struct {
+	repo  Repository
+	cache Cache
+}
+
+// NewService creates a new dashboard service. A nil cache computes the dashboard on every request.
+func NewService(repo Repository, cache Cache) *ServiceUsecase {
+	return &ServiceUsecase{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// GetDashboard returns the dashboard of the authenticated vendor.
+// Cache failures are logged and the dashboard is computed from the database instead.
+func (s *ServiceUsecase) GetDashboard(ctx context.Context, userID int64) (*DashboardResponse, error) {
+	vendorID, err := s.repo.GetVendorIDByUserID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewForbiddenError("Vendor profile not found")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if s.cache != nil {
+		cached, err := s.cache.Get(ctx, vendorID)
+		if err != nil {
+			slog.WarnContext(ctx, "Dashboard cache unavailable", slog.Int64("vendor_id", vendorID), slog.Any("error", err))
+		}
+		if cached != nil {
+			return cached, nil
+		}
+	}
+
+	dashboard, err := s.repo.GetVendorDashboard(ctx, vendorID, services.NewDashboardPeriod(time.Now()))
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+	resp := ToDashboardResponse(dashboard)
+
+	if s.cache != nil {
+		if err := s.cache.Set(ctx, vendorID, resp); err != nil {
+			slog.WarnContext(ctx, "Failed to cache dashboard", slog.Int64("vendor_id", vendorID), slog.Any("error", err))
+		}
+	}
+
+	return resp, nil
+}
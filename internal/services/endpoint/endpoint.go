@@ -1,18 +1,38 @@
 package endpoint
 
 import (
+	"net/http"
+
+	"github.com/frahmantamala/jadiles/internal"
+	authpkg "github.com/frahmantamala/jadiles/internal/auth"
+	"github.com/frahmantamala/jadiles/internal/payment"
+	paymentPostgresql "github.com/frahmantamala/jadiles/internal/payment/postgresql"
+	"github.com/frahmantamala/jadiles/internal/services"
 	"github.com/frahmantamala/jadiles/internal/services/booking"
+	"github.com/frahmantamala/jadiles/internal/services/catalog"
+	"github.com/frahmantamala/jadiles/internal/services/coach"
+	"github.com/frahmantamala/jadiles/internal/services/dashboard"
 	"github.com/frahmantamala/jadiles/internal/services/detail"
 	"github.com/frahmantamala/jadiles/internal/services/postgresql"
 	"github.com/frahmantamala/jadiles/internal/services/review"
 	"github.com/frahmantamala/jadiles/internal/services/schedule"
 	"github.com/frahmantamala/jadiles/internal/services/search"
+	"github.com/frahmantamala/jadiles/internal/services/waitlist"
 	"github.com/go-chi/chi/v5"
+	goRedis "github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 // RegisterServiceRoutes registers service-related routes
-func RegisterServiceRoutes(r chi.Router, db *gorm.DB) error {
+func RegisterServiceRoutes(
+	r chi.Router,
+	db *gorm.DB,
+	redisClient goRedis.UniversalClient,
+	jwtAuth *authpkg.JWTAuthentication,
+	gateway payment.Gateway,
+	idempotent func(http.Handler) http.Handler,
+	config internal.Config,
+) error {
 	// Initialize repository
 	repo := postgresql.NewRepository(db)
 
@@ -33,9 +53,31 @@ func RegisterServiceRoutes(r chi.Router, db *gorm.DB) error {
 	reviewHandler := review.NewHandler(reviewSvc)
 
 	// Initialize booking capability
-	bookingSvc := booking.NewService(repo)
+	holdPolicy := services.NewHoldPolicy(config.Booking.Hold)
+	refunder := payment.NewService(paymentPostgresql.NewPaymentRepository(db), gateway, config.Payment, holdPolicy)
+	bookingSvc := booking.NewService(repo, services.NewCancellationPolicy(config.Booking.Cancellation), refunder)
 	bookingHandler := booking.NewHandler(bookingSvc)
 
+	// Initialize vendor catalog capability
+	catalogSvc := catalog.NewService(repo)
+	catalogHandler := catalog.NewHandler(catalogSvc)
+
+	// Initialize coach capability
+	coachSvc := coach.NewService(repo, authpkg.NewPasswordManager())
+	coachHandler := coach.NewHandler(coachSvc)
+
+	// Initialize vendor dashboard capability, cached briefly as vendors reopen it constantly
+	var dashboardCache dashboard.Cache
+	if redisClient != nil && config.Dashboard.CacheTTL > 0 {
+		dashboardCache = dashboard.NewRedisCache(redisClient, config.Dashboard.CacheTTL)
+	}
+	dashboardSvc := dashboard.NewService(repo, dashboardCache)
+	dashboardHandler := dashboard.NewHandler(dashboardSvc)
+
+	// Initialize waitlist capability
+	waitlistSvc := waitlist.NewService(repo)
+	waitlistHandler := waitlist.NewHandler(waitlistSvc)
+
 	// Public routes (no authentication required)
 	r.Get("/services/search", searchHandler.SearchServices)
 	r.Get("/categories", searchHandler.GetCategories)
@@ -43,9 +85,75 @@ func RegisterServiceRoutes(r chi.Router, db *gorm.DB) error {
 	r.Get("/services/{service_id}/availability", scheduleHandler.GetServiceAvailability)
 	r.Get("/services/{service_id}/reviews", reviewHandler.GetServiceReviews)
 
-	// Booking routes (require authentication - TODO: add auth middleware)
-	r.Post("/bookings", bookingHandler.CreateBooking)
-	r.Get("/bookings/{booking_id}", bookingHandler.GetBooking)
+	// Booking routes (require authentication)
+	r.Group(func(r chi.Router) {
+		r.Use(jwtAuth.Authenticator)
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtAuth.RequireRole("parent"))
+
+			r.With(idempotent).Post("/bookings", bookingHandler.CreateBooking)
+			r.Get("/bookings", bookingHandler.ListBookings)
+			r.Get("/bookings/{booking_id}", bookingHandler.GetBooking)
+			r.Get("/bookings/{booking_id}/reschedules", bookingHandler.GetSessionReschedules)
+			r.Post("/bookings/{booking_id}/sessions/{session_id}/reschedule", bookingHandler.RescheduleSession)
+
+			r.Post("/waitlist", waitlistHandler.JoinWaitlist)
+			r.Get("/waitlist", waitlistHandler.ListWaitlist)
+			r.Delete("/waitlist/{entry_id}", waitlistHandler.LeaveWaitlist)
+
+			r.Post("/reviews", reviewHandler.CreateReview)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtAuth.RequireRole("vendor"))
+
+			r.Get("/vendor/dashboard", dashboardHandler.GetDashboard)
+
+			r.Post("/vendor/reviews/{review_id}/respond", reviewHandler.RespondToReview)
+
+			r.Get("/vendor/bookings", bookingHandler.ListVendorBookings)
+			r.Post("/vendor/bookings/{booking_id}/confirm", bookingHandler.ConfirmBooking)
+			r.Post("/vendor/bookings/{booking_id}/reject", bookingHandler.RejectBooking)
+
+			r.Get("/vendor/services", catalogHandler.ListServices)
+			r.Post("/vendor/services", catalogHandler.CreateService)
+			r.Put("/vendor/services/{id}", catalogHandler.UpdateService)
+			r.Delete("/vendor/services/{id}", catalogHandler.DeleteService)
+			r.Post("/vendor/services/{service_id}/schedules", catalogHandler.AddSchedule)
+			r.Put("/vendor/services/{service_id}/schedules/{schedule_id}", catalogHandler.UpdateSchedule)
+			r.Post("/vendor/schedule-exceptions", catalogHandler.AddScheduleException)
+
+			r.Get("/vendor/coaches", coachHandler.ListCoaches)
+			r.Post("/vendor/coaches", coachHandler.AddCoach)
+			r.Get("/vendor/coaches/{coach_id}", coachHandler.GetCoach)
+			r.Put("/vendor/coaches/{coach_id}", coachHandler.UpdateCoach)
+			r.Delete("/vendor/coaches/{coach_id}", coachHandler.DeleteCoach)
+			r.Get("/vendor/services/{service_id}/coaches", coachHandler.ListServiceCoaches)
+			r.Post("/vendor/services/{service_id}/coaches", coachHandler.AssignCoach)
+			r.Delete("/vendor/services/{service_id}/coaches/{coach_id}", coachHandler.UnassignCoach)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtAuth.RequireRole("coach"))
+
+			r.Get("/coach/sessions", coachHandler.ListSessions)
+			r.Get("/coach/sessions/{session_id}/roster", coachHandler.GetSessionRoster)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtAuth.RequireRole("vendor", "coach"))
+
+			r.Post("/vendor/sessions/{session_id}/complete", bookingHandler.CompleteSession)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtAuth.RequireRole("parent", "vendor"))
+
+			r.Post("/bookings/{booking_id}/cancel", bookingHandler.CancelBooking)
+			r.Post("/reviews/{review_id}/report", reviewHandler.ReportReview)
+		})
+	})
 
 	return nil
 }
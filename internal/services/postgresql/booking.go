@@ -8,6 +8,7 @@ import (
 
 	"github.com/frahmantamala/jadiles/internal"
 	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/payment"
 	"github.com/frahmantamala/jadiles/internal/services"
 	"github.com/frahmantamala/jadiles/internal/services/booking"
 	"gorm.io/gorm"
@@ -31,7 +32,12 @@ func (r *Repository) CreateBookingWithTransaction(ctx context.Context, req *serv
 
 		// 2. Get service with vendor info
 		var service datamodel.Services
-		if err := tx.Where("id = ? AND status = ?", req.ServiceID, "active").First(&service).Error; err != nil {
+		if err := tx.Table("services s").
+			Select("s.*").
+			Joins("INNER JOIN vendors v ON v.id = s.vendor_id").
+			Where("s.id = ?", req.ServiceID).
+			Where(visibleServiceCondition).
+			Take(&service).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return internal.NewNotFoundError("Service not found or inactive")
 			}
@@ -39,7 +45,8 @@ func (r *Repository) CreateBookingWithTransaction(ctx context.Context, req *serv
 		}
 
 		// 3. Check and reserve slots with pessimistic locking
-		if err := checkAndReserveSlots(tx, req.SessionDates); err != nil {
+		now := time.Now()
+		if err := checkAndReserveSlots(tx, req.SessionDates, req.ChildID, now); err != nil {
 			return err
 		}
 
@@ -63,6 +70,7 @@ func (r *Repository) CreateBookingWithTransaction(ctx context.Context, req *serv
 			TotalSessions:  req.BookingType.GetSessionCount(),
 			TotalAmount:    totalAmount,
 			Status:         string(services.BookingStatusPending),
+			PaymentStatus:  string(services.PaymentStatusUnpaid),
 			PreferredCoach: req.PreferredCoach,
 			ParentNotes:    req.ParentNotes,
 			Version:        1, // Initial version for optimistic locking
@@ -78,7 +86,18 @@ func (r *Repository) CreateBookingWithTransaction(ctx context.Context, req *serv
 			return err
 		}
 
-		// 8. Build domain booking object
+		// 8. Start the status timeline
+		created := services.NewBookingCreated(bookingData.ID, services.BookingStatusPending, services.ActorParent, &req.ParentID, bookingData.CreatedAt)
+		if err := recordStatusChange(tx, created); err != nil {
+			return err
+		}
+
+		// 9. Take the child off the waitlists of the booked slots, consuming any seat offered to them
+		if err := claimWaitlistEntries(tx, bookingData.ID, req.ChildID, req.SessionDates, now); err != nil {
+			return err
+		}
+
+		// 10. Build domain booking object
 		booking = &services.Booking{
 			ID:             bookingData.ID,
 			BookingNumber:  bookingData.BookingNumber,
@@ -90,6 +109,7 @@ func (r *Repository) CreateBookingWithTransaction(ctx context.Context, req *serv
 			TotalSessions:  bookingData.TotalSessions,
 			TotalAmount:    bookingData.TotalAmount,
 			Status:         services.BookingStatus(bookingData.Status),
+			PaymentStatus:  services.PaymentStatus(bookingData.PaymentStatus),
 			PreferredCoach: bookingData.PreferredCoach,
 			ParentNotes:    bookingData.ParentNotes,
 			Version:        bookingData.Version,
@@ -108,8 +128,10 @@ func (r *Repository) CreateBookingWithTransaction(ctx context.Context, req *serv
 	return booking, nil
 }
 
-// checkAndReserveSlots verifies slot availability with row-level locking
-func checkAndReserveSlots(tx *gorm.DB, sessionDates []services.BookingSessionRequest) error {
+// checkAndReserveSlots verifies slot availability for a child with row-level locking.
+// Other children on the waitlist are ahead in line: their live offers count as taken seats, and so do their
+// waiting entries unless the child holds an offer for the slot.
+func checkAndReserveSlots(tx *gorm.DB, sessionDates []services.BookingSessionRequest, childID int64, now time.Time) error {
 	for i, session := range sessionDates {
 		var schedule datamodel.Schedule
 
@@ -128,22 +150,26 @@ func checkAndReserveSlots(tx *gorm.DB, sessionDates []services.BookingSessionReq
 			return internal.NewInternalServerError(err)
 		}
 
-		// Count existing bookings for this schedule and date
-		var bookedCount int64
-		countQuery := `
-			SELECT COUNT(*)
-			FROM booking_sessions
-			WHERE schedule_id = ?
-			  AND session_date = ?
-			  AND status NOT IN ('cancelled', 'no_show')
-		`
+		// Reject days the vendor has closed
+		closed, err := isClosedOn(tx, session.ScheduleID, schedule.ServiceID, session.SessionDate)
+		if err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if closed {
+			return internal.NewConflictError(
+				fmt.Sprintf("Session %d: %s is closed", i+1, session.SessionDate.Format("2006-01-02")),
+				internal.ErrConflict,
+			)
+		}
 
-		if err := tx.Raw(countQuery, session.ScheduleID, session.SessionDate).Scan(&bookedCount).Error; err != nil {
+		// Count the seats taken by bookings and by other children on the waitlist
+		seats, err := countSlotSeats(tx, session.ScheduleID, session.SessionDate, schedule.AvailableSlots, childID, now)
+		if err != nil {
 			return internal.NewInternalServerError(err)
 		}
 
 		// Check if slots are available
-		availableSlots := schedule.AvailableSlots - int(bookedCount)
+		availableSlots := seats.Available()
 		if availableSlots <= 0 {
 			return internal.NewConflictError(
 				fmt.Sprintf("Session %d: no available slots for %s", i+1, session.SessionDate.Format("2006-01-02")),
@@ -159,7 +185,7 @@ func checkAndReserveSlots(tx *gorm.DB, sessionDates []services.BookingSessionReq
 func createBookingSessions(tx *gorm.DB, bookingID int64, sessionDates []services.BookingSessionRequest) ([]*services.BookingSession, error) {
 	sessions := make([]*services.BookingSession, 0, len(sessionDates))
 
-	for _, sessionReq := range sessionDates {
+	for i, sessionReq := range sessionDates {
 		// Get schedule details for times
 		var schedule datamodel.Schedule
 		if err := tx.Where("id = ?", sessionReq.ScheduleID).First(&schedule).Error; err != nil {
@@ -170,11 +196,14 @@ func createBookingSessions(tx *gorm.DB, bookingID int64, sessionDates []services
 		}
 
 		sessionData := &datamodel.BookingSession{
-			BookingID:   bookingID,
-			ScheduleID:  sessionReq.ScheduleID,
-			SessionDate: sessionReq.SessionDate,
-			Status:      string(services.SessionStatusScheduled),
-			CoachID:     schedule.CoachID,
+			BookingID:     bookingID,
+			ScheduleID:    sessionReq.ScheduleID,
+			SessionNumber: i + 1,
+			SessionDate:   sessionReq.SessionDate,
+			StartTime:     schedule.StartTime,
+			EndTime:       schedule.EndTime,
+			Status:        string(services.SessionStatusScheduled),
+			CoachID:       schedule.CoachID,
 		}
 
 		if err := tx.Create(sessionData).Error; err != nil {
@@ -182,16 +211,17 @@ func createBookingSessions(tx *gorm.DB, bookingID int64, sessionDates []services
 		}
 
 		sessions = append(sessions, &services.BookingSession{
-			ID:          sessionData.ID,
-			BookingID:   sessionData.BookingID,
-			ScheduleID:  sessionData.ScheduleID,
-			SessionDate: sessionData.SessionDate,
-			StartTime:   schedule.StartTime,
-			EndTime:     schedule.EndTime,
-			Status:      services.SessionStatus(sessionData.Status),
-			CoachID:     sessionData.CoachID,
-			CreatedAt:   sessionData.CreatedAt,
-			UpdatedAt:   sessionData.UpdatedAt,
+			ID:            sessionData.ID,
+			BookingID:     sessionData.BookingID,
+			ScheduleID:    sessionData.ScheduleID,
+			SessionNumber: sessionData.SessionNumber,
+			SessionDate:   sessionData.SessionDate,
+			StartTime:     schedule.StartTime,
+			EndTime:       schedule.EndTime,
+			Status:        services.SessionStatus(sessionData.Status),
+			CoachID:       sessionData.CoachID,
+			CreatedAt:     sessionData.CreatedAt,
+			UpdatedAt:     sessionData.UpdatedAt,
 		})
 	}
 
@@ -290,53 +320,158 @@ func (r *Repository) GetBookingByID(ctx context.Context, bookingID int64) (*serv
 
 	// Get sessions
 	var sessionsData []datamodel.BookingSession
-	if err := r.db.WithContext(ctx).Where("booking_id = ?", bookingID).Find(&sessionsData).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("session_date, start_time").
+		Find(&sessionsData).Error; err != nil {
 		return nil, err
 	}
 
 	// Convert to domain models
 	sessions := make([]*services.BookingSession, 0, len(sessionsData))
 	for _, sd := range sessionsData {
-		// Get schedule for times
-		var schedule datamodel.Schedule
-		r.db.WithContext(ctx).Where("id = ?", sd.ScheduleID).First(&schedule)
-
 		sessions = append(sessions, &services.BookingSession{
-			ID:          sd.ID,
-			BookingID:   sd.BookingID,
-			ScheduleID:  sd.ScheduleID,
-			SessionDate: sd.SessionDate,
-			StartTime:   schedule.StartTime,
-			EndTime:     schedule.EndTime,
-			Status:      services.SessionStatus(sd.Status),
-			CoachID:     sd.CoachID,
-			CreatedAt:   sd.CreatedAt,
-			UpdatedAt:   sd.UpdatedAt,
+			ID:            sd.ID,
+			BookingID:     sd.BookingID,
+			ScheduleID:    sd.ScheduleID,
+			SessionNumber: sd.SessionNumber,
+			SessionDate:   sd.SessionDate,
+			StartTime:     sd.StartTime,
+			EndTime:       sd.EndTime,
+			Status:        services.SessionStatus(sd.Status),
+			CoachID:       sd.CoachID,
+			Attended:      sd.Attended,
+			CoachNotes:    sd.CoachNotes,
+			Rating:        sd.Rating,
+			CompletedAt:   sd.CompletedAt,
+			CreatedAt:     sd.CreatedAt,
+			UpdatedAt:     sd.UpdatedAt,
 		})
 	}
 
-	booking := &services.Booking{
-		ID:             bookingData.ID,
-		BookingNumber:  bookingData.BookingNumber,
-		ParentID:       bookingData.ParentID,
-		ChildID:        bookingData.ChildID,
-		ServiceID:      bookingData.ServiceID,
-		VendorID:       bookingData.VendorID,
-		BookingType:    services.BookingType(bookingData.BookingType),
-		TotalSessions:  bookingData.TotalSessions,
-		TotalAmount:    bookingData.TotalAmount,
-		Status:         services.BookingStatus(bookingData.Status),
-		PreferredCoach: bookingData.PreferredCoach,
-		ParentNotes:    bookingData.ParentNotes,
-		Version:        bookingData.Version,
-		CreatedAt:      bookingData.CreatedAt,
-		UpdatedAt:      bookingData.UpdatedAt,
-		Sessions:       sessions,
-	}
+	booking := toDomainBooking(&bookingData)
+	booking.Sessions = sessions
 
 	return booking, nil
 }
 
+// CancelBookingWithTransaction cancels a booking and its remaining sessions atomically.
+// Cancelled sessions are no longer counted by checkAndReserveSlots, which frees their slots.
+// A refund owed to the parent is queued in the same transaction, so it is retried until it goes through.
+// Uses optimistic locking on the booking version to reject concurrent state changes.
+func (r *Repository) CancelBookingWithTransaction(ctx context.Context, booking *services.Booking, cancellation *services.BookingCancellation) error {
+	change, err := services.NewBookingStatusChange(
+		booking.ID, booking.Status, services.BookingStatusCancelled,
+		services.ActorType(cancellation.CancelledBy), cancellation.CancelledByUserID,
+		cancellation.Reason, cancellation.CancelledAt,
+	)
+	if err != nil {
+		return internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Move booking to cancelled if nobody changed it in the meantime
+		result := tx.Model(&datamodel.Booking{}).
+			Where("id = ? AND version = ? AND status = ?", booking.ID, booking.Version, string(booking.Status)).
+			Updates(map[string]interface{}{
+				"status":              string(services.BookingStatusCancelled),
+				"cancellation_reason": cancellation.Reason,
+				"cancelled_by":        string(cancellation.CancelledBy),
+				"cancelled_at":        cancellation.CancelledAt,
+				"refund_amount":       cancellation.RefundAmount,
+				"version":             booking.Version + 1,
+				"updated_at":          cancellation.CancelledAt,
+			})
+		if result.Error != nil {
+			return internal.NewInternalServerError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return internal.ErrOptimisticLock
+		}
+
+		// 2. Cancel sessions that have not taken place yet to release their slots
+		if err := tx.Model(&datamodel.BookingSession{}).
+			Where("booking_id = ? AND status = ?", booking.ID, string(services.SessionStatusScheduled)).
+			Updates(map[string]interface{}{
+				"status":     string(services.SessionStatusCancelled),
+				"updated_at": cancellation.CancelledAt,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 3. Record who cancelled the booking
+		if err := recordStatusChange(tx, change); err != nil {
+			return err
+		}
+
+		// 4. Queue the refund of the settled payment
+		if cancellation.RefundAmount > 0 {
+			refundID, err := queueRefund(tx, booking.ID, cancellation.RefundAmount, cancellation.Reason, cancellation.CancelledAt)
+			if err != nil {
+				return err
+			}
+			cancellation.RefundID = refundID
+		}
+
+		booking.Status = services.BookingStatusCancelled
+		booking.Version++
+		return nil
+	})
+}
+
+// queueRefund queues a refund of the booking's settled payment for the payment service to send
+func queueRefund(tx *gorm.DB, bookingID int64, amount float64, reason string, at time.Time) (int64, error) {
+	var paid datamodel.Payment
+	if err := tx.Select("id").
+		Where("booking_id = ? AND status = ?", bookingID, string(payment.StatusSuccess)).
+		Order("paid_at DESC").
+		First(&paid).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, internal.NewBusinessRuleError("Booking has no settled payment to refund", internal.ErrInvalidState)
+		}
+		return 0, internal.NewInternalServerError(err)
+	}
+
+	refund := &datamodel.Refund{
+		BookingID:     bookingID,
+		PaymentID:     paid.ID,
+		Amount:        amount,
+		Reason:        reason,
+		Status:        string(payment.RefundStatusPending),
+		NextAttemptAt: at,
+		CreatedAt:     at,
+		UpdatedAt:     at,
+	}
+	if err := tx.Create(refund).Error; err != nil {
+		return 0, internal.NewInternalServerError(err)
+	}
+	return refund.ID, nil
+}
+
+// GetVendorIDByUserID retrieves the vendor owned by a user
+func (r *Repository) GetVendorIDByUserID(ctx context.Context, userID int64) (int64, error) {
+	var vendor datamodel.Vendor
+	if err := r.db.WithContext(ctx).Select("id").Where("user_id = ?", userID).First(&vendor).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, sql.ErrNoRows
+		}
+		return 0, err
+	}
+	return vendor.ID, nil
+}
+
+// IsEmailVerified checks if the user verified their email address
+func (r *Repository) IsEmailVerified(ctx context.Context, userID int64) (bool, error) {
+	var user datamodel.User
+	if err := r.db.WithContext(ctx).Select("email_verified").Where("id = ?", userID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, sql.ErrNoRows
+		}
+		return false, err
+	}
+	return user.EmailVerified, nil
+}
+
 // GetBookingEnrichment fetches service, child, and vendor names for booking detail
 func (r *Repository) GetBookingEnrichment(ctx context.Context, serviceID, childID, vendorID int64) (*booking.BookingEnrichment, error) {
 	enrichment := &booking.BookingEnrichment{}
@@ -348,8 +483,8 @@ func (r *Repository) GetBookingEnrichment(ctx context.Context, serviceID, childI
 	}
 	err := r.db.WithContext(ctx).
 		Table("services").
-		Select("services.name, categories.name as category_name").
-		Joins("LEFT JOIN categories ON categories.id = services.category_id").
+		Select("services.name, service_categories.name as category_name").
+		Joins("LEFT JOIN service_categories ON service_categories.id = services.category_id").
 		Where("services.id = ?", serviceID).
 		Scan(&serviceData).Error
 
@@ -372,3 +507,952 @@ func (r *Repository) GetBookingEnrichment(ctx context.Context, serviceID, childI
 
 	return enrichment, nil
 }
+
+// GetReschedulePolicy retrieves the vendor's reschedule rules
+func (r *Repository) GetReschedulePolicy(ctx context.Context, vendorID int64) (*services.ReschedulePolicy, error) {
+	var vendor datamodel.Vendor
+	if err := r.db.WithContext(ctx).
+		Select("reschedule_cutoff_hours", "max_reschedules_per_booking").
+		Where("id = ?", vendorID).
+		First(&vendor).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+
+	return &services.ReschedulePolicy{
+		Cutoff:        time.Duration(vendor.RescheduleCutoffHours) * time.Hour,
+		MaxPerBooking: vendor.MaxReschedulesPerBooking,
+	}, nil
+}
+
+// RescheduleSessionWithTransaction moves a booked session to another schedule slot atomically.
+// The booking row is locked first so reschedules of its sessions count against the limit one at a time,
+// then the target slot goes through the same SELECT FOR UPDATE capacity check as booking creation.
+// Moving the row releases the old slot.
+func (r *Repository) RescheduleSessionWithTransaction(
+	ctx context.Context,
+	booking *services.Booking,
+	req *services.RescheduleSessionRequest,
+	policy *services.ReschedulePolicy,
+	now time.Time,
+) (*services.RescheduleResult, error) {
+	var result *services.RescheduleResult
+
+	txErr := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the booking and verify it can still be rescheduled
+		var bookingData datamodel.Booking
+		if err := tx.Raw(`
+			SELECT * FROM bookings WHERE id = ? FOR UPDATE
+		`, req.BookingID).Scan(&bookingData).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if bookingData.ID == 0 {
+			return internal.NewNotFoundError("Booking")
+		}
+		if locked := toDomainBooking(&bookingData); !locked.CanBeRescheduled() {
+			return internal.NewBusinessRuleError(
+				fmt.Sprintf("Booking with status %s cannot be rescheduled", locked.Status),
+				internal.ErrInvalidState,
+			)
+		}
+
+		// 2. Lock the session to move
+		var session datamodel.BookingSession
+		if err := tx.Raw(`
+			SELECT id, booking_id, schedule_id, session_date, status, coach_id, created_at, updated_at
+			FROM booking_sessions
+			WHERE id = ? AND booking_id = ?
+			FOR UPDATE
+		`, req.SessionID, req.BookingID).Scan(&session).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if session.ID == 0 {
+			return internal.NewNotFoundError("Booking session")
+		}
+		if session.Status != string(services.SessionStatusScheduled) {
+			return internal.NewBusinessRuleError(
+				fmt.Sprintf("Session with status %s cannot be rescheduled", session.Status),
+				internal.ErrInvalidState,
+			)
+		}
+
+		// 3. Enforce the per-booking reschedule limit
+		var rescheduleCount int64
+		if err := tx.Model(&datamodel.BookingSessionReschedule{}).
+			Where("booking_id = ?", req.BookingID).
+			Count(&rescheduleCount).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if err := policy.CheckLimit(int(rescheduleCount)); err != nil {
+			return internal.NewBusinessRuleError(err.Error(), internal.ErrBusinessRule)
+		}
+
+		if session.ScheduleID == req.NewScheduleID && sameDate(session.SessionDate, req.NewDate) {
+			return internal.NewValidationError("Session is already booked in this slot")
+		}
+
+		// 4. Lock the target schedule and verify it has capacity
+		newSlot := []services.BookingSessionRequest{{ScheduleID: req.NewScheduleID, SessionDate: req.NewDate}}
+		if err := checkAndReserveSlots(tx, newSlot, booking.ChildID, now); err != nil {
+			return err
+		}
+
+		var schedule datamodel.Schedule
+		if err := tx.Where("id = ? AND is_active = ?", req.NewScheduleID, true).First(&schedule).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return internal.NewNotFoundError("Schedule")
+			}
+			return internal.NewInternalServerError(err)
+		}
+		if schedule.ServiceID != booking.ServiceID {
+			return internal.NewValidationError("Schedule does not belong to the booked service")
+		}
+		if int(req.NewDate.Weekday()) != schedule.DayOfWeek {
+			return internal.NewValidationError(
+				fmt.Sprintf("Schedule runs on %s, not on %s", services.GetDayName(schedule.DayOfWeek), req.NewDate.Weekday()),
+			)
+		}
+
+		newSession := &services.BookingSession{
+			ID:          session.ID,
+			BookingID:   session.BookingID,
+			ScheduleID:  schedule.ID,
+			SessionDate: req.NewDate,
+			StartTime:   schedule.StartTime,
+			EndTime:     schedule.EndTime,
+			Status:      services.SessionStatusScheduled,
+			CoachID:     schedule.CoachID,
+			CreatedAt:   session.CreatedAt,
+			UpdatedAt:   now,
+		}
+		if err := policy.CheckCutoff(newSession.StartsAt(), now); err != nil {
+			return internal.NewBusinessRuleError(err.Error(), internal.ErrBusinessRule)
+		}
+
+		// 5. Move the session to the new slot
+		if err := tx.Model(&datamodel.BookingSession{}).
+			Where("id = ?", session.ID).
+			Updates(map[string]interface{}{
+				"schedule_id":  schedule.ID,
+				"session_date": req.NewDate,
+				"start_time":   schedule.StartTime,
+				"end_time":     schedule.EndTime,
+				"coach_id":     schedule.CoachID,
+				"updated_at":   now,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 6. Record the move in the reschedule history
+		history := &datamodel.BookingSessionReschedule{
+			BookingID:        req.BookingID,
+			BookingSessionID: session.ID,
+			FromScheduleID:   session.ScheduleID,
+			FromSessionDate:  session.SessionDate,
+			ToScheduleID:     schedule.ID,
+			ToSessionDate:    req.NewDate,
+			RescheduledBy:    req.RequestedBy,
+			Reason:           req.Reason,
+			CreatedAt:        now,
+		}
+		if err := tx.Create(history).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		result = &services.RescheduleResult{
+			Session:              newSession,
+			Reschedule:           toSessionReschedule(history),
+			RemainingReschedules: policy.MaxPerBooking - int(rescheduleCount) - 1,
+		}
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return result, nil
+}
+
+// GetSessionReschedules retrieves the reschedule history of a booking
+func (r *Repository) GetSessionReschedules(ctx context.Context, bookingID int64) ([]*services.SessionReschedule, error) {
+	var historyData []datamodel.BookingSessionReschedule
+	if err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("created_at ASC").
+		Find(&historyData).Error; err != nil {
+		return nil, err
+	}
+
+	history := make([]*services.SessionReschedule, 0, len(historyData))
+	for i := range historyData {
+		history = append(history, toSessionReschedule(&historyData[i]))
+	}
+	return history, nil
+}
+
+func toSessionReschedule(data *datamodel.BookingSessionReschedule) *services.SessionReschedule {
+	return &services.SessionReschedule{
+		ID:               data.ID,
+		BookingID:        data.BookingID,
+		BookingSessionID: data.BookingSessionID,
+		FromScheduleID:   data.FromScheduleID,
+		FromSessionDate:  data.FromSessionDate,
+		ToScheduleID:     data.ToScheduleID,
+		ToSessionDate:    data.ToSessionDate,
+		RescheduledBy:    data.RescheduledBy,
+		Reason:           data.Reason,
+		CreatedAt:        data.CreatedAt,
+	}
+}
+
+// sameDate reports whether two timestamps fall on the same calendar day
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+// ExpireHeldBookings cancels unpaid bookings created before the cutoff, expires their pending payments
+// and releases their slots. Bookings the vendor already confirmed are held the same way until they are paid.
+// Rows locked by another transaction (e.g. a payment being confirmed) are skipped and picked up on the next sweep.
+// Returns the IDs of the expired bookings.
+func (r *Repository) ExpireHeldBookings(ctx context.Context, cutoff time.Time, limit int, now time.Time) ([]int64, error) {
+	var expiredIDs []int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock a batch of expired holds
+		var held []struct {
+			ID     int64
+			Status string
+		}
+		if err := tx.Raw(`
+			SELECT id, status
+			FROM bookings
+			WHERE status IN ?
+			  AND payment_status = ?
+			  AND created_at < ?
+			ORDER BY created_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		`, heldBookingStatuses(), string(services.PaymentStatusUnpaid), cutoff, limit).
+			Scan(&held).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		if len(held) == 0 {
+			return nil
+		}
+
+		// 2. Lock their pending payments, leaving out bookings whose payment is being settled right now
+		heldIDs := make([]int64, 0, len(held))
+		for _, b := range held {
+			heldIDs = append(heldIDs, b.ID)
+		}
+		busy, err := lockPendingPayments(tx, heldIDs)
+		if err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 3. Record the expiry in each booking's timeline
+		history := make([]*datamodel.BookingStatusHistory, 0, len(held))
+		for _, b := range held {
+			if busy[b.ID] {
+				continue
+			}
+			change, err := services.NewBookingStatusChange(
+				b.ID, services.BookingStatus(b.Status), services.BookingStatusCancelled,
+				services.ActorSystem, nil, services.HoldExpiredReason, now,
+			)
+			if err != nil {
+				return internal.NewInternalServerError(err)
+			}
+			history = append(history, change.ToDataModel())
+			expiredIDs = append(expiredIDs, b.ID)
+		}
+
+		if len(expiredIDs) == 0 {
+			return nil
+		}
+
+		// 4. Cancel the bookings on behalf of the system
+		if err := tx.Model(&datamodel.Booking{}).
+			Where("id IN ?", expiredIDs).
+			Updates(map[string]interface{}{
+				"status":              string(services.BookingStatusCancelled),
+				"cancellation_reason": services.HoldExpiredReason,
+				"cancelled_by":        string(services.CancelledBySystem),
+				"cancelled_at":        now,
+				"version":             gorm.Expr("version + 1"),
+				"updated_at":          now,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 5. Expire their pending payments so they can no longer be paid
+		if err := tx.Model(&datamodel.Payment{}).
+			Where("booking_id IN ? AND status = ?", expiredIDs, string(payment.StatusPending)).
+			Updates(map[string]interface{}{
+				"status":     string(payment.StatusExpired),
+				"updated_at": now,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 6. Cancel their sessions so checkAndReserveSlots stops counting them
+		if err := tx.Model(&datamodel.BookingSession{}).
+			Where("booking_id IN ? AND status = ?", expiredIDs, string(services.SessionStatusScheduled)).
+			Updates(map[string]interface{}{
+				"status":     string(services.SessionStatusCancelled),
+				"updated_at": now,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		if err := tx.Create(&history).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return expiredIDs, nil
+}
+
+// lockPendingPayments locks the pending payments of the given bookings, skipping rows already locked.
+// Returns the bookings whose payment was skipped: a payment notification locks the payment before its
+// booking, so waiting for it here could deadlock.
+func lockPendingPayments(tx *gorm.DB, bookingIDs []int64) (map[int64]bool, error) {
+	var pending, locked []struct {
+		ID        int64
+		BookingID int64
+	}
+	if err := tx.Raw(`
+		SELECT id, booking_id FROM payments WHERE booking_id IN ? AND status = ?
+	`, bookingIDs, string(payment.StatusPending)).Scan(&pending).Error; err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Raw(`
+		SELECT id, booking_id FROM payments WHERE booking_id IN ? AND status = ?
+		FOR UPDATE SKIP LOCKED
+	`, bookingIDs, string(payment.StatusPending)).Scan(&locked).Error; err != nil {
+		return nil, err
+	}
+
+	lockedIDs := make(map[int64]bool, len(locked))
+	for _, p := range locked {
+		lockedIDs[p.ID] = true
+	}
+
+	busy := make(map[int64]bool)
+	for _, p := range pending {
+		if !lockedIDs[p.ID] {
+			busy[p.BookingID] = true
+		}
+	}
+	return busy, nil
+}
+
+// heldBookingStatuses lists the statuses in which an unpaid booking holds its slots
+func heldBookingStatuses() []string {
+	return []string{string(services.BookingStatusPending), string(services.BookingStatusConfirmed)}
+}
+
+// bookingListRow is a booking with its next session and ordering columns
+type bookingListRow struct {
+	datamodel.Booking
+	NextSessionID     *int64     `gorm:"column:next_session_id"`
+	NextScheduleID    *int64     `gorm:"column:next_schedule_id"`
+	NextSessionDate   *time.Time `gorm:"column:next_session_date"`
+	NextStartTime     *string    `gorm:"column:next_start_time"`
+	NextEndTime       *string    `gorm:"column:next_end_time"`
+	CompletedSessions int        `gorm:"column:completed_sessions"`
+	Bucket            int        `gorm:"column:bucket"` // 0 = has an upcoming session, 1 = no upcoming session
+	SortAt            time.Time  `gorm:"column:sort_at"`
+}
+
+// ListParentBookings lists a parent's bookings using keyset pagination.
+// Bookings with an upcoming session come first (soonest first), followed by the rest (newest first).
+// Returns up to filter.Limit+1 items so the caller can tell whether another page exists.
+func (r *Repository) ListParentBookings(ctx context.Context, filter *services.BookingListFilter) ([]*services.BookingListItem, error) {
+	scheduled := string(services.SessionStatusScheduled)
+
+	inner := r.db.WithContext(ctx).
+		Table("bookings b").
+		Select(`
+			b.*,
+			ns.id AS next_session_id,
+			ns.schedule_id AS next_schedule_id,
+			ns.session_date AS next_session_date,
+			ns.start_time AS next_start_time,
+			ns.end_time AS next_end_time,
+			COALESCE(cs.completed, 0) AS completed_sessions,
+			CASE WHEN ns.id IS NULL THEN 1 ELSE 0 END AS bucket,
+			COALESCE(ns.session_date + ns.start_time, b.created_at) AS sort_at
+		`).
+		Joins(`LEFT JOIN LATERAL (
+			SELECT bs.id, bs.schedule_id, bs.session_date, bs.start_time, bs.end_time
+			FROM booking_sessions bs
+			WHERE bs.booking_id = b.id
+			  AND bs.status = ?
+			  AND bs.session_date + bs.start_time >= ?
+			ORDER BY bs.session_date, bs.start_time
+			LIMIT 1
+		) ns ON TRUE`, scheduled, filter.Now).
+		Joins(`LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS completed
+			FROM booking_sessions bs
+			WHERE bs.booking_id = b.id AND bs.status = ?
+		) cs ON TRUE`, string(services.SessionStatusCompleted)).
+		Where("b.parent_id = ?", filter.ParentID)
+
+	if filter.Status != nil {
+		inner = inner.Where("b.status = ?", string(*filter.Status))
+	}
+	if filter.ChildID != nil {
+		inner = inner.Where("b.child_id = ?", *filter.ChildID)
+	}
+	if filter.ServiceID != nil {
+		inner = inner.Where("b.service_id = ?", *filter.ServiceID)
+	}
+	if filter.From != nil || filter.To != nil {
+		dateRange := "EXISTS (SELECT 1 FROM booking_sessions bs WHERE bs.booking_id = b.id"
+		args := []interface{}{}
+		if filter.From != nil {
+			dateRange += " AND bs.session_date >= ?"
+			args = append(args, *filter.From)
+		}
+		if filter.To != nil {
+			dateRange += " AND bs.session_date <= ?"
+			args = append(args, *filter.To)
+		}
+		inner = inner.Where(dateRange+")", args...)
+	}
+
+	query := r.db.WithContext(ctx).Table("(?) AS q", inner)
+
+	if filter.Upcoming {
+		query = query.Where("q.bucket = 0")
+	}
+
+	// Continue after the cursor in the same ordering
+	if c := filter.Cursor; c != nil {
+		if c.Upcoming {
+			query = query.Where("(q.bucket = 0 AND (q.sort_at > ? OR (q.sort_at = ? AND q.id > ?))) OR q.bucket = 1",
+				c.SortAt, c.SortAt, c.ID)
+		} else {
+			query = query.Where("q.bucket = 1 AND (q.sort_at < ? OR (q.sort_at = ? AND q.id < ?))",
+				c.SortAt, c.SortAt, c.ID)
+		}
+	}
+
+	var rows []*bookingListRow
+	if err := query.
+		Order("q.bucket ASC").
+		Order("CASE WHEN q.bucket = 0 THEN q.sort_at END ASC").
+		Order("CASE WHEN q.bucket = 1 THEN q.sort_at END DESC").
+		Order("CASE WHEN q.bucket = 0 THEN q.id END ASC").
+		Order("CASE WHEN q.bucket = 1 THEN q.id END DESC").
+		Limit(filter.Limit + 1).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*services.BookingListItem, 0, len(rows))
+	for _, row := range rows {
+		item := &services.BookingListItem{
+			Booking:           toDomainBooking(&row.Booking),
+			CompletedSessions: row.CompletedSessions,
+			Cursor: services.BookingCursor{
+				Upcoming: row.Bucket == 0,
+				SortAt:   row.SortAt,
+				ID:       row.ID,
+				AsOf:     filter.Now,
+			},
+		}
+		if row.NextSessionID != nil {
+			item.NextSession = &services.BookingSession{
+				ID:          *row.NextSessionID,
+				BookingID:   row.ID,
+				SessionDate: *row.NextSessionDate,
+				StartTime:   *row.NextStartTime,
+				EndTime:     *row.NextEndTime,
+				Status:      services.SessionStatusScheduled,
+			}
+			if row.NextScheduleID != nil {
+				item.NextSession.ScheduleID = *row.NextScheduleID
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// GetBookingEnrichments fetches service, child, and vendor names for many bookings at once.
+// Returns the enrichment of each booking keyed by booking ID.
+func (r *Repository) GetBookingEnrichments(ctx context.Context, bookings []*services.Booking) (map[int64]*booking.BookingEnrichment, error) {
+	enrichments := make(map[int64]*booking.BookingEnrichment, len(bookings))
+	if len(bookings) == 0 {
+		return enrichments, nil
+	}
+
+	serviceIDs := make([]int64, 0, len(bookings))
+	childIDs := make([]int64, 0, len(bookings))
+	vendorIDs := make([]int64, 0, len(bookings))
+	for _, b := range bookings {
+		serviceIDs = append(serviceIDs, b.ServiceID)
+		childIDs = append(childIDs, b.ChildID)
+		vendorIDs = append(vendorIDs, b.VendorID)
+	}
+
+	// Fetch service names and categories
+	var serviceRows []struct {
+		ID           int64
+		Name         string
+		CategoryName string
+	}
+	if err := r.db.WithContext(ctx).
+		Table("services").
+		Select("services.id, services.name, service_categories.name AS category_name").
+		Joins("LEFT JOIN service_categories ON service_categories.id = services.category_id").
+		Where("services.id IN ?", serviceIDs).
+		Scan(&serviceRows).Error; err != nil {
+		return nil, err
+	}
+
+	// Fetch child names
+	var childRows []datamodel.Children
+	if err := r.db.WithContext(ctx).
+		Select("id", "name").
+		Where("id IN ?", childIDs).
+		Find(&childRows).Error; err != nil {
+		return nil, err
+	}
+
+	// Fetch vendor names
+	var vendorRows []datamodel.Vendor
+	if err := r.db.WithContext(ctx).
+		Select("id", "business_name").
+		Where("id IN ?", vendorIDs).
+		Find(&vendorRows).Error; err != nil {
+		return nil, err
+	}
+
+	serviceByID := make(map[int64]int, len(serviceRows))
+	for i, s := range serviceRows {
+		serviceByID[s.ID] = i
+	}
+	childNames := make(map[int64]string, len(childRows))
+	for _, c := range childRows {
+		childNames[c.ID] = c.Name
+	}
+	vendorNames := make(map[int64]string, len(vendorRows))
+	for _, v := range vendorRows {
+		vendorNames[v.ID] = v.BusinessName
+	}
+
+	for _, b := range bookings {
+		enrichment := &booking.BookingEnrichment{
+			ChildName:  childNames[b.ChildID],
+			VendorName: vendorNames[b.VendorID],
+		}
+		if i, ok := serviceByID[b.ServiceID]; ok {
+			enrichment.ServiceName = serviceRows[i].Name
+			enrichment.CategoryName = serviceRows[i].CategoryName
+		}
+		enrichments[b.ID] = enrichment
+	}
+
+	return enrichments, nil
+}
+
+// toDomainBooking converts booking data model to domain model without sessions
+func toDomainBooking(data *datamodel.Booking) *services.Booking {
+	b := &services.Booking{
+		ID:             data.ID,
+		BookingNumber:  data.BookingNumber,
+		ParentID:       data.ParentID,
+		ChildID:        data.ChildID,
+		ServiceID:      data.ServiceID,
+		VendorID:       data.VendorID,
+		BookingType:    services.BookingType(data.BookingType),
+		TotalSessions:  data.TotalSessions,
+		TotalAmount:    data.TotalAmount,
+		Status:         services.BookingStatus(data.Status),
+		PaymentStatus:  services.PaymentStatus(data.PaymentStatus),
+		PreferredCoach: data.PreferredCoach,
+		ParentNotes:    data.ParentNotes,
+		Version:        data.Version,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+
+		CompletedSessions: data.CompletedSessions,
+
+		CancellationReason: data.CancellationReason,
+		CancelledAt:        data.CancelledAt,
+		RefundAmount:       data.RefundAmount,
+	}
+
+	if data.CancelledBy != nil {
+		cancelledBy := services.CancelledBy(*data.CancelledBy)
+		b.CancelledBy = &cancelledBy
+	}
+
+	return b
+}
+
+// vendorBookingRow is a booking with the session, child, parent and coach shown to the vendor
+type vendorBookingRow struct {
+	datamodel.Booking
+	SessionID        *int64     `gorm:"column:session_id"`
+	ScheduleID       *int64     `gorm:"column:schedule_id"`
+	SessionNumber    *int       `gorm:"column:session_number"`
+	SessionDate      *time.Time `gorm:"column:session_date"`
+	StartTime        *string    `gorm:"column:start_time"`
+	EndTime          *string    `gorm:"column:end_time"`
+	SessionStatus    *string    `gorm:"column:session_status"`
+	ServiceName      string     `gorm:"column:service_name"`
+	ChildName        string     `gorm:"column:child_name"`
+	ChildDateOfBirth time.Time  `gorm:"column:child_date_of_birth"`
+	ChildPhoto       *string    `gorm:"column:child_photo"`
+	ParentName       string     `gorm:"column:parent_name"`
+	ParentPhone      string     `gorm:"column:parent_phone"`
+	CoachName        *string    `gorm:"column:coach_name"`
+}
+
+// ListVendorBookings lists the bookings a vendor received, one page at a time.
+// With a date filter only bookings with a session that day are returned, ordered by start time;
+// otherwise bookings are ordered by their next scheduled session, then newest first.
+// Returns the page and the total number of matching bookings.
+func (r *Repository) ListVendorBookings(ctx context.Context, filter *services.VendorBookingFilter) ([]*services.VendorBookingItem, int64, error) {
+	query := r.db.WithContext(ctx).
+		Table("bookings b").
+		Where("b.vendor_id = ?", filter.VendorID)
+
+	// Pick the session to show: the one on the requested date, or the next one still to come
+	if filter.Date != nil {
+		query = query.Joins(`JOIN LATERAL (
+			SELECT bs.id, bs.schedule_id, bs.coach_id, bs.session_number, bs.session_date, bs.start_time, bs.end_time, bs.status
+			FROM booking_sessions bs
+			WHERE bs.booking_id = b.id AND bs.session_date = ?
+			ORDER BY bs.start_time
+			LIMIT 1
+		) s ON TRUE`, *filter.Date)
+	} else {
+		query = query.Joins(`LEFT JOIN LATERAL (
+			SELECT bs.id, bs.schedule_id, bs.coach_id, bs.session_number, bs.session_date, bs.start_time, bs.end_time, bs.status
+			FROM booking_sessions bs
+			WHERE bs.booking_id = b.id
+			  AND bs.status = ?
+			  AND bs.session_date + bs.start_time >= ?
+			ORDER BY bs.session_date, bs.start_time
+			LIMIT 1
+		) s ON TRUE`, string(services.SessionStatusScheduled), filter.Now)
+	}
+
+	if filter.Status != nil {
+		query = query.Where("b.status = ?", string(*filter.Status))
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []*vendorBookingRow
+	if err := query.
+		Select(`
+			b.*,
+			s.id AS session_id,
+			s.schedule_id,
+			s.session_number,
+			s.session_date,
+			s.start_time,
+			s.end_time,
+			s.status AS session_status,
+			sv.name AS service_name,
+			c.name AS child_name,
+			c.date_of_birth AS child_date_of_birth,
+			c.photo AS child_photo,
+			u.full_name AS parent_name,
+			u.phone AS parent_phone,
+			co.full_name AS coach_name
+		`).
+		Joins("JOIN services sv ON sv.id = b.service_id").
+		Joins("JOIN children c ON c.id = b.child_id").
+		Joins("JOIN users u ON u.id = b.parent_id").
+		Joins("LEFT JOIN coaches co ON co.id = COALESCE(s.coach_id, b.coach_id)").
+		Order("s.session_date + s.start_time ASC NULLS LAST").
+		Order("b.created_at DESC").
+		Order("b.id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset()).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]*services.VendorBookingItem, 0, len(rows))
+	for _, row := range rows {
+		item := &services.VendorBookingItem{
+			Booking:          toDomainBooking(&row.Booking),
+			ServiceName:      row.ServiceName,
+			ChildName:        row.ChildName,
+			ChildDateOfBirth: row.ChildDateOfBirth,
+			ChildPhoto:       row.ChildPhoto,
+			ParentName:       row.ParentName,
+			ParentPhone:      row.ParentPhone,
+			CoachName:        row.CoachName,
+		}
+		if row.SessionID != nil {
+			item.Session = &services.BookingSession{
+				ID:            *row.SessionID,
+				BookingID:     row.ID,
+				SessionNumber: *row.SessionNumber,
+				SessionDate:   *row.SessionDate,
+				StartTime:     *row.StartTime,
+				EndTime:       *row.EndTime,
+				Status:        services.SessionStatus(*row.SessionStatus),
+			}
+			if row.ScheduleID != nil {
+				item.Session.ScheduleID = *row.ScheduleID
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items, total, nil
+}
+
+// ConfirmBookingWithTransaction moves a pending booking to confirmed.
+// Uses optimistic locking on the booking version to reject concurrent state changes.
+func (r *Repository) ConfirmBookingWithTransaction(ctx context.Context, booking *services.Booking, confirmedBy int64, at time.Time) error {
+	change, err := services.NewBookingStatusChange(
+		booking.ID, booking.Status, services.BookingStatusConfirmed,
+		services.ActorVendor, &confirmedBy, "", at,
+	)
+	if err != nil {
+		return internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Move booking to confirmed if nobody changed it in the meantime
+		result := tx.Model(&datamodel.Booking{}).
+			Where("id = ? AND version = ? AND status = ?", booking.ID, booking.Version, string(services.BookingStatusPending)).
+			Updates(map[string]interface{}{
+				"status":     string(services.BookingStatusConfirmed),
+				"version":    booking.Version + 1,
+				"updated_at": at,
+			})
+		if result.Error != nil {
+			return internal.NewInternalServerError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return internal.ErrOptimisticLock
+		}
+
+		// 2. Record the vendor's confirmation
+		if err := recordStatusChange(tx, change); err != nil {
+			return err
+		}
+
+		booking.Status = services.BookingStatusConfirmed
+		booking.Version++
+		booking.UpdatedAt = at
+		return nil
+	})
+}
+
+// GetBookingIDBySessionID retrieves the booking a session belongs to
+func (r *Repository) GetBookingIDBySessionID(ctx context.Context, sessionID int64) (int64, error) {
+	var session datamodel.BookingSession
+	if err := r.db.WithContext(ctx).Select("booking_id").Where("id = ?", sessionID).First(&session).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, sql.ErrNoRows
+		}
+		return 0, err
+	}
+	return session.BookingID, nil
+}
+
+// GetCoachByUserID retrieves the active coach profile of a user and the vendor it works for
+func (r *Repository) GetCoachByUserID(ctx context.Context, userID int64) (int64, int64, error) {
+	var coach datamodel.Coach
+	if err := r.db.WithContext(ctx).
+		Select("id", "vendor_id").
+		Where("user_id = ? AND status = ?", userID, "active").
+		First(&coach).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, 0, sql.ErrNoRows
+		}
+		return 0, 0, err
+	}
+	return coach.ID, coach.VendorID, nil
+}
+
+// CompleteSessionWithTransaction records the outcome of a session and advances its booking atomically.
+// The booking row is locked so sessions of the same booking completed concurrently are counted once each.
+// The booking becomes ongoing after its first delivered session and completed once none are left scheduled.
+func (r *Repository) CompleteSessionWithTransaction(ctx context.Context, bookingID int64, req *services.CompleteSessionRequest, at time.Time) (*services.SessionCompletionResult, error) {
+	var result *services.SessionCompletionResult
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the booking and verify it still accepts completions
+		var bookingData datamodel.Booking
+		if err := tx.Raw(`
+			SELECT * FROM bookings WHERE id = ? FOR UPDATE
+		`, bookingID).Scan(&bookingData).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if bookingData.ID == 0 {
+			return internal.NewNotFoundError("Booking")
+		}
+
+		booking := toDomainBooking(&bookingData)
+		if err := booking.CanCompleteSessions(); err != nil {
+			return internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+		}
+
+		// 2. Record the session outcome if it is still scheduled
+		sessionStatus := req.SessionStatus()
+		update := tx.Model(&datamodel.BookingSession{}).
+			Where("id = ? AND booking_id = ? AND status = ?", req.SessionID, bookingID, string(services.SessionStatusScheduled)).
+			Updates(map[string]interface{}{
+				"status":       string(sessionStatus),
+				"attended":     req.Attended,
+				"coach_notes":  req.CoachNotes,
+				"rating":       req.Rating,
+				"completed_at": at,
+				"completed_by": req.CompletedBy,
+				"updated_at":   at,
+			})
+		if update.Error != nil {
+			return internal.NewInternalServerError(update.Error)
+		}
+		if update.RowsAffected == 0 {
+			return internal.ErrOptimisticLock
+		}
+
+		// 3. Complete the booking once no session is left to deliver
+		var remaining int64
+		if err := tx.Model(&datamodel.BookingSession{}).
+			Where("booking_id = ? AND status = ?", bookingID, string(services.SessionStatusScheduled)).
+			Count(&remaining).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		bookingStatus := services.BookingStatusOngoing
+		if remaining == 0 {
+			bookingStatus = services.BookingStatusCompleted
+		}
+
+		if bookingStatus != booking.Status {
+			change, err := services.NewBookingStatusChange(
+				bookingID, booking.Status, bookingStatus,
+				services.ActorTypeFromRole(req.Role), &req.CompletedBy, "", at,
+			)
+			if err != nil {
+				return internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+			}
+			if err := recordStatusChange(tx, change); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&datamodel.Booking{}).
+			Where("id = ?", bookingID).
+			Updates(map[string]interface{}{
+				"status":             string(bookingStatus),
+				"completed_sessions": gorm.Expr("completed_sessions + 1"),
+				"version":            gorm.Expr("version + 1"),
+				"updated_at":         at,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 4. Reload the session as stored
+		var sessionData datamodel.BookingSession
+		if err := tx.Where("id = ?", req.SessionID).First(&sessionData).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		result = &services.SessionCompletionResult{
+			Session: &services.BookingSession{
+				ID:            sessionData.ID,
+				BookingID:     sessionData.BookingID,
+				ScheduleID:    sessionData.ScheduleID,
+				SessionNumber: sessionData.SessionNumber,
+				SessionDate:   sessionData.SessionDate,
+				StartTime:     sessionData.StartTime,
+				EndTime:       sessionData.EndTime,
+				Status:        services.SessionStatus(sessionData.Status),
+				CoachID:       sessionData.CoachID,
+				Attended:      sessionData.Attended,
+				CoachNotes:    sessionData.CoachNotes,
+				Rating:        sessionData.Rating,
+				CompletedAt:   sessionData.CompletedAt,
+				CreatedAt:     sessionData.CreatedAt,
+				UpdatedAt:     sessionData.UpdatedAt,
+			},
+			BookingStatus:     bookingStatus,
+			CompletedSessions: bookingData.CompletedSessions + 1,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetBookingStatusHistory retrieves the status timeline of a booking, oldest first
+func (r *Repository) GetBookingStatusHistory(ctx context.Context, bookingID int64) ([]*services.BookingStatusChange, error) {
+	var rows []datamodel.BookingStatusHistory
+	if err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("created_at, id").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	history := make([]*services.BookingStatusChange, 0, len(rows))
+	for i := range rows {
+		history = append(history, toBookingStatusChange(&rows[i]))
+	}
+	return history, nil
+}
+
+// recordStatusChange appends a transition to the booking's status timeline
+func recordStatusChange(tx *gorm.DB, change *services.BookingStatusChange) error {
+	data := change.ToDataModel()
+	if err := tx.Create(data).Error; err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	change.ID = data.ID
+	return nil
+}
+
+func toBookingStatusChange(data *datamodel.BookingStatusHistory) *services.BookingStatusChange {
+	change := &services.BookingStatusChange{
+		ID:        data.ID,
+		BookingID: data.BookingID,
+		ToStatus:  services.BookingStatus(data.ToStatus),
+		ActorType: services.ActorType(data.ActorType),
+		ActorID:   data.ActorID,
+		Reason:    data.Reason,
+		CreatedAt: data.CreatedAt,
+	}
+	if data.FromStatus != nil {
+		from := services.BookingStatus(*data.FromStatus)
+		change.FromStatus = &from
+	}
+	return change
+}
@@ -0,0 +1,513 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListVendorServices lists a vendor's services, newest first. Archived services are left out.
+func (r *Repository) ListVendorServices(ctx context.Context, vendorID int64, page, limit int) ([]*services.Service, int64, error) {
+	query := r.db.WithContext(ctx).Model(&datamodel.Services{}).
+		Where("vendor_id = ? AND status <> ?", vendorID, string(services.ServiceStatusArchived))
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []*datamodel.Services
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*services.Service, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, toDomainService(row))
+	}
+
+	return result, total, nil
+}
+
+// GetVendorService retrieves a service for its vendor to manage
+func (r *Repository) GetVendorService(ctx context.Context, serviceID int64) (*services.Service, error) {
+	var data datamodel.Services
+	if err := r.db.WithContext(ctx).Where("id = ?", serviceID).First(&data).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+	return toDomainService(&data), nil
+}
+
+// IsActiveCategory checks if a category exists and accepts services
+func (r *Repository) IsActiveCategory(ctx context.Context, categoryID int64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&datamodel.ServiceCategory{}).
+		Where("id = ? AND is_active = ?", categoryID, true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateService inserts a new service and fills in its generated fields
+func (r *Repository) CreateService(ctx context.Context, service *services.Service) error {
+	data := service.ToDataModel()
+	if err := r.db.WithContext(ctx).Create(data).Error; err != nil {
+		return err
+	}
+
+	service.ID = data.ID
+	service.Version = data.Version
+	service.CreatedAt = data.CreatedAt
+	service.UpdatedAt = data.UpdatedAt
+	return nil
+}
+
+// UpdateService saves a vendor's changes to a service using optimistic locking
+func (r *Repository) UpdateService(ctx context.Context, service *services.Service) error {
+	data := service.ToDataModel()
+
+	result := r.db.WithContext(ctx).Model(&datamodel.Services{}).
+		Where("id = ? AND version = ?", service.ID, service.Version).
+		Updates(map[string]interface{}{
+			"category_id":       data.CategoryID,
+			"name":              data.Name,
+			"description":       data.Description,
+			"age_min":           data.AgeMin,
+			"age_max":           data.AgeMax,
+			"skill_level":       data.SkillLevel,
+			"class_type":        data.ClassType,
+			"max_participants":  data.MaxParticipants,
+			"duration_minutes":  data.DurationMinutes,
+			"price_per_session": data.PricePerSession,
+			"trial_price":       data.TrialPrice,
+			"package_4_price":   data.Package4Price,
+			"package_8_price":   data.Package8Price,
+			"package_12_price":  data.Package12Price,
+			"requirements":      data.Requirements,
+			"what_will_learn":   data.WhatWillLearn,
+			"status":            data.Status,
+			"version":           service.Version + 1,
+			"updated_at":        service.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return internal.ErrOptimisticLock
+	}
+
+	service.Version++
+	return nil
+}
+
+// DeleteService removes a service, or archives it if it has bookings so their history stays intact.
+// The service row is locked so a booking cannot be created for it while it is being removed.
+// Returns true if the service was archived.
+func (r *Repository) DeleteService(ctx context.Context, service *services.Service, at time.Time) (bool, error) {
+	var archived bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the service at the version the vendor saw
+		var locked datamodel.Services
+		if err := tx.Raw(`
+			SELECT id, version
+			FROM services
+			WHERE id = ? AND version = ?
+			FOR UPDATE
+		`, service.ID, service.Version).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if locked.ID == 0 {
+			return internal.ErrOptimisticLock
+		}
+
+		// 2. Services without bookings can go, schedules and coach assignments cascade
+		var bookingCount int64
+		if err := tx.Model(&datamodel.Booking{}).
+			Where("service_id = ?", service.ID).
+			Count(&bookingCount).Error; err != nil {
+			return err
+		}
+		if bookingCount == 0 {
+			return tx.Delete(&datamodel.Services{}, service.ID).Error
+		}
+
+		// 3. Otherwise archive it, which hides it from search and stops new bookings
+		archived = true
+		return tx.Model(&datamodel.Services{}).
+			Where("id = ?", service.ID).
+			Updates(map[string]interface{}{
+				"status":     string(services.ServiceStatusArchived),
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": at,
+			}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return archived, nil
+}
+
+func toDomainService(data *datamodel.Services) *services.Service {
+	return services.FromServiceWithAggregates(&services.ServiceWithAggregates{Services: *data})
+}
+
+// IsVendorCoach checks if a coach is active and works for the vendor
+func (r *Repository) IsVendorCoach(ctx context.Context, coachID, vendorID int64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&datamodel.Coach{}).
+		Where("id = ? AND vendor_id = ? AND status = ?", coachID, vendorID, "active").
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetScheduleByID retrieves a schedule
+func (r *Repository) GetScheduleByID(ctx context.Context, scheduleID int64) (*services.Schedule, error) {
+	var data datamodel.Schedule
+	if err := r.db.WithContext(ctx).Where("id = ?", scheduleID).First(&data).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+	return toDomainSchedule(&data), nil
+}
+
+// CreateSchedule inserts a new schedule, rejecting it if its coach is already busy at that time
+func (r *Repository) CreateSchedule(ctx context.Context, schedule *services.Schedule) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCoachOverlap(tx, schedule); err != nil {
+			return err
+		}
+
+		data := &datamodel.Schedule{
+			ServiceID:      schedule.ServiceID,
+			CoachID:        schedule.CoachID,
+			DayOfWeek:      schedule.DayOfWeek,
+			StartTime:      schedule.StartTime,
+			EndTime:        schedule.EndTime,
+			AvailableSlots: schedule.AvailableSlots,
+			IsActive:       schedule.IsActive,
+			CreatedAt:      schedule.CreatedAt,
+			UpdatedAt:      schedule.UpdatedAt,
+		}
+		if err := tx.Create(data).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		schedule.ID = data.ID
+		return nil
+	})
+}
+
+// UpdateSchedule changes the coach, capacity or active flag of a schedule.
+// The schedule row is locked, which also holds off bookings of it until the change is done.
+// Changes that leave upcoming booked sessions without a seat are only saved when forced;
+// the affected sessions are returned either way.
+func (r *Repository) UpdateSchedule(ctx context.Context, schedule *services.Schedule, force bool, now time.Time) (*services.ScheduleChangeResult, error) {
+	result := &services.ScheduleChangeResult{Schedule: schedule}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	txErr := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the schedule and read its current state
+		var current datamodel.Schedule
+		if err := tx.Raw(`
+			SELECT id, service_id, coach_id, day_of_week, start_time, end_time, available_slots, is_active
+			FROM schedules
+			WHERE id = ?
+			FOR UPDATE
+		`, schedule.ID).Scan(&current).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if current.ID == 0 {
+			return internal.NewNotFoundError("Schedule")
+		}
+
+		// 2. The coach must be free at that time
+		if err := checkCoachOverlap(tx, schedule); err != nil {
+			return err
+		}
+
+		// 3. Find the upcoming sessions the change would leave without a seat
+		var affected []*services.AffectedSession
+		var err error
+		switch {
+		case current.IsActive && !schedule.IsActive:
+			affected, err = findAffectedSessions(tx,
+				"bs.schedule_id = ? AND bs.session_date >= ?", schedule.ID, today)
+		case schedule.AvailableSlots < current.AvailableSlots:
+			affected, err = findAffectedSessions(tx, `
+				bs.schedule_id = ? AND bs.session_date IN (
+					SELECT session_date
+					FROM booking_sessions
+					WHERE schedule_id = ?
+					  AND session_date >= ?
+					  AND status NOT IN ('cancelled', 'no_show')
+					GROUP BY session_date
+					HAVING COUNT(*) > ?
+				)`, schedule.ID, schedule.ID, today, schedule.AvailableSlots)
+		}
+		if err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		result.Affected = affected
+		if len(affected) > 0 && !force {
+			return nil
+		}
+
+		// 4. Save the change
+		if err := tx.Model(&datamodel.Schedule{}).
+			Where("id = ?", schedule.ID).
+			Updates(map[string]interface{}{
+				"coach_id":        schedule.CoachID,
+				"available_slots": schedule.AvailableSlots,
+				"is_active":       schedule.IsActive,
+				"updated_at":      now,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 5. Upcoming sessions follow the schedule's new coach
+		if !sameCoach(current.CoachID, schedule.CoachID) {
+			if err := tx.Model(&datamodel.BookingSession{}).
+				Where("schedule_id = ? AND session_date >= ? AND status = ?",
+					schedule.ID, today, string(services.SessionStatusScheduled)).
+				Updates(map[string]interface{}{
+					"coach_id":   schedule.CoachID,
+					"updated_at": now,
+				}).Error; err != nil {
+				return internal.NewInternalServerError(err)
+			}
+		}
+
+		schedule.UpdatedAt = now
+		result.Applied = true
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return result, nil
+}
+
+// CreateScheduleException closes a day for all of a vendor's services, or for one of them.
+// The affected schedules are locked so no booking lands on the day while it is being closed.
+// Closing a day with booked sessions is only saved when forced; the affected sessions are returned either way.
+func (r *Repository) CreateScheduleException(ctx context.Context, req *services.ScheduleExceptionRequest, force bool, now time.Time) (*services.ScheduleChangeResult, error) {
+	result := &services.ScheduleChangeResult{}
+
+	txErr := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the schedules running on that day
+		scheduleQuery := tx.Table("schedules sch").
+			Select("sch.id").
+			Joins("JOIN services s ON s.id = sch.service_id").
+			Where("s.vendor_id = ? AND sch.day_of_week = ?", req.VendorID, int(req.Date.Weekday()))
+		if req.ServiceID != nil {
+			scheduleQuery = scheduleQuery.Where("s.id = ?", *req.ServiceID)
+		}
+
+		var scheduleIDs []int64
+		if err := scheduleQuery.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "sch"}}).
+			Scan(&scheduleIDs).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 2. The day must not be closed already
+		closedQuery := tx.Model(&datamodel.ScheduleException{}).
+			Where("exception_date = ? AND is_closed = ?", req.Date, true)
+		if req.ServiceID != nil {
+			closedQuery = closedQuery.Where("(vendor_id = ? AND service_id IS NULL) OR service_id = ?", req.VendorID, *req.ServiceID)
+		} else {
+			closedQuery = closedQuery.Where("vendor_id = ? AND service_id IS NULL", req.VendorID)
+		}
+
+		var closedCount int64
+		if err := closedQuery.Count(&closedCount).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if closedCount > 0 {
+			return internal.NewConflictError(
+				fmt.Sprintf("%s is already closed", req.Date.Format("2006-01-02")),
+				internal.ErrConflict,
+			)
+		}
+
+		// 3. Find the sessions booked on that day
+		if len(scheduleIDs) > 0 {
+			affected, err := findAffectedSessions(tx,
+				"bs.schedule_id IN ? AND bs.session_date = ?", scheduleIDs, req.Date)
+			if err != nil {
+				return internal.NewInternalServerError(err)
+			}
+			result.Affected = affected
+		}
+		if len(result.Affected) > 0 && !force {
+			return nil
+		}
+
+		// 4. Close the day. Service closures are stored without vendor_id, which would close every service.
+		reason := req.Reason
+		data := &datamodel.ScheduleException{
+			ServiceID:     req.ServiceID,
+			ExceptionDate: req.Date,
+			Reason:        &reason,
+			IsClosed:      true,
+			CreatedAt:     now,
+		}
+		if req.ServiceID == nil {
+			vendorID := req.VendorID
+			data.VendorID = &vendorID
+		}
+		if err := tx.Create(data).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		result.Exception = &services.ScheduleException{
+			ID:        data.ID,
+			VendorID:  data.VendorID,
+			ServiceID: data.ServiceID,
+			Date:      data.ExceptionDate,
+			Reason:    data.Reason,
+			IsClosed:  data.IsClosed,
+			CreatedAt: data.CreatedAt,
+		}
+		result.Applied = true
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return result, nil
+}
+
+// checkCoachOverlap rejects an active schedule whose coach already runs another schedule at an overlapping time.
+// The coach row is locked so concurrent schedule changes for the same coach are checked one after the other.
+func checkCoachOverlap(tx *gorm.DB, schedule *services.Schedule) error {
+	if schedule.CoachID == nil || !schedule.IsActive {
+		return nil
+	}
+
+	var coachID int64
+	if err := tx.Raw(`SELECT id FROM coaches WHERE id = ? FOR UPDATE`, *schedule.CoachID).Scan(&coachID).Error; err != nil {
+		return internal.NewInternalServerError(err)
+	}
+
+	var others []*datamodel.Schedule
+	if err := tx.Where("coach_id = ? AND day_of_week = ? AND is_active = ? AND id <> ?",
+		*schedule.CoachID, schedule.DayOfWeek, true, schedule.ID).
+		Find(&others).Error; err != nil {
+		return internal.NewInternalServerError(err)
+	}
+
+	for _, other := range others {
+		if schedule.Overlaps(toDomainSchedule(other)) {
+			return internal.NewConflictError(
+				fmt.Sprintf("Coach is already scheduled on %s from %s to %s (schedule %d)",
+					services.GetDayName(other.DayOfWeek), other.StartTime, other.EndTime, other.ID),
+				internal.ErrConflict,
+			)
+		}
+	}
+
+	return nil
+}
+
+// affectedSessionRow is a booked session with its booking and child
+type affectedSessionRow struct {
+	BookingID     int64     `gorm:"column:booking_id"`
+	BookingNumber string    `gorm:"column:booking_number"`
+	BookingStatus string    `gorm:"column:booking_status"`
+	SessionID     int64     `gorm:"column:session_id"`
+	ScheduleID    int64     `gorm:"column:schedule_id"`
+	SessionDate   time.Time `gorm:"column:session_date"`
+	StartTime     string    `gorm:"column:start_time"`
+	EndTime       string    `gorm:"column:end_time"`
+	ChildName     string    `gorm:"column:child_name"`
+}
+
+// findAffectedSessions lists the scheduled sessions matching the condition, earliest first
+func findAffectedSessions(tx *gorm.DB, condition string, args ...interface{}) ([]*services.AffectedSession, error) {
+	var rows []*affectedSessionRow
+	if err := tx.Table("booking_sessions bs").
+		Select(`
+			b.id AS booking_id, b.booking_number, b.status AS booking_status,
+			bs.id AS session_id, bs.schedule_id, bs.session_date, bs.start_time, bs.end_time,
+			c.name AS child_name`).
+		Joins("JOIN bookings b ON b.id = bs.booking_id").
+		Joins("JOIN children c ON c.id = b.child_id").
+		Where("bs.status = ?", string(services.SessionStatusScheduled)).
+		Where(condition, args...).
+		Order("bs.session_date ASC, bs.start_time ASC, b.created_at ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	affected := make([]*services.AffectedSession, 0, len(rows))
+	for _, row := range rows {
+		affected = append(affected, &services.AffectedSession{
+			BookingID:     row.BookingID,
+			BookingNumber: row.BookingNumber,
+			BookingStatus: services.BookingStatus(row.BookingStatus),
+			SessionID:     row.SessionID,
+			ScheduleID:    row.ScheduleID,
+			SessionDate:   row.SessionDate,
+			StartTime:     row.StartTime,
+			EndTime:       row.EndTime,
+			ChildName:     row.ChildName,
+		})
+	}
+	return affected, nil
+}
+
+// isClosedOn checks if the vendor closed the day for the schedule, its service or all their services
+func isClosedOn(tx *gorm.DB, scheduleID, serviceID int64, date time.Time) (bool, error) {
+	var count int64
+	err := tx.Raw(`
+		SELECT COUNT(*)
+		FROM schedule_exceptions
+		WHERE exception_date = ?
+		  AND is_closed = true
+		  AND (schedule_id = ? OR service_id = ? OR vendor_id = (SELECT vendor_id FROM services WHERE id = ?))
+	`, date, scheduleID, serviceID, serviceID).Scan(&count).Error
+	return count > 0, err
+}
+
+func sameCoach(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func toDomainSchedule(data *datamodel.Schedule) *services.Schedule {
+	return &services.Schedule{
+		ID:             data.ID,
+		ServiceID:      data.ServiceID,
+		CoachID:        data.CoachID,
+		DayOfWeek:      data.DayOfWeek,
+		StartTime:      data.StartTime,
+		EndTime:        data.EndTime,
+		AvailableSlots: data.AvailableSlots,
+		IsActive:       data.IsActive,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
@@ -0,0 +1,550 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"gorm.io/gorm"
+)
+
+// CoachData represents a coach row joined with its user account
+type CoachData struct {
+	datamodel.Coach
+	Email string
+	Phone string
+}
+
+const coachSelect = `
+	SELECT c.*, u.email, u.phone
+	FROM coaches c
+	INNER JOIN users u ON u.id = c.user_id
+`
+
+// ListVendorCoaches lists a vendor's coaches, active ones first
+func (r *Repository) ListVendorCoaches(ctx context.Context, vendorID int64) ([]*services.Coach, error) {
+	var rows []*CoachData
+	query := coachSelect + `
+		WHERE c.vendor_id = ?
+		ORDER BY c.status ASC, c.full_name ASC
+	`
+	if err := r.db.WithContext(ctx).Raw(query, vendorID).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	coaches := make([]*services.Coach, 0, len(rows))
+	for _, row := range rows {
+		coaches = append(coaches, toDomainCoach(row))
+	}
+	return coaches, nil
+}
+
+// GetCoachByID retrieves a coach with the contact details of its user account
+func (r *Repository) GetCoachByID(ctx context.Context, coachID int64) (*services.Coach, error) {
+	var row CoachData
+	query := coachSelect + `
+		WHERE c.id = ?
+	`
+	if err := r.db.WithContext(ctx).Raw(query, coachID).Scan(&row).Error; err != nil {
+		return nil, err
+	}
+	if row.ID == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return toDomainCoach(&row), nil
+}
+
+// IsEmailRegistered checks if a user account already uses the email
+func (r *Repository) IsEmailRegistered(ctx context.Context, email string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&datamodel.User{}).
+		Where("LOWER(email) = LOWER(?)", email).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateCoach creates the coach's user account and profile in one transaction
+func (r *Repository) CreateCoach(ctx context.Context, coach *services.Coach, account *datamodel.User) error {
+	certifications, specializations, err := marshalCoachCredentials(coach)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+
+		data := &datamodel.Coach{
+			UserID:          account.ID,
+			VendorID:        coach.VendorID,
+			FullName:        coach.FullName,
+			Bio:             coach.Bio,
+			ExperienceYears: coach.ExperienceYears,
+			Education:       coach.Education,
+			Certifications:  certifications,
+			Specializations: specializations,
+			Photo:           coach.Photo,
+			IsFeatured:      coach.IsFeatured,
+			Status:          string(coach.Status),
+			CreatedAt:       coach.CreatedAt,
+			UpdatedAt:       coach.UpdatedAt,
+		}
+		if err := tx.Create(data).Error; err != nil {
+			return err
+		}
+
+		coach.ID = data.ID
+		coach.UserID = account.ID
+		return nil
+	})
+}
+
+// UpdateCoach saves a coach's profile and keeps the linked user account in step.
+// Deactivating a coach suspends their login and removes them from services; it is refused
+// while active schedules still run with them. Reactivating restores the login.
+func (r *Repository) UpdateCoach(ctx context.Context, coach *services.Coach, now time.Time) error {
+	certifications, specializations, err := marshalCoachCredentials(coach)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the coach row, schedule assignment checks lock it as well
+		var current datamodel.Coach
+		if err := tx.Raw(`
+			SELECT id, status
+			FROM coaches
+			WHERE id = ?
+			FOR UPDATE
+		`, coach.ID).Scan(&current).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if current.ID == 0 {
+			return internal.NewNotFoundError("Coach")
+		}
+
+		deactivating := current.Status == string(services.CoachStatusActive) && !coach.IsActive()
+		reactivating := current.Status != string(services.CoachStatusActive) && coach.IsActive()
+
+		// 2. A coach still running active schedules cannot be deactivated
+		if deactivating {
+			var scheduleCount int64
+			if err := tx.Model(&datamodel.Schedule{}).
+				Where("coach_id = ? AND is_active = ?", coach.ID, true).
+				Count(&scheduleCount).Error; err != nil {
+				return internal.NewInternalServerError(err)
+			}
+			if scheduleCount > 0 {
+				return internal.NewConflictError(
+					fmt.Sprintf("Coach is assigned to %d active schedule(s), reassign them first", scheduleCount),
+					internal.ErrConflict,
+				)
+			}
+		}
+
+		// 3. Save the profile
+		if err := tx.Model(&datamodel.Coach{}).
+			Where("id = ?", coach.ID).
+			Updates(map[string]interface{}{
+				"full_name":        coach.FullName,
+				"bio":              coach.Bio,
+				"experience_years": coach.ExperienceYears,
+				"education":        coach.Education,
+				"certifications":   certifications,
+				"specializations":  specializations,
+				"photo":            coach.Photo,
+				"is_featured":      coach.IsFeatured,
+				"status":           string(coach.Status),
+				"updated_at":       now,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 4. Keep the user account in step
+		accountUpdates := map[string]interface{}{
+			"full_name":  coach.FullName,
+			"phone":      coach.Phone,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": now,
+		}
+		if deactivating {
+			accountUpdates["status"] = "suspended"
+		}
+		if reactivating {
+			accountUpdates["status"] = "active"
+		}
+		if err := tx.Model(&datamodel.User{}).
+			Where("id = ?", coach.UserID).
+			Updates(accountUpdates).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		// 5. Inactive coaches no longer teach any service
+		if deactivating {
+			if err := removeCoachFromServices(tx, coach.ID); err != nil {
+				return internal.NewInternalServerError(err)
+			}
+		}
+
+		coach.UpdatedAt = now
+		return nil
+	})
+}
+
+// ListServiceCoaches lists the active coaches assigned to a service, primary coach first
+func (r *Repository) ListServiceCoaches(ctx context.Context, serviceID int64) ([]*services.CoachDetail, error) {
+	data, err := r.GetServiceCoaches(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return toCoachDetails(data)
+}
+
+// AssignServiceCoach assigns a coach to a service, or changes whether they are its primary coach.
+// A service has at most one primary coach; the first coach assigned becomes primary.
+func (r *Repository) AssignServiceCoach(ctx context.Context, serviceID, coachID int64, isPrimary bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the service so concurrent assignments agree on the primary coach
+		if err := lockService(tx, serviceID); err != nil {
+			return err
+		}
+
+		// 2. Without another primary coach this one becomes primary
+		if !isPrimary {
+			var otherPrimaries int64
+			if err := tx.Model(&datamodel.ServiceCoach{}).
+				Where("service_id = ? AND coach_id <> ? AND is_primary = ?", serviceID, coachID, true).
+				Count(&otherPrimaries).Error; err != nil {
+				return internal.NewInternalServerError(err)
+			}
+			isPrimary = otherPrimaries == 0
+		}
+
+		// 3. Demote the current primary coach
+		if isPrimary {
+			if err := tx.Model(&datamodel.ServiceCoach{}).
+				Where("service_id = ? AND coach_id <> ? AND is_primary = ?", serviceID, coachID, true).
+				Update("is_primary", false).Error; err != nil {
+				return internal.NewInternalServerError(err)
+			}
+		}
+
+		// 4. Upsert the assignment
+		if err := tx.Exec(`
+			INSERT INTO service_coaches (service_id, coach_id, is_primary)
+			VALUES (?, ?, ?)
+			ON CONFLICT (service_id, coach_id) DO UPDATE SET is_primary = EXCLUDED.is_primary
+		`, serviceID, coachID, isPrimary).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		return nil
+	})
+}
+
+// UnassignServiceCoach removes a coach from a service.
+// When the primary coach is removed, the longest-assigned remaining coach takes over.
+func (r *Repository) UnassignServiceCoach(ctx context.Context, serviceID, coachID int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockService(tx, serviceID); err != nil {
+			return err
+		}
+
+		var assignment datamodel.ServiceCoach
+		result := tx.Where("service_id = ? AND coach_id = ?", serviceID, coachID).Limit(1).Find(&assignment)
+		if result.Error != nil {
+			return internal.NewInternalServerError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return internal.NewNotFoundError("Coach assignment")
+		}
+
+		if err := tx.Delete(&datamodel.ServiceCoach{}, assignment.ID).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		if assignment.IsPrimary {
+			if err := tx.Exec(`
+				UPDATE service_coaches
+				SET is_primary = true
+				WHERE id = (
+					SELECT id FROM service_coaches
+					WHERE service_id = ?
+					ORDER BY id ASC
+					LIMIT 1
+				)
+			`, serviceID).Error; err != nil {
+				return internal.NewInternalServerError(err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// removeCoachFromServices drops all service assignments of a coach,
+// promoting another coach on each service where they were primary
+func removeCoachFromServices(tx *gorm.DB, coachID int64) error {
+	var primaryServiceIDs []int64
+	if err := tx.Model(&datamodel.ServiceCoach{}).
+		Where("coach_id = ? AND is_primary = ?", coachID, true).
+		Pluck("service_id", &primaryServiceIDs).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("coach_id = ?", coachID).Delete(&datamodel.ServiceCoach{}).Error; err != nil {
+		return err
+	}
+
+	if len(primaryServiceIDs) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`
+		UPDATE service_coaches
+		SET is_primary = true
+		WHERE id IN (
+			SELECT DISTINCT ON (service_id) id
+			FROM service_coaches
+			WHERE service_id IN ?
+			ORDER BY service_id, id ASC
+		)
+	`, primaryServiceIDs).Error
+}
+
+// lockService locks a service row for the rest of the transaction
+func lockService(tx *gorm.DB, serviceID int64) error {
+	var id int64
+	if err := tx.Raw(`SELECT id FROM services WHERE id = ? FOR UPDATE`, serviceID).Scan(&id).Error; err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if id == 0 {
+		return internal.NewNotFoundError("Service")
+	}
+	return nil
+}
+
+func marshalCoachCredentials(coach *services.Coach) (*string, *string, error) {
+	certifications := coach.Certifications
+	if certifications == nil {
+		certifications = []services.Certification{}
+	}
+	certificationsJSON, err := json.Marshal(certifications)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	specializations := coach.Specializations
+	if specializations == nil {
+		specializations = []string{}
+	}
+	specializationsJSON, err := json.Marshal(specializations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificationsStr := string(certificationsJSON)
+	specializationsStr := string(specializationsJSON)
+	return &certificationsStr, &specializationsStr, nil
+}
+
+func toDomainCoach(data *CoachData) *services.Coach {
+	var certifications []services.Certification
+	var specializations []string
+
+	// Parse JSONB certifications
+	if data.Certifications != nil {
+		if err := json.Unmarshal([]byte(*data.Certifications), &certifications); err != nil {
+			certifications = []services.Certification{}
+		}
+	}
+
+	// Parse JSONB specializations
+	if data.Specializations != nil {
+		if err := json.Unmarshal([]byte(*data.Specializations), &specializations); err != nil {
+			specializations = []string{}
+		}
+	}
+
+	return &services.Coach{
+		ID:              data.ID,
+		UserID:          data.UserID,
+		VendorID:        data.VendorID,
+		FullName:        data.FullName,
+		Email:           data.Email,
+		Phone:           data.Phone,
+		Bio:             data.Bio,
+		ExperienceYears: data.ExperienceYears,
+		Education:       data.Education,
+		Certifications:  certifications,
+		Specializations: specializations,
+		Photo:           data.Photo,
+		IsFeatured:      data.IsFeatured,
+		Status:          services.CoachStatus(data.Status),
+		CreatedAt:       data.CreatedAt,
+		UpdatedAt:       data.UpdatedAt,
+	}
+}
+
+// CoachSessionData represents a booked session joined with its booking, service and child
+type CoachSessionData struct {
+	SessionID      int64
+	SessionNumber  int
+	SessionDate    time.Time
+	StartTime      string
+	EndTime        string
+	Status         string
+	Attended       bool
+	CoachNotes     *string
+	ScheduleID     *int64
+	CoachID        *int64
+	BookingID      int64
+	BookingNumber  string
+	BookingStatus  string
+	TotalSessions  int
+	ParentNotes    *string
+	ServiceID      int64
+	ServiceName    string
+	ClassType      string
+	ChildID        int64
+	ChildName      string
+	ChildNickname  *string
+	ChildBirthDate time.Time
+	ChildGender    *string
+	SpecialNeeds   *string
+}
+
+const coachSessionSelect = `
+	SELECT
+		bs.id AS session_id, bs.session_number, bs.session_date, bs.start_time, bs.end_time,
+		bs.status, bs.attended, bs.coach_notes, bs.schedule_id,
+		COALESCE(bs.coach_id, b.coach_id) AS coach_id,
+		b.id AS booking_id, b.booking_number, b.status AS booking_status, b.total_sessions, b.parent_notes,
+		s.id AS service_id, s.name AS service_name, s.class_type,
+		c.id AS child_id, c.name AS child_name, c.nickname AS child_nickname,
+		c.date_of_birth AS child_birth_date, c.gender AS child_gender, c.special_needs
+	FROM booking_sessions bs
+	INNER JOIN bookings b ON b.id = bs.booking_id
+	INNER JOIN services s ON s.id = b.service_id
+	INNER JOIN children c ON c.id = b.child_id
+`
+
+// coachVisibleBookingStatuses are the bookings a coach prepares for; pending ones may still be rejected
+func coachVisibleBookingStatuses() []string {
+	return []string{
+		string(services.BookingStatusConfirmed),
+		string(services.BookingStatusOngoing),
+		string(services.BookingStatusCompleted),
+	}
+}
+
+// ListCoachSessions lists the sessions a coach teaches within a date range, in teaching order.
+// Sessions without a coach of their own belong to the coach chosen for the booking.
+func (r *Repository) ListCoachSessions(ctx context.Context, filter *services.CoachSessionFilter) ([]*services.CoachSession, error) {
+	var rows []*CoachSessionData
+	query := coachSessionSelect + `
+		WHERE COALESCE(bs.coach_id, b.coach_id) = ?
+		  AND bs.session_date BETWEEN ? AND ?
+		  AND bs.status <> ?
+		  AND b.status IN ?
+		ORDER BY bs.session_date ASC, bs.start_time ASC, c.name ASC
+	`
+	if err := r.db.WithContext(ctx).Raw(query,
+		filter.CoachID,
+		filter.From.Format("2006-01-02"),
+		filter.To.Format("2006-01-02"),
+		string(services.SessionStatusCancelled),
+		coachVisibleBookingStatuses(),
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainCoachSessions(rows), nil
+}
+
+// GetCoachSession retrieves a booked session with its booking, service and child
+func (r *Repository) GetCoachSession(ctx context.Context, sessionID int64) (*services.CoachSession, error) {
+	var row CoachSessionData
+	query := coachSessionSelect + `
+		WHERE bs.id = ?
+	`
+	if err := r.db.WithContext(ctx).Raw(query, sessionID).Scan(&row).Error; err != nil {
+		return nil, err
+	}
+	if row.SessionID == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return toDomainCoachSession(&row), nil
+}
+
+// ListSessionRoster lists every child booked into the same class as the session:
+// the same schedule on the same date. Sessions outside a schedule only list themselves.
+func (r *Repository) ListSessionRoster(ctx context.Context, session *services.CoachSession) ([]*services.CoachSession, error) {
+	if session.ScheduleID == nil {
+		return []*services.CoachSession{session}, nil
+	}
+
+	var rows []*CoachSessionData
+	query := coachSessionSelect + `
+		WHERE bs.schedule_id = ?
+		  AND bs.session_date = ?
+		  AND bs.start_time = ?
+		  AND bs.status <> ?
+		  AND b.status IN ?
+		ORDER BY c.name ASC
+	`
+	if err := r.db.WithContext(ctx).Raw(query,
+		*session.ScheduleID,
+		session.SessionDate.Format("2006-01-02"),
+		session.StartTime,
+		string(services.SessionStatusCancelled),
+		coachVisibleBookingStatuses(),
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainCoachSessions(rows), nil
+}
+
+func toDomainCoachSessions(rows []*CoachSessionData) []*services.CoachSession {
+	sessions := make([]*services.CoachSession, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, toDomainCoachSession(row))
+	}
+	return sessions
+}
+
+func toDomainCoachSession(data *CoachSessionData) *services.CoachSession {
+	return &services.CoachSession{
+		SessionID:      data.SessionID,
+		SessionNumber:  data.SessionNumber,
+		SessionDate:    data.SessionDate,
+		StartTime:      data.StartTime,
+		EndTime:        data.EndTime,
+		Status:         services.SessionStatus(data.Status),
+		Attended:       data.Attended,
+		CoachNotes:     data.CoachNotes,
+		ScheduleID:     data.ScheduleID,
+		CoachID:        data.CoachID,
+		BookingID:      data.BookingID,
+		BookingNumber:  data.BookingNumber,
+		BookingStatus:  services.BookingStatus(data.BookingStatus),
+		TotalSessions:  data.TotalSessions,
+		ParentNotes:    data.ParentNotes,
+		ServiceID:      data.ServiceID,
+		ServiceName:    data.ServiceName,
+		ClassType:      services.ClassType(data.ClassType),
+		ChildID:        data.ChildID,
+		ChildName:      data.ChildName,
+		ChildNickname:  data.ChildNickname,
+		ChildBirthDate: data.ChildBirthDate,
+		ChildGender:    data.ChildGender,
+		SpecialNeeds:   data.SpecialNeeds,
+	}
+}
@@ -0,0 +1,207 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/services"
+	"gorm.io/gorm"
+)
+
+const (
+	dashboardPendingLimit = 10
+	dashboardReviewLimit  = 5
+)
+
+// DashboardSessionData represents a session row of the vendor dashboard
+type DashboardSessionData struct {
+	SessionID     int64
+	BookingID     int64
+	BookingNumber string
+	ServiceName   string
+	ChildName     string
+	CoachName     *string
+	SessionDate   time.Time
+	StartTime     string
+	EndTime       string
+	Status        string
+}
+
+// PendingBookingData represents a booking awaiting confirmation
+type PendingBookingData struct {
+	BookingID        int64
+	BookingNumber    string
+	ServiceName      string
+	ChildName        string
+	BookingType      string
+	TotalAmount      float64
+	FirstSessionDate *time.Time
+	CreatedAt        time.Time
+}
+
+// GetVendorDashboard computes the vendor dashboard in a handful of aggregate queries.
+// All queries run in one read-only transaction so the figures agree with each other.
+func (r *Repository) GetVendorDashboard(ctx context.Context, vendorID int64, period services.DashboardPeriod) (*services.VendorDashboard, error) {
+	dashboard := &services.VendorDashboard{Period: period}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY").Error; err != nil {
+			return err
+		}
+
+		// 1. Running totals
+		if err := tx.Raw(`
+			SELECT
+				(SELECT COUNT(*) FROM services WHERE vendor_id = v.id AND status = 'active') AS total_services,
+				(SELECT COUNT(*) FROM coaches WHERE vendor_id = v.id AND status = 'active') AS active_coaches,
+				(SELECT COUNT(*) FROM bookings WHERE vendor_id = v.id AND status = 'pending') AS pending_bookings,
+				COALESCE(v.rating_avg, 0) AS rating_avg,
+				COALESCE(v.total_reviews, 0) AS total_reviews
+			FROM vendors v
+			WHERE v.id = ?
+		`, vendorID).Scan(&dashboard.Overview).Error; err != nil {
+			return err
+		}
+
+		// 2. This week's calendar
+		var sessions []*DashboardSessionData
+		if err := tx.Raw(`
+			SELECT
+				bs.id AS session_id, b.id AS booking_id, b.booking_number,
+				s.name AS service_name, c.name AS child_name, co.full_name AS coach_name,
+				bs.session_date, bs.start_time, bs.end_time, bs.status
+			FROM booking_sessions bs
+			INNER JOIN bookings b ON b.id = bs.booking_id
+			INNER JOIN services s ON s.id = b.service_id
+			INNER JOIN children c ON c.id = b.child_id
+			LEFT JOIN coaches co ON co.id = COALESCE(bs.coach_id, b.coach_id)
+			WHERE b.vendor_id = ?
+			  AND b.status IN ('pending', 'confirmed', 'ongoing', 'completed')
+			  AND bs.status <> 'cancelled'
+			  AND bs.session_date BETWEEN ? AND ?
+			ORDER BY bs.session_date ASC, bs.start_time ASC, s.name ASC
+		`, vendorID, period.WeekStart.Format("2006-01-02"), period.WeekEnd.Format("2006-01-02")).
+			Scan(&sessions).Error; err != nil {
+			return err
+		}
+		dashboard.WeekSessions = toDashboardSessions(sessions)
+
+		// 3. Bookings awaiting confirmation, oldest first so none are left waiting
+		var pending []*PendingBookingData
+		if err := tx.Raw(`
+			SELECT
+				b.id AS booking_id, b.booking_number, s.name AS service_name, c.name AS child_name,
+				b.booking_type, b.total_amount, MIN(bs.session_date) AS first_session_date, b.created_at
+			FROM bookings b
+			INNER JOIN services s ON s.id = b.service_id
+			INNER JOIN children c ON c.id = b.child_id
+			LEFT JOIN booking_sessions bs ON bs.booking_id = b.id
+			WHERE b.vendor_id = ? AND b.status = 'pending'
+			GROUP BY b.id, s.name, c.name
+			ORDER BY b.created_at ASC
+			LIMIT ?
+		`, vendorID, dashboardPendingLimit).Scan(&pending).Error; err != nil {
+			return err
+		}
+		dashboard.PendingBookings = toPendingBookingSummaries(pending)
+
+		// 4. This month's revenue, by payment status
+		monthEnd := period.MonthEnd.AddDate(0, 0, 1)
+		if err := tx.Raw(`
+			SELECT
+				COALESCE(SUM(b.total_amount) FILTER (WHERE b.payment_status = 'paid'), 0) AS paid,
+				COALESCE(SUM(b.total_amount) FILTER (WHERE b.payment_status = 'unpaid' AND b.status <> 'cancelled'), 0) AS outstanding,
+				COALESCE(SUM(b.total_amount) FILTER (WHERE b.payment_status = 'refunded'), 0) AS refunded,
+				COUNT(*) FILTER (WHERE b.status <> 'cancelled') AS total_bookings,
+				COUNT(DISTINCT b.parent_id) FILTER (
+					WHERE b.status <> 'cancelled' AND NOT EXISTS (
+						SELECT 1 FROM bookings prev
+						WHERE prev.vendor_id = b.vendor_id
+						  AND prev.parent_id = b.parent_id
+						  AND prev.created_at < ?
+					)
+				) AS new_customers
+			FROM bookings b
+			WHERE b.vendor_id = ? AND b.created_at >= ? AND b.created_at < ?
+		`, period.MonthStart, vendorID, period.MonthStart, monthEnd).Scan(&dashboard.Revenue).Error; err != nil {
+			return err
+		}
+
+		// 5. Seats taken per active schedule this week
+		if err := tx.Raw(`
+			SELECT
+				sch.id AS schedule_id, s.id AS service_id, s.name AS service_name,
+				sch.day_of_week, sch.start_time, sch.end_time, sch.available_slots,
+				COUNT(bs.id) AS booked_seats
+			FROM schedules sch
+			INNER JOIN services s ON s.id = sch.service_id
+			LEFT JOIN booking_sessions bs ON bs.schedule_id = sch.id
+				AND bs.session_date BETWEEN ? AND ?
+				AND bs.status <> 'cancelled'
+			WHERE s.vendor_id = ? AND s.status = 'active' AND sch.is_active = true
+			GROUP BY sch.id, s.id, s.name
+			ORDER BY sch.day_of_week ASC, sch.start_time ASC
+		`, period.WeekStart.Format("2006-01-02"), period.WeekEnd.Format("2006-01-02"), vendorID).
+			Scan(&dashboard.Occupancy).Error; err != nil {
+			return err
+		}
+
+		// 6. Latest reviews
+		if err := tx.Raw(`
+			SELECT
+				r.id, s.name AS service_name, u.full_name AS parent_name, r.rating, r.review_text,
+				(r.vendor_response IS NOT NULL) AS responded, r.created_at
+			FROM reviews r
+			INNER JOIN services s ON s.id = r.service_id
+			INNER JOIN users u ON u.id = r.parent_id
+			WHERE r.vendor_id = ?
+			ORDER BY r.created_at DESC
+			LIMIT ?
+		`, vendorID, dashboardReviewLimit).Scan(&dashboard.LatestReviews).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dashboard, nil
+}
+
+func toDashboardSessions(rows []*DashboardSessionData) []*services.DashboardSession {
+	sessions := make([]*services.DashboardSession, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, &services.DashboardSession{
+			SessionID:     row.SessionID,
+			BookingID:     row.BookingID,
+			BookingNumber: row.BookingNumber,
+			ServiceName:   row.ServiceName,
+			ChildName:     row.ChildName,
+			CoachName:     row.CoachName,
+			SessionDate:   row.SessionDate,
+			StartTime:     row.StartTime,
+			EndTime:       row.EndTime,
+			Status:        services.SessionStatus(row.Status),
+		})
+	}
+	return sessions
+}
+
+func toPendingBookingSummaries(rows []*PendingBookingData) []*services.PendingBookingSummary {
+	bookings := make([]*services.PendingBookingSummary, 0, len(rows))
+	for _, row := range rows {
+		bookings = append(bookings, &services.PendingBookingSummary{
+			BookingID:        row.BookingID,
+			BookingNumber:    row.BookingNumber,
+			ServiceName:      row.ServiceName,
+			ChildName:        row.ChildName,
+			BookingType:      services.BookingType(row.BookingType),
+			TotalAmount:      row.TotalAmount,
+			FirstSessionDate: row.FirstSessionDate,
+			CreatedAt:        row.CreatedAt,
+		})
+	}
+	return bookings
+}
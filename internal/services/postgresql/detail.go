@@ -95,7 +95,15 @@ type ReviewSummaryData struct {
 
 // GetServiceDetail fetches all service detail data sequentially
 func (r *Repository) GetServiceDetail(ctx context.Context, serviceID int64) (*ServiceDetailData, error) {
-	// 1. Get service
+	// 1. Get service, hidden ones are reported as missing
+	visible, err := r.IsServiceVisible(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, sql.ErrNoRows
+	}
+
 	service, err := r.GetServiceByID(ctx, serviceID)
 	if err != nil {
 		return nil, err
@@ -151,7 +159,7 @@ func (r *Repository) GetVendorByServiceID(ctx context.Context, serviceID int64)
 			v.logo, v.cover_image, v.rating_avg, v.total_reviews, v.verified
 		FROM vendors v
 		INNER JOIN services s ON v.id = s.vendor_id
-		WHERE s.id = $1 AND v.status = 'approved'
+		WHERE s.id = $1 AND ` + visibleServiceCondition + `
 	`
 	err := r.db.WithContext(ctx).Raw(query, serviceID).Scan(&vendor).Error
 	if err != nil {
@@ -199,14 +207,14 @@ func (r *Repository) GetTopReviews(ctx context.Context, serviceID int64, limit i
 	query := `
 		SELECT
 			r.id, u.full_name as parent_name, r.rating, r.review_text,
-			r.did_child_enjoy, r.would_recommend, r.photos,
-			r.vendor_response, r.vendor_responded_at, r.created_at,
+			r.child_enjoyed AS did_child_enjoy, COALESCE(r.would_recommend, false) AS would_recommend, r.photos,
+			r.vendor_response, r.responded_at, r.created_at,
 			EXTRACT(YEAR FROM AGE(CURRENT_DATE, ch.date_of_birth))::int as child_age
 		FROM reviews r
 		INNER JOIN bookings b ON r.booking_id = b.id
 		INNER JOIN users u ON r.parent_id = u.id
 		INNER JOIN children ch ON b.child_id = ch.id
-		WHERE r.service_id = $1
+		WHERE r.service_id = $1 AND ` + publishedReviewCondition + `
 		ORDER BY r.created_at DESC
 		LIMIT $2
 	`
@@ -226,10 +234,10 @@ func (r *Repository) GetReviewSummary(ctx context.Context, serviceID int64) (*Re
 			COALESCE(SUM(CASE WHEN rating = 3 THEN 1 ELSE 0 END), 0) as rating_3,
 			COALESCE(SUM(CASE WHEN rating = 4 THEN 1 ELSE 0 END), 0) as rating_4,
 			COALESCE(SUM(CASE WHEN rating = 5 THEN 1 ELSE 0 END), 0) as rating_5,
-			COALESCE(AVG(CASE WHEN did_child_enjoy = true THEN 100.0 ELSE 0.0 END), 0) as child_enjoyed_pct,
+			COALESCE(AVG(CASE WHEN child_enjoyed = true THEN 100.0 ELSE 0.0 END), 0) as child_enjoyed_pct,
 			COALESCE(AVG(CASE WHEN would_recommend = true THEN 100.0 ELSE 0.0 END), 0) as would_recommend_pct
-		FROM reviews
-		WHERE service_id = $1
+		FROM reviews r
+		WHERE r.service_id = $1 AND ` + publishedReviewCondition + `
 	`
 	err := r.db.WithContext(ctx).Raw(query, serviceID).Scan(&summary).Error
 	return &summary, err
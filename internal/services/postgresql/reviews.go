@@ -2,6 +2,14 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/services"
+	userPostgresql "github.com/frahmantamala/jadiles/internal/user/postgresql"
+	"gorm.io/gorm"
 )
 
 // GetServiceReviews fetches paginated reviews for a service
@@ -12,7 +20,7 @@ func (r *Repository) GetServiceReviews(ctx context.Context, serviceID int64, pag
 	var total int64
 
 	// Count total reviews
-	countQuery := `SELECT COUNT(*) FROM reviews WHERE service_id = $1`
+	countQuery := `SELECT COUNT(*) FROM reviews r WHERE r.service_id = $1 AND ` + publishedReviewCondition
 	if err := r.db.WithContext(ctx).Raw(countQuery, serviceID).Scan(&total).Error; err != nil {
 		return nil, 0, err
 	}
@@ -21,14 +29,14 @@ func (r *Repository) GetServiceReviews(ctx context.Context, serviceID int64, pag
 	query := `
 		SELECT
 			r.id, u.full_name as parent_name, r.rating, r.review_text,
-			r.did_child_enjoy, r.would_recommend, r.photos,
-			r.vendor_response, r.vendor_responded_at, r.created_at,
+			r.child_enjoyed AS did_child_enjoy, COALESCE(r.would_recommend, false) AS would_recommend, r.photos,
+			r.vendor_response, r.responded_at, r.created_at,
 			EXTRACT(YEAR FROM AGE(CURRENT_DATE, ch.date_of_birth))::int as child_age
 		FROM reviews r
 		INNER JOIN bookings b ON r.booking_id = b.id
 		INNER JOIN users u ON r.parent_id = u.id
 		INNER JOIN children ch ON b.child_id = ch.id
-		WHERE r.service_id = $1
+		WHERE r.service_id = $1 AND ` + publishedReviewCondition + `
 		ORDER BY r.created_at DESC
 		LIMIT $2 OFFSET $3
 	`
@@ -36,3 +44,162 @@ func (r *Repository) GetServiceReviews(ctx context.Context, serviceID int64, pag
 
 	return reviews, total, err
 }
+
+// CreateReview stores a parent's review of their booking and recomputes the vendor's rating.
+// The booking row is locked so a booking is reviewed once even under concurrent submissions, and the
+// vendor row is locked so concurrent reviews of the same vendor do not lose rating updates.
+func (r *Repository) CreateReview(ctx context.Context, review *services.Review) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the booking and count its completed sessions
+		var booking services.ReviewableBooking
+		if err := tx.Raw(`
+			SELECT
+				b.id, b.parent_id, b.vendor_id, b.service_id, b.coach_id, b.status,
+				(SELECT COUNT(*) FROM booking_sessions bs
+				 WHERE bs.booking_id = b.id AND bs.status = 'completed') AS completed_sessions
+			FROM bookings b
+			WHERE b.id = ?
+			FOR UPDATE OF b
+		`, review.BookingID).Scan(&booking).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if booking.ID == 0 {
+			return internal.NewNotFoundError("Booking")
+		}
+		if booking.ParentID != review.ParentID {
+			return internal.NewForbiddenError("Access denied")
+		}
+		if err := booking.CanBeReviewed(); err != nil {
+			return internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+		}
+
+		// 2. Insert the review, one per booking
+		review.VendorID = booking.VendorID
+		review.ServiceID = booking.ServiceID
+		review.CoachID = booking.CoachID
+
+		photos := review.Photos
+		if photos == nil {
+			photos = []string{}
+		}
+		photosJSON, err := json.Marshal(photos)
+		if err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		var inserted struct {
+			ID int64
+		}
+		if err := tx.Raw(`
+			INSERT INTO reviews
+				(booking_id, parent_id, vendor_id, service_id, coach_id, rating, review_text,
+				 child_enjoyed, would_recommend, photos, is_approved, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (booking_id) DO NOTHING
+			RETURNING id
+		`, review.BookingID, review.ParentID, review.VendorID, review.ServiceID, review.CoachID,
+			review.Rating, review.ReviewText, review.ChildEnjoyed, review.WouldRecommend,
+			string(photosJSON), review.IsApproved, review.CreatedAt, review.UpdatedAt).
+			Scan(&inserted).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if inserted.ID == 0 {
+			return internal.NewConflictError("This booking has already been reviewed", internal.ErrConflict)
+		}
+		review.ID = inserted.ID
+
+		// 3. Recompute the vendor's rating from its published reviews, as hiding a review does
+		if err := userPostgresql.RecomputeVendorRating(tx, booking.VendorID, review.CreatedAt); err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		return nil
+	})
+}
+
+// GetReviewByID retrieves a review, published or hidden
+func (r *Repository) GetReviewByID(ctx context.Context, reviewID int64) (*services.Review, error) {
+	var data datamodel.Review
+	if err := r.db.WithContext(ctx).Where("id = ?", reviewID).First(&data).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+	return toDomainReview(&data), nil
+}
+
+// SaveReviewResponse stores the vendor's response to a review.
+// Only published reviews are updated, so a review hidden meanwhile keeps no new response.
+func (r *Repository) SaveReviewResponse(ctx context.Context, review *services.Review) error {
+	result := r.db.WithContext(ctx).Model(&datamodel.Review{}).
+		Where("id = ? AND is_approved = ?", review.ID, true).
+		Updates(map[string]interface{}{
+			"vendor_response": review.VendorResponse,
+			"responded_at":    review.RespondedAt,
+			"updated_at":      review.UpdatedAt,
+		})
+	if result.Error != nil {
+		return internal.NewInternalServerError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return internal.NewBusinessRuleError("hidden reviews cannot be answered", internal.ErrInvalidState)
+	}
+	return nil
+}
+
+// CreateReviewReport files a report for the moderation queue.
+// A user has at most one open report per review.
+func (r *Repository) CreateReviewReport(ctx context.Context, report *services.ReviewReport) error {
+	var inserted struct {
+		ID int64
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO review_reports (review_id, reporter_id, reporter_role, reason, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (review_id, reporter_id) WHERE status = 'open' DO NOTHING
+		RETURNING id
+	`, report.ReviewID, report.ReporterID, report.ReporterRole, report.Reason, string(report.Status), report.CreatedAt).
+		Scan(&inserted).Error; err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if inserted.ID == 0 {
+		return internal.NewConflictError("You have already reported this review", internal.ErrConflict)
+	}
+
+	report.ID = inserted.ID
+	return nil
+}
+
+func toDomainReview(data *datamodel.Review) *services.Review {
+	return &services.Review{
+		ID:             data.ID,
+		BookingID:      data.BookingID,
+		ParentID:       data.ParentID,
+		VendorID:       data.VendorID,
+		ServiceID:      data.ServiceID,
+		CoachID:        data.CoachID,
+		Rating:         data.Rating,
+		ReviewText:     data.ReviewText,
+		ChildEnjoyed:   data.ChildEnjoyed,
+		WouldRecommend: data.WouldRecommend,
+		Photos:         parseJSONStrings(data.Photos),
+		VendorResponse: data.VendorResponse,
+		RespondedAt:    data.RespondedAt,
+		IsApproved:     data.IsApproved,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+}
+
+// parseJSONStrings decodes a JSONB array of strings, treating malformed values as empty
+func parseJSONStrings(raw *string) []string {
+	if raw == nil || *raw == "" {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(*raw), &values); err != nil {
+		return nil
+	}
+	return values
+}
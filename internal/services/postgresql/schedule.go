@@ -97,5 +97,11 @@ func (r *Repository) GetBookedSlotsCount(ctx context.Context, scheduleID int64,
 		return 0, err
 	}
 
-	return result.AvailableSlots, nil
+	// Seats held for the waitlist are not open to new bookings
+	seats, err := countSlotSeats(r.db.WithContext(ctx), scheduleID, date, result.TotalSlots, 0, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return seats.Available(), nil
 }
@@ -66,8 +66,7 @@ func (r *Repository) SearchServices(ctx context.Context, filters *services.Searc
 		`).
 		Joins("INNER JOIN vendors v ON s.vendor_id = v.id").
 		Joins("INNER JOIN service_categories sc ON s.category_id = sc.id").
-		Where("s.status = ?", "active").
-		Where("v.status = ?", "approved")
+		Where(visibleServiceCondition)
 
 	// Apply filters
 	query = r.applyFilters(query, filters)
@@ -77,8 +76,7 @@ func (r *Repository) SearchServices(ctx context.Context, filters *services.Searc
 		Table("services s").
 		Joins("INNER JOIN vendors v ON s.vendor_id = v.id").
 		Joins("INNER JOIN service_categories sc ON s.category_id = sc.id").
-		Where("s.status = ?", "active").
-		Where("v.status = ?", "approved")
+		Where(visibleServiceCondition)
 	countQuery = r.applyFilters(countQuery, filters)
 
 	if err := countQuery.Count(&total).Error; err != nil {
@@ -0,0 +1,27 @@
+package postgresql
+
+import (
+	"context"
+)
+
+// visibleServiceCondition is the one rule deciding which services parents can see and book,
+// for queries aliasing services as s and their vendor as v. A service is visible while it is
+// published and its vendor is approved; draft, inactive and archived services, and every
+// service of a pending, suspended or rejected vendor, are hidden.
+const visibleServiceCondition = "s.status = 'active' AND v.status = 'active'"
+
+// publishedReviewCondition is the rule deciding which reviews parents can read, for queries
+// aliasing reviews as r. Reviews hidden by an admin are kept for the vendor and the audit trail.
+const publishedReviewCondition = "r.is_approved = true"
+
+// IsServiceVisible checks if parents may see and book a service
+func (r *Repository) IsServiceVisible(ctx context.Context, serviceID int64) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("services s").
+		Joins("INNER JOIN vendors v ON v.id = s.vendor_id").
+		Where("s.id = ?", serviceID).
+		Where(visibleServiceCondition).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/admin"
+	adminpg "github.com/frahmantamala/jadiles/internal/admin/postgresql"
+	"github.com/frahmantamala/jadiles/internal/notification"
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+func TestSuspendedVendorServicesAreHidden(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRepository(db)
+	f := newFixture(t, db)
+	ctx := context.Background()
+
+	date := nextWeek()
+	vendorID := f.vendor()
+	categoryID := f.category()
+	serviceID := f.service(vendorID, categoryID)
+	scheduleID := f.schedule(serviceID, date, 4)
+	parentID, childID := f.parent()
+
+	search := func() []*services.ServiceWithAggregates {
+		t.Helper()
+		results, _, err := repo.SearchServices(ctx, &services.SearchFilters{CategoryID: &categoryID, Page: 1, PageSize: 10})
+		if err != nil {
+			t.Fatalf("search services: %v", err)
+		}
+		return results
+	}
+
+	// The availability calendar is served only for visible services
+	visible := func() bool {
+		t.Helper()
+		ok, err := repo.IsServiceVisible(ctx, serviceID)
+		if err != nil {
+			t.Fatalf("check service visibility: %v", err)
+		}
+		return ok
+	}
+
+	book := func() error {
+		_, err := repo.CreateBookingWithTransaction(ctx, &services.CreateBookingRequest{
+			ParentID:     parentID,
+			ChildID:      childID,
+			ServiceID:    serviceID,
+			BookingType:  services.BookingTypeSingle,
+			SessionDates: []services.BookingSessionRequest{{ScheduleID: scheduleID, SessionDate: date}},
+		})
+		return err
+	}
+
+	// Before suspension the service is listed, shown, open and bookable
+	if results := search(); len(results) != 1 || results[0].ID != serviceID {
+		t.Fatalf("search before suspension returned %d services, want the vendor's service", len(results))
+	}
+	if _, err := repo.GetServiceDetail(ctx, serviceID); err != nil {
+		t.Fatalf("detail before suspension: %v", err)
+	}
+	if !visible() {
+		t.Fatalf("service is hidden before suspension")
+	}
+	if err := book(); err != nil {
+		t.Fatalf("booking before suspension: %v", err)
+	}
+
+	// An admin suspends the vendor
+	service := admin.NewService(adminpg.NewAdminRepository(db), notification.NewLogSender())
+	if _, err := service.SuspendVendor(ctx, f.user("admin"), vendorID, &admin.VendorReasonParams{Reason: "Test suspension"}); err != nil {
+		t.Fatalf("suspend vendor: %v", err)
+	}
+
+	// Afterwards it disappears everywhere parents look
+	if results := search(); len(results) != 0 {
+		t.Errorf("search after suspension returned %d services, want none", len(results))
+	}
+	if _, err := repo.GetServiceDetail(ctx, serviceID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("detail after suspension: got %v, want %v", err, sql.ErrNoRows)
+	}
+	if visible() {
+		t.Errorf("service is visible after suspension")
+	}
+	if err := book(); !errors.Is(err, internal.ErrNotFound) {
+		t.Errorf("booking after suspension: got %v, want not found", err)
+	}
+}
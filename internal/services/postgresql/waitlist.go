@@ -0,0 +1,443 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/services"
+	"gorm.io/gorm"
+)
+
+// countBookedSeats counts the seats taken by bookings in a (schedule, date) slot
+func countBookedSeats(tx *gorm.DB, scheduleID int64, date time.Time) (int64, error) {
+	var count int64
+	err := tx.Raw(`
+		SELECT COUNT(*)
+		FROM booking_sessions
+		WHERE schedule_id = ?
+		  AND session_date = ?
+		  AND status NOT IN ('cancelled', 'no_show')
+	`, scheduleID, date).Scan(&count).Error
+	return count, err
+}
+
+// countSlotSeats counts how the seats of a slot are taken, as seen when booking it for childID
+func countSlotSeats(tx *gorm.DB, scheduleID int64, date time.Time, capacity int, childID int64, now time.Time) (services.SlotSeats, error) {
+	seats := services.SlotSeats{Capacity: capacity}
+
+	booked, err := countBookedSeats(tx, scheduleID, date)
+	if err != nil {
+		return seats, err
+	}
+	seats.Booked = int(booked)
+
+	var queued struct {
+		OtherOffers  int
+		OtherWaiting int
+		OwnOffers    int
+	}
+	if err := tx.Raw(`
+		SELECT
+			COUNT(*) FILTER (WHERE child_id <> ? AND status = ? AND offer_expires_at > ?) AS other_offers,
+			COUNT(*) FILTER (WHERE child_id <> ? AND status = ?) AS other_waiting,
+			COUNT(*) FILTER (WHERE child_id = ? AND status = ? AND offer_expires_at > ?) AS own_offers
+		FROM waitlist_entries
+		WHERE schedule_id = ?
+		  AND session_date = ?
+	`, childID, string(services.WaitlistStatusOffered), now,
+		childID, string(services.WaitlistStatusWaiting),
+		childID, string(services.WaitlistStatusOffered), now,
+		scheduleID, date).Scan(&queued).Error; err != nil {
+		return seats, err
+	}
+
+	seats.OtherOffers = queued.OtherOffers
+	seats.OtherWaiting = queued.OtherWaiting
+	seats.HoldsOffer = queued.OwnOffers > 0
+	return seats, nil
+}
+
+// countLiveOffers counts the seats of a slot held by offers that have not lapsed
+func countLiveOffers(tx *gorm.DB, scheduleID int64, date time.Time, now time.Time) (int64, error) {
+	var count int64
+	err := tx.Model(&datamodel.WaitlistEntry{}).
+		Where("schedule_id = ? AND session_date = ? AND status = ? AND offer_expires_at > ?",
+			scheduleID, date, string(services.WaitlistStatusOffered), now).
+		Count(&count).Error
+	return count, err
+}
+
+// claimWaitlistEntries marks the child's active entries for the booked slots as claimed by the booking
+func claimWaitlistEntries(tx *gorm.DB, bookingID, childID int64, sessionDates []services.BookingSessionRequest, now time.Time) error {
+	for _, session := range sessionDates {
+		if err := tx.Model(&datamodel.WaitlistEntry{}).
+			Where("schedule_id = ? AND session_date = ? AND child_id = ? AND status IN ?",
+				session.ScheduleID, session.SessionDate, childID, activeWaitlistStatuses()).
+			Updates(map[string]interface{}{
+				"status":             string(services.WaitlistStatusClaimed),
+				"claimed_booking_id": bookingID,
+				"updated_at":         now,
+			}).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+	}
+	return nil
+}
+
+func activeWaitlistStatuses() []string {
+	return []string{string(services.WaitlistStatusWaiting), string(services.WaitlistStatusOffered)}
+}
+
+// JoinWaitlist queues a child for a fully booked slot.
+// The schedule row is locked so the slot cannot free up between the capacity check and the insert.
+func (r *Repository) JoinWaitlist(ctx context.Context, req *services.JoinWaitlistRequest, now time.Time) (*services.WaitlistEntry, error) {
+	var entry *services.WaitlistEntry
+
+	txErr := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Verify child belongs to parent
+		var child datamodel.Children
+		if err := tx.Where("id = ? AND parent_id = ?", req.ChildID, req.ParentID).First(&child).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return internal.NewNotFoundError("Child not found or does not belong to parent")
+			}
+			return internal.NewInternalServerError(err)
+		}
+
+		// 2. Lock the schedule of an active service
+		var schedule datamodel.Schedule
+		if err := tx.Raw(`
+			SELECT sch.id, sch.service_id, sch.day_of_week, sch.start_time, sch.end_time, sch.available_slots, sch.is_active
+			FROM schedules sch
+			JOIN services s ON s.id = sch.service_id
+			JOIN vendors v ON v.id = s.vendor_id
+			WHERE sch.id = ? AND sch.is_active = true AND `+visibleServiceCondition+`
+			FOR UPDATE OF sch
+		`, req.ScheduleID).Scan(&schedule).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if schedule.ID == 0 {
+			return internal.NewNotFoundError("Schedule not found or inactive")
+		}
+		if int(req.SessionDate.Weekday()) != schedule.DayOfWeek {
+			return internal.NewValidationError(
+				fmt.Sprintf("Schedule runs on %s, not on %s", services.GetDayName(schedule.DayOfWeek), req.SessionDate.Weekday()),
+			)
+		}
+
+		closed, err := isClosedOn(tx, schedule.ID, schedule.ServiceID, req.SessionDate)
+		if err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if closed {
+			return internal.NewBusinessRuleError(
+				fmt.Sprintf("%s is closed", req.SessionDate.Format("2006-01-02")),
+				internal.ErrBusinessRule,
+			)
+		}
+
+		// 3. The child must not already be booked or queued for the slot
+		var bookedForChild int64
+		if err := tx.Raw(`
+			SELECT COUNT(*)
+			FROM booking_sessions bs
+			JOIN bookings b ON b.id = bs.booking_id
+			WHERE bs.schedule_id = ?
+			  AND bs.session_date = ?
+			  AND bs.status NOT IN ('cancelled', 'no_show')
+			  AND b.child_id = ?
+		`, req.ScheduleID, req.SessionDate, req.ChildID).Scan(&bookedForChild).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if bookedForChild > 0 {
+			return internal.NewConflictError("Child is already booked for this session", internal.ErrConflict)
+		}
+
+		var queuedForChild int64
+		if err := tx.Model(&datamodel.WaitlistEntry{}).
+			Where("schedule_id = ? AND session_date = ? AND child_id = ? AND status IN ?",
+				req.ScheduleID, req.SessionDate, req.ChildID, activeWaitlistStatuses()).
+			Count(&queuedForChild).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if queuedForChild > 0 {
+			return internal.NewConflictError("Child is already on the waitlist for this session", internal.ErrConflict)
+		}
+
+		// 4. Only full slots have a waitlist, open seats are booked directly
+		seats, err := countSlotSeats(tx, req.ScheduleID, req.SessionDate, schedule.AvailableSlots, req.ChildID, now)
+		if err != nil {
+			return internal.NewInternalServerError(err)
+		}
+		if seats.Available() > 0 {
+			return internal.NewBusinessRuleError("Session still has available slots, book it directly", internal.ErrBusinessRule)
+		}
+
+		// 5. Join the end of the queue
+		data := &datamodel.WaitlistEntry{
+			ScheduleID:  req.ScheduleID,
+			SessionDate: req.SessionDate,
+			ParentID:    req.ParentID,
+			ChildID:     req.ChildID,
+			Status:      string(services.WaitlistStatusWaiting),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := tx.Create(data).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		var position int64
+		if err := tx.Model(&datamodel.WaitlistEntry{}).
+			Where("schedule_id = ? AND session_date = ? AND status = ? AND (created_at, id) <= (?, ?)",
+				req.ScheduleID, req.SessionDate, string(services.WaitlistStatusWaiting), data.CreatedAt, data.ID).
+			Count(&position).Error; err != nil {
+			return internal.NewInternalServerError(err)
+		}
+
+		entry = toWaitlistEntry(data)
+		entry.Position = int(position)
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return entry, nil
+}
+
+// waitlistItemRow is a waitlist entry joined with its slot, service and child
+type waitlistItemRow struct {
+	datamodel.WaitlistEntry
+	Position    int    `gorm:"column:position"`
+	ServiceID   int64  `gorm:"column:service_id"`
+	ServiceName string `gorm:"column:service_name"`
+	ChildName   string `gorm:"column:child_name"`
+	StartTime   string `gorm:"column:start_time"`
+	EndTime     string `gorm:"column:end_time"`
+}
+
+// ListParentWaitlist lists a parent's active waitlist entries, soonest session first.
+// Waiting entries carry their place in the queue.
+func (r *Repository) ListParentWaitlist(ctx context.Context, parentID int64) ([]*services.WaitlistItem, error) {
+	var rows []*waitlistItemRow
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			w.*,
+			CASE WHEN w.status = ? THEN (
+				SELECT COUNT(*)
+				FROM waitlist_entries o
+				WHERE o.schedule_id = w.schedule_id
+				  AND o.session_date = w.session_date
+				  AND o.status = ?
+				  AND (o.created_at, o.id) <= (w.created_at, w.id)
+			) ELSE 0 END AS position,
+			s.id AS service_id,
+			s.name AS service_name,
+			c.name AS child_name,
+			sch.start_time,
+			sch.end_time
+		FROM waitlist_entries w
+		JOIN schedules sch ON sch.id = w.schedule_id
+		JOIN services s ON s.id = sch.service_id
+		JOIN children c ON c.id = w.child_id
+		WHERE w.parent_id = ?
+		  AND w.status IN ?
+		ORDER BY w.session_date ASC, sch.start_time ASC, w.id ASC
+	`, string(services.WaitlistStatusWaiting), string(services.WaitlistStatusWaiting),
+		parentID, activeWaitlistStatuses()).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*services.WaitlistItem, 0, len(rows))
+	for _, row := range rows {
+		entry := toWaitlistEntry(&row.WaitlistEntry)
+		entry.Position = row.Position
+		items = append(items, &services.WaitlistItem{
+			WaitlistEntry: *entry,
+			ServiceID:     row.ServiceID,
+			ServiceName:   row.ServiceName,
+			ChildName:     row.ChildName,
+			StartTime:     row.StartTime,
+			EndTime:       row.EndTime,
+		})
+	}
+
+	return items, nil
+}
+
+// GetWaitlistEntryByID retrieves a waitlist entry
+func (r *Repository) GetWaitlistEntryByID(ctx context.Context, entryID int64) (*services.WaitlistEntry, error) {
+	var data datamodel.WaitlistEntry
+	if err := r.db.WithContext(ctx).Where("id = ?", entryID).First(&data).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sql.ErrNoRows
+		}
+		return nil, err
+	}
+	return toWaitlistEntry(&data), nil
+}
+
+// CancelWaitlistEntry takes an active entry off the waitlist, releasing any seat offered to it.
+// Returns false if the entry was no longer active.
+func (r *Repository) CancelWaitlistEntry(ctx context.Context, entryID int64, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&datamodel.WaitlistEntry{}).
+		Where("id = ? AND status IN ?", entryID, activeWaitlistStatuses()).
+		Updates(map[string]interface{}{
+			"status":     string(services.WaitlistStatusCancelled),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// waitlistSlot is a (schedule, date) slot with parents waiting for a seat
+type waitlistSlot struct {
+	ScheduleID  int64     `gorm:"column:schedule_id"`
+	SessionDate time.Time `gorm:"column:session_date"`
+}
+
+// ProcessWaitlist expires lapsed offers and entries for past sessions, then offers every free seat
+// to the longest waiting entries of its slot. Each slot is processed in its own transaction
+// with the schedule row locked, so offers never exceed capacity under concurrent bookings.
+func (r *Repository) ProcessWaitlist(ctx context.Context, now time.Time, offerWindow time.Duration) (*services.WaitlistSweepResult, error) {
+	result := &services.WaitlistSweepResult{}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// 1. Expire offers that were not claimed in time and entries whose session has passed
+	expired := r.db.WithContext(ctx).Model(&datamodel.WaitlistEntry{}).
+		Where("(status = ? AND offer_expires_at <= ?) OR (status IN ? AND session_date < ?)",
+			string(services.WaitlistStatusOffered), now, activeWaitlistStatuses(), today).
+		Updates(map[string]interface{}{
+			"status":     string(services.WaitlistStatusExpired),
+			"updated_at": now,
+		})
+	if expired.Error != nil {
+		return nil, expired.Error
+	}
+	result.Expired = int(expired.RowsAffected)
+
+	// 2. Find the slots that have someone waiting
+	var slots []*waitlistSlot
+	if err := r.db.WithContext(ctx).Model(&datamodel.WaitlistEntry{}).
+		Distinct("schedule_id", "session_date").
+		Where("status = ? AND session_date >= ?", string(services.WaitlistStatusWaiting), today).
+		Order("session_date ASC").
+		Scan(&slots).Error; err != nil {
+		return nil, err
+	}
+
+	// 3. Hand out the free seats of each slot
+	for _, slot := range slots {
+		offered, err := r.offerFreeSeats(ctx, slot, now, offerWindow)
+		if err != nil {
+			return result, err
+		}
+		result.Offered = append(result.Offered, offered...)
+	}
+
+	return result, nil
+}
+
+// offerFreeSeats offers the free seats of a slot to its waiting entries in the order they joined
+func (r *Repository) offerFreeSeats(ctx context.Context, slot *waitlistSlot, now time.Time, offerWindow time.Duration) ([]*services.WaitlistEntry, error) {
+	var offered []*services.WaitlistEntry
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. Lock the schedule so bookings of the slot wait for the offers
+		var schedule datamodel.Schedule
+		if err := tx.Raw(`
+			SELECT id, available_slots, is_active
+			FROM schedules
+			WHERE id = ?
+			FOR UPDATE
+		`, slot.ScheduleID).Scan(&schedule).Error; err != nil {
+			return err
+		}
+		if schedule.ID == 0 || !schedule.IsActive {
+			return nil
+		}
+
+		// 2. Seats not taken by bookings or live offers are free
+		bookedCount, err := countBookedSeats(tx, slot.ScheduleID, slot.SessionDate)
+		if err != nil {
+			return err
+		}
+		offerCount, err := countLiveOffers(tx, slot.ScheduleID, slot.SessionDate, now)
+		if err != nil {
+			return err
+		}
+		free := schedule.AvailableSlots - int(bookedCount) - int(offerCount)
+		if free <= 0 {
+			return nil
+		}
+
+		// 3. Offer them to the first entries in the queue
+		var entries []*datamodel.WaitlistEntry
+		if err := tx.Where("schedule_id = ? AND session_date = ? AND status = ?",
+			slot.ScheduleID, slot.SessionDate, string(services.WaitlistStatusWaiting)).
+			Order("created_at ASC, id ASC").
+			Limit(free).
+			Find(&entries).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(entries))
+		for _, e := range entries {
+			ids = append(ids, e.ID)
+		}
+
+		expiresAt := now.Add(offerWindow)
+		if err := tx.Model(&datamodel.WaitlistEntry{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":           string(services.WaitlistStatusOffered),
+				"offered_at":       now,
+				"offer_expires_at": expiresAt,
+				"updated_at":       now,
+			}).Error; err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			entry := toWaitlistEntry(e)
+			entry.Status = services.WaitlistStatusOffered
+			entry.OfferedAt = &now
+			entry.OfferExpiresAt = &expiresAt
+			entry.UpdatedAt = now
+			offered = append(offered, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return offered, nil
+}
+
+func toWaitlistEntry(data *datamodel.WaitlistEntry) *services.WaitlistEntry {
+	return &services.WaitlistEntry{
+		ID:               data.ID,
+		ScheduleID:       data.ScheduleID,
+		SessionDate:      data.SessionDate,
+		ParentID:         data.ParentID,
+		ChildID:          data.ChildID,
+		Status:           services.WaitlistStatus(data.Status),
+		OfferedAt:        data.OfferedAt,
+		OfferExpiresAt:   data.OfferExpiresAt,
+		ClaimedBookingID: data.ClaimedBookingID,
+		CreatedAt:        data.CreatedAt,
+		UpdatedAt:        data.UpdatedAt,
+	}
+}
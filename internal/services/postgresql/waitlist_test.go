@@ -0,0 +1,97 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+func TestWaitlistOfferIsClaimedWithAnotherParentWaiting(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRepository(db)
+	f := newFixture(t, db)
+	ctx := context.Background()
+
+	// A one-seat slot, booked by the first parent
+	date := nextWeek()
+	serviceID := f.service(f.vendor(), f.category())
+	scheduleID := f.schedule(serviceID, date, 1)
+	slot := []services.BookingSessionRequest{{ScheduleID: scheduleID, SessionDate: date}}
+
+	bookingRequest := func(parentID, childID int64) *services.CreateBookingRequest {
+		return &services.CreateBookingRequest{
+			ParentID:     parentID,
+			ChildID:      childID,
+			ServiceID:    serviceID,
+			BookingType:  services.BookingTypeSingle,
+			SessionDates: slot,
+		}
+	}
+
+	firstParent, firstChild := f.parent()
+	first, err := repo.CreateBookingWithTransaction(ctx, bookingRequest(firstParent, firstChild))
+	if err != nil {
+		t.Fatalf("book the only seat: %v", err)
+	}
+
+	// Two more parents queue for it
+	offeredParent, offeredChild := f.parent()
+	waitingParent, waitingChild := f.parent()
+	for _, p := range []struct{ parentID, childID int64 }{{offeredParent, offeredChild}, {waitingParent, waitingChild}} {
+		if _, err := repo.JoinWaitlist(ctx, &services.JoinWaitlistRequest{
+			ParentID:    p.parentID,
+			ChildID:     p.childID,
+			ScheduleID:  scheduleID,
+			SessionDate: date,
+		}, time.Now()); err != nil {
+			t.Fatalf("join waitlist: %v", err)
+		}
+	}
+
+	// The seat frees up and is offered to the first in line
+	f.exec(`UPDATE booking_sessions SET status = 'cancelled' WHERE booking_id = ?`, first.ID)
+
+	result, err := repo.ProcessWaitlist(ctx, time.Now(), 30*time.Minute)
+	if err != nil {
+		t.Fatalf("process waitlist: %v", err)
+	}
+	var offer *services.WaitlistEntry
+	for _, entry := range result.Offered {
+		if entry.ChildID == offeredChild {
+			offer = entry
+		}
+		if entry.ChildID == waitingChild {
+			t.Fatalf("second in line was offered the seat")
+		}
+	}
+	if offer == nil {
+		t.Fatalf("first in line was not offered the seat")
+	}
+
+	// The parent still waiting cannot take the offered seat
+	_, err = repo.CreateBookingWithTransaction(ctx, bookingRequest(waitingParent, waitingChild))
+	if !errors.Is(err, internal.ErrConflict) {
+		t.Fatalf("booking by the waiting parent: got %v, want a conflict", err)
+	}
+
+	// The offer holder claims it even though someone else is waiting
+	booking, err := repo.CreateBookingWithTransaction(ctx, bookingRequest(offeredParent, offeredChild))
+	if err != nil {
+		t.Fatalf("claim the offer: %v", err)
+	}
+
+	claimed, err := repo.GetWaitlistEntryByID(ctx, offer.ID)
+	if err != nil {
+		t.Fatalf("get offered entry: %v", err)
+	}
+	if claimed.Status != services.WaitlistStatusClaimed {
+		t.Errorf("offered entry status = %s, want %s", claimed.Status, services.WaitlistStatusClaimed)
+	}
+	if claimed.ClaimedBookingID == nil || *claimed.ClaimedBookingID != booking.ID {
+		t.Errorf("offered entry claimed booking = %v, want %d", claimed.ClaimedBookingID, booking.ID)
+	}
+}
@@ -2,9 +2,11 @@ package review
 
 import (
 	"context"
+	"encoding/json"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/frahmantamala/jadiles/internal"
@@ -140,3 +142,165 @@ func CalculatePagination(page, limit int, total int64) *ReviewPagination {
 		TotalPages: totalPages,
 	}
 }
+
+// CreateReviewParams represents the HTTP request body for reviewing a booking.
+// Business rules are checked by services.Review.Validate.
+type CreateReviewParams struct {
+	BookingID      int64    `json:"booking_id" validate:"required,gt=0"`
+	Rating         int      `json:"rating" validate:"required,min=1,max=5"`
+	ReviewText     *string  `json:"review_text,omitempty"`
+	ChildEnjoyed   *bool    `json:"child_enjoyed,omitempty"`
+	WouldRecommend *bool    `json:"would_recommend,omitempty"`
+	Photos         []string `json:"photos,omitempty" validate:"omitempty,dive,url"`
+}
+
+// NewCreateReviewParams parses the create review request
+func NewCreateReviewParams(r *http.Request) (*CreateReviewParams, error) {
+	var params CreateReviewParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the create review parameters
+func (p *CreateReviewParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToReview converts DTO to a new published review by the parent
+func (p *CreateReviewParams) ToReview(parentID int64, now time.Time) *services.Review {
+	var reviewText *string
+	if p.ReviewText != nil {
+		text := strings.TrimSpace(*p.ReviewText)
+		if text != "" {
+			reviewText = &text
+		}
+	}
+
+	return &services.Review{
+		BookingID:      p.BookingID,
+		ParentID:       parentID,
+		Rating:         p.Rating,
+		ReviewText:     reviewText,
+		ChildEnjoyed:   p.ChildEnjoyed,
+		WouldRecommend: p.WouldRecommend,
+		Photos:         p.Photos,
+		IsApproved:     true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// ToReviewCreatedResponse converts a submitted review to response
+func ToReviewCreatedResponse(review *services.Review) *v1.ReviewCreatedResponse {
+	message := "Review submitted successfully"
+	resp := &v1.ReviewCreatedResponse{Message: &message}
+	v1Review := ToV1Review(review)
+	resp.Data.Review = &v1Review
+	return resp
+}
+
+// ToV1Review converts a domain review to v1.Review
+func ToV1Review(review *services.Review) v1.Review {
+	photos := review.Photos
+	if photos == nil {
+		photos = []string{}
+	}
+
+	return v1.Review{
+		Id:             &review.ID,
+		Rating:         &review.Rating,
+		ReviewText:     review.ReviewText,
+		ChildEnjoyed:   review.ChildEnjoyed,
+		WouldRecommend: review.WouldRecommend,
+		Photos:         &photos,
+		VendorResponse: review.VendorResponse,
+		RespondedAt:    review.RespondedAt,
+		CreatedAt:      &review.CreatedAt,
+	}
+}
+
+// RespondToReviewParams represents a vendor's response to a review
+type RespondToReviewParams struct {
+	Response string `json:"response" validate:"required,max=1000"`
+}
+
+// NewRespondToReviewParams parses the request body
+func NewRespondToReviewParams(r *http.Request) (*RespondToReviewParams, error) {
+	var params RespondToReviewParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the response params
+func (p *RespondToReviewParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ReportReviewParams represents a parent or vendor flagging a review for moderation
+type ReportReviewParams struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+// NewReportReviewParams parses the request body
+func NewReportReviewParams(r *http.Request) (*ReportReviewParams, error) {
+	var params ReportReviewParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the report params
+func (p *ReportReviewParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ToReviewReport converts params to a domain report awaiting moderation
+func (p *ReportReviewParams) ToReviewReport(reviewID, reporterID int64, role string, now time.Time) *services.ReviewReport {
+	return &services.ReviewReport{
+		ReviewID:     reviewID,
+		ReporterID:   reporterID,
+		ReporterRole: role,
+		Reason:       p.Reason,
+		Status:       services.ReviewReportStatusOpen,
+		CreatedAt:    now,
+	}
+}
+
+// ReviewResponse is the response for a review a vendor answered
+type ReviewResponse struct {
+	Message string `json:"message"`
+	Data    struct {
+		Review v1.Review `json:"review"`
+	} `json:"data"`
+}
+
+// ToReviewResponse converts an answered review to response
+func ToReviewResponse(review *services.Review) *ReviewResponse {
+	resp := &ReviewResponse{Message: "Response saved successfully"}
+	resp.Data.Review = ToV1Review(review)
+	return resp
+}
+
+// ReviewReportResponse is the response for a filed report
+type ReviewReportResponse struct {
+	Message string `json:"message"`
+	Data    struct {
+		ReportID int64  `json:"report_id"`
+		ReviewID int64  `json:"review_id"`
+		Status   string `json:"status"`
+	} `json:"data"`
+}
+
+// ToReviewReportResponse converts a filed report to response
+func ToReviewReportResponse(report *services.ReviewReport) *ReviewReportResponse {
+	resp := &ReviewReportResponse{Message: "Review reported, an admin will look into it"}
+	resp.Data.ReportID = report.ID
+	resp.Data.ReviewID = report.ReviewID
+	resp.Data.Status = string(report.Status)
+	return resp
+}
@@ -56,3 +56,110 @@ func (h *Handler) GetServiceReviews(w http.ResponseWriter, r *http.Request) {
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, response)
 }
+
+// CreateReview handles POST /reviews
+func (h *Handler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	params, err := NewCreateReviewParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.CreateReview(ctx, parentID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+// RespondToReview handles POST /vendor/reviews/{review_id}/respond
+func (h *Handler) RespondToReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	reviewID, err := strconv.ParseInt(chi.URLParam(r, "review_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("review_id must be a valid integer"))
+		return
+	}
+
+	params, err := NewRespondToReviewParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.RespondToReview(ctx, userID, reviewID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ReportReview handles POST /reviews/{review_id}/report
+func (h *Handler) ReportReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated user from JWT context (parent or vendor)
+	userID, err := internal.ExtractUserID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+	role, _ := internal.ExtractRole(ctx)
+
+	reviewID, err := strconv.ParseInt(chi.URLParam(r, "review_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("review_id must be a valid integer"))
+		return
+	}
+
+	params, err := NewReportReviewParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response, err := h.service.ReportReview(ctx, userID, role, reviewID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
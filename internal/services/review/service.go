@@ -2,8 +2,11 @@ package review
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/services"
 	"github.com/frahmantamala/jadiles/internal/services/postgresql"
 	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
 )
@@ -12,6 +15,11 @@ import (
 type Repository interface {
 	GetServiceReviews(ctx context.Context, serviceID int64, page, limit int) ([]*postgresql.ReviewPreviewData, int64, error)
 	GetReviewSummary(ctx context.Context, serviceID int64) (*postgresql.ReviewSummaryData, error)
+	CreateReview(ctx context.Context, review *services.Review) error
+	GetReviewByID(ctx context.Context, reviewID int64) (*services.Review, error)
+	SaveReviewResponse(ctx context.Context, review *services.Review) error
+	CreateReviewReport(ctx context.Context, report *services.ReviewReport) error
+	GetVendorIDByUserID(ctx context.Context, userID int64) (int64, error)
 }
 
 // ServiceUsecase handles review business logic
@@ -61,3 +69,112 @@ func (s *ServiceUsecase) GetServiceReviews(ctx context.Context, serviceID int64,
 
 	return response, nil
 }
+
+// CreateReview lets a parent review their booking once the child attended at least one session.
+// Each booking is reviewed once; the rating is folded into the vendor's average in the same transaction.
+func (s *ServiceUsecase) CreateReview(ctx context.Context, parentID int64, params *CreateReviewParams) (*v1.ReviewCreatedResponse, error) {
+	review := params.ToReview(parentID, time.Now())
+	if err := review.Validate(); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	if err := s.repo.CreateReview(ctx, review); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToReviewCreatedResponse(review), nil
+}
+
+// RespondToReview posts or edits the vendor's single public response to a review of their business.
+// The response can be edited within a window after it was first posted.
+func (s *ServiceUsecase) RespondToReview(ctx context.Context, userID, reviewID int64, params *RespondToReviewParams) (*ReviewResponse, error) {
+	vendorID, err := s.resolveVendorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.getReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.VendorID != vendorID {
+		return nil, internal.NewForbiddenError("Access denied")
+	}
+
+	if err := review.Respond(params.Response, time.Now()); err != nil {
+		return nil, internal.NewBusinessRuleError(err.Error(), internal.ErrInvalidState)
+	}
+
+	if err := s.repo.SaveReviewResponse(ctx, review); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToReviewResponse(review), nil
+}
+
+// ReportReview flags a published review for admin moderation. Parents may report any review they
+// can read; vendors only reviews of their own business.
+func (s *ServiceUsecase) ReportReview(ctx context.Context, userID int64, role string, reviewID int64, params *ReportReviewParams) (*ReviewReportResponse, error) {
+	review, err := s.getReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == "vendor" {
+		vendorID, err := s.resolveVendorID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if review.VendorID != vendorID {
+			return nil, internal.NewForbiddenError("Access denied")
+		}
+	}
+
+	if !review.IsApproved {
+		return nil, internal.NewBusinessRuleError("This review is already hidden", internal.ErrInvalidState)
+	}
+
+	report := params.ToReviewReport(review.ID, userID, role, time.Now())
+	if err := report.Validate(); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	if err := s.repo.CreateReviewReport(ctx, report); err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToReviewReportResponse(report), nil
+}
+
+// getReview loads a review, published or hidden
+func (s *ServiceUsecase) getReview(ctx context.Context, reviewID int64) (*services.Review, error) {
+	review, err := s.repo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("Review")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+	return review, nil
+}
+
+// resolveVendorID finds the vendor profile of the authenticated user
+func (s *ServiceUsecase) resolveVendorID(ctx context.Context, userID int64) (int64, error) {
+	vendorID, err := s.repo.GetVendorIDByUserID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, internal.NewForbiddenError("Vendor profile not found")
+		}
+		return 0, internal.NewInternalServerError(err)
+	}
+	return vendorID, nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// ================== Reviews ==================
+
+const (
+	// MaxReviewTextLength bounds the free text of a review
+	MaxReviewTextLength = 2000
+	// MaxReviewPhotos bounds the photos attached to a review
+	MaxReviewPhotos = 5
+	// MaxReviewResponseLength bounds a vendor's response to a review
+	MaxReviewResponseLength = 1000
+	// ReviewResponseEditWindow is how long a vendor may edit their response after first posting it
+	ReviewResponseEditWindow = 48 * time.Hour
+)
+
+// Review is a parent's rating of a booking, linked to the vendor, service and coach it rates
+type Review struct {
+	ID             int64
+	BookingID      int64
+	ParentID       int64
+	VendorID       int64
+	ServiceID      int64
+	CoachID        *int64
+	Rating         int
+	ReviewText     *string
+	ChildEnjoyed   *bool
+	WouldRecommend *bool
+	Photos         []string // JSONB
+	VendorResponse *string
+	RespondedAt    *time.Time
+	IsApproved     bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Validate validates the fields a parent submits
+func (r *Review) Validate() error {
+	if r.BookingID <= 0 {
+		return fmt.Errorf("booking_id is required")
+	}
+
+	if r.Rating < 1 || r.Rating > 5 {
+		return fmt.Errorf("rating must be between 1 and 5")
+	}
+
+	if r.ReviewText != nil && len(*r.ReviewText) > MaxReviewTextLength {
+		return fmt.Errorf("review_text must not exceed %d characters", MaxReviewTextLength)
+	}
+
+	if len(r.Photos) > MaxReviewPhotos {
+		return fmt.Errorf("at most %d photos are allowed", MaxReviewPhotos)
+	}
+	for i, photo := range r.Photos {
+		if photo == "" {
+			return fmt.Errorf("photos[%d] must not be empty", i)
+		}
+	}
+
+	return nil
+}
+
+// Respond sets the vendor's single response to the review. The first response starts the edit
+// window; after it closes the response is final. Hidden reviews cannot be answered.
+func (r *Review) Respond(response string, now time.Time) error {
+	if !r.IsApproved {
+		return fmt.Errorf("hidden reviews cannot be answered")
+	}
+
+	if response == "" {
+		return fmt.Errorf("response is required")
+	}
+	if len(response) > MaxReviewResponseLength {
+		return fmt.Errorf("response must not exceed %d characters", MaxReviewResponseLength)
+	}
+
+	if r.RespondedAt == nil {
+		r.RespondedAt = &now
+	} else if now.After(r.RespondedAt.Add(ReviewResponseEditWindow)) {
+		return fmt.Errorf("the response can only be edited within %s of posting it", formatDuration(ReviewResponseEditWindow))
+	}
+
+	r.VendorResponse = &response
+	r.UpdatedAt = now
+	return nil
+}
+
+// ReviewableBooking is what a review needs from the booking it rates
+type ReviewableBooking struct {
+	ID                int64
+	ParentID          int64
+	VendorID          int64
+	ServiceID         int64
+	CoachID           *int64
+	Status            BookingStatus
+	CompletedSessions int
+}
+
+// CanBeReviewed checks that the child attended at least one session of the booking
+func (b *ReviewableBooking) CanBeReviewed() error {
+	if b.CompletedSessions == 0 {
+		return fmt.Errorf("a booking can be reviewed once at least one session is completed")
+	}
+	return nil
+}
+
+// ReviewReportStatus tracks a report through the moderation queue
+type ReviewReportStatus string
+
+const (
+	ReviewReportStatusOpen      ReviewReportStatus = "open"
+	ReviewReportStatusHidden    ReviewReportStatus = "hidden"
+	ReviewReportStatusDismissed ReviewReportStatus = "dismissed"
+)
+
+// ReviewReport is a parent or vendor flagging a review for an admin to moderate
+type ReviewReport struct {
+	ID           int64
+	ReviewID     int64
+	ReporterID   int64
+	ReporterRole string // parent, vendor
+	Reason       string
+	Status       ReviewReportStatus
+	CreatedAt    time.Time
+}
+
+// Validate validates the report fields
+func (r *ReviewReport) Validate() error {
+	if r.ReporterRole != "parent" && r.ReporterRole != "vendor" {
+		return fmt.Errorf("only parents and vendors can report reviews")
+	}
+	if r.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if len(r.Reason) > 1000 {
+		return fmt.Errorf("reason must not exceed 1000 characters")
+	}
+	return nil
+}
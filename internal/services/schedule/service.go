@@ -12,6 +12,7 @@ import (
 
 // Repository defines the data access interface for schedule capability
 type Repository interface {
+	IsServiceVisible(ctx context.Context, serviceID int64) (bool, error)
 	GetSchedulesByService(ctx context.Context, serviceID int64) ([]*postgresql.ScheduleData, error)
 	GetScheduleExceptions(ctx context.Context, serviceID int64, startDate, endDate time.Time) ([]*postgresql.ScheduleExceptionData, error)
 	GetBookedSlotsCount(ctx context.Context, scheduleID int64, date time.Time) (int, error)
@@ -38,6 +39,14 @@ func (s *ServiceUsecase) GetServiceAvailability(ctx context.Context, serviceID i
 	}
 	year, month := monthDate.Year(), int(monthDate.Month())
 
+	visible, err := s.repo.IsServiceVisible(ctx, serviceID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+	if !visible {
+		return nil, internal.NewNotFoundError("Service")
+	}
+
 	// Build monthly availability
 	availability, err := s.buildMonthlyAvailability(ctx, serviceID, year, month)
 	if err != nil {
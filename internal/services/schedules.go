@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// ================== Schedule Management ==================
+
+// Schedule is a weekly recurring slot of a service
+type Schedule struct {
+	ID             int64
+	ServiceID      int64
+	CoachID        *int64
+	DayOfWeek      int    // 0=Sunday, 6=Saturday
+	StartTime      string // HH:MM:SS format
+	EndTime        string // HH:MM:SS format
+	AvailableSlots int
+	IsActive       bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Validate validates schedule fields
+func (s *Schedule) Validate() error {
+	if s.ServiceID <= 0 {
+		return fmt.Errorf("service_id is required")
+	}
+
+	if s.DayOfWeek < 0 || s.DayOfWeek > 6 {
+		return fmt.Errorf("day_of_week must be between 0 (Sunday) and 6 (Saturday)")
+	}
+
+	start, err := parseClock(s.StartTime)
+	if err != nil {
+		return fmt.Errorf("start_time must be in HH:MM format")
+	}
+	end, err := parseClock(s.EndTime)
+	if err != nil {
+		return fmt.Errorf("end_time must be in HH:MM format")
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("start_time must be before end_time")
+	}
+
+	if s.AvailableSlots <= 0 {
+		return fmt.Errorf("available_slots must be greater than 0")
+	}
+
+	return nil
+}
+
+// ValidateForService checks the schedule fits the service it belongs to
+func (s *Schedule) ValidateForService(service *Service) error {
+	if service.MaxParticipants > 0 && s.AvailableSlots > service.MaxParticipants {
+		return fmt.Errorf("available_slots must not exceed the service's max_participants (%d)", service.MaxParticipants)
+	}
+	return nil
+}
+
+// Overlaps checks if both schedules run on the same day at overlapping times
+func (s *Schedule) Overlaps(other *Schedule) bool {
+	if s.DayOfWeek != other.DayOfWeek {
+		return false
+	}
+
+	start, _ := parseClock(s.StartTime)
+	end, _ := parseClock(s.EndTime)
+	otherStart, _ := parseClock(other.StartTime)
+	otherEnd, _ := parseClock(other.EndTime)
+
+	return start.Before(otherEnd) && otherStart.Before(end)
+}
+
+// NormalizeClock converts HH:MM or HH:MM:SS to the HH:MM:SS format stored in the database
+func NormalizeClock(value string) (string, error) {
+	t, err := parseClock(value)
+	if err != nil {
+		return "", err
+	}
+	return t.Format("15:04:05"), nil
+}
+
+func parseClock(value string) (time.Time, error) {
+	t, err := time.Parse("15:04:05", value)
+	if err != nil {
+		t, err = time.Parse("15:04", value)
+	}
+	return t, err
+}
+
+// ScheduleUpdate holds the schedule fields a vendor can change once bookings exist.
+// Moving a schedule to another day or time is done by deactivating it and adding a new one.
+type ScheduleUpdate struct {
+	CoachID        *int64
+	AvailableSlots *int
+	IsActive       *bool
+}
+
+// ScheduleExceptionRequest represents a vendor closing a day, for all their services or one of them
+type ScheduleExceptionRequest struct {
+	VendorID  int64
+	ServiceID *int64 // nil closes the day for every service of the vendor
+	Date      time.Time
+	Reason    string
+}
+
+// Validate validates the schedule exception request
+func (r *ScheduleExceptionRequest) Validate(now time.Time) error {
+	if r.VendorID <= 0 {
+		return fmt.Errorf("vendor_id is required")
+	}
+	if r.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, r.Date.Location())
+	if r.Date.Before(today) {
+		return fmt.Errorf("exception_date must not be in the past")
+	}
+	return nil
+}
+
+// AffectedSession is a booked session that a schedule change would leave without a seat
+type AffectedSession struct {
+	BookingID     int64
+	BookingNumber string
+	BookingStatus BookingStatus
+	SessionID     int64
+	ScheduleID    int64
+	SessionDate   time.Time
+	StartTime     string
+	EndTime       string
+	ChildName     string
+}
+
+// ScheduleChangeResult is the outcome of a schedule change that may overbook existing sessions.
+// Changes affecting booked sessions are only applied when forced, the affected sessions are reported either way.
+type ScheduleChangeResult struct {
+	Schedule  *Schedule
+	Exception *ScheduleException
+	Applied   bool
+	Affected  []*AffectedSession
+}
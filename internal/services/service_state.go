@@ -0,0 +1,92 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+)
+
+// ================== Service Lifecycle ==================
+
+// serviceTransitions lists the statuses a vendor can move a service to.
+// Drafts are published once, after that a service is only switched between active and inactive.
+// Archived services are final and only reached by deleting a service that has bookings.
+var serviceTransitions = map[ServiceStatus][]ServiceStatus{
+	ServiceStatusDraft:    {ServiceStatusActive, ServiceStatusInactive},
+	ServiceStatusActive:   {ServiceStatusInactive},
+	ServiceStatusInactive: {ServiceStatusActive},
+}
+
+// CanTransitionTo checks if a service can move from this status to the given one
+func (s ServiceStatus) CanTransitionTo(to ServiceStatus) bool {
+	for _, allowed := range serviceTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns an error if a service cannot move from this status to the given one
+func (s ServiceStatus) ValidateTransition(to ServiceStatus) error {
+	if !s.CanTransitionTo(to) {
+		return fmt.Errorf("service cannot move from %s to %s", s, to)
+	}
+	return nil
+}
+
+// IsArchived checks if the service was deleted by its vendor
+func (s *Service) IsArchived() bool {
+	return s.Status == ServiceStatusArchived
+}
+
+// ValidateAgeRange validates the optional age range
+func (s *Service) ValidateAgeRange() error {
+	if s.AgeMin < 0 || s.AgeMax < 0 {
+		return fmt.Errorf("age must not be negative")
+	}
+	if s.AgeMin > 0 && s.AgeMax > 0 && s.AgeMin > s.AgeMax {
+		return fmt.Errorf("age_min must not be greater than age_max")
+	}
+	return nil
+}
+
+// ToDataModel converts domain service to data model.
+// Zero ages and participant limits are stored as NULL.
+func (s *Service) ToDataModel() *datamodel.Services {
+	dm := &datamodel.Services{
+		ID:              s.ID,
+		VendorID:        s.VendorID,
+		CategoryID:      s.CategoryID,
+		Name:            s.Name,
+		Description:     s.Description,
+		SkillLevel:      string(s.SkillLevel),
+		ClassType:       string(s.ClassType),
+		DurationMinutes: s.DurationMinutes,
+		PricePerSession: s.PricePerSession,
+		TrialPrice:      s.TrialPrice,
+		Package4Price:   s.Package4Price,
+		Package8Price:   s.Package8Price,
+		Package12Price:  s.Package12Price,
+		Requirements:    s.Requirements,
+		WhatWillLearn:   s.WhatWillLearn,
+		Status:          string(s.Status),
+		IsFeatured:      s.IsFeatured,
+		Version:         s.Version,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+	if s.AgeMin > 0 {
+		ageMin := s.AgeMin
+		dm.AgeMin = &ageMin
+	}
+	if s.AgeMax > 0 {
+		ageMax := s.AgeMax
+		dm.AgeMax = &ageMax
+	}
+	if s.MaxParticipants > 0 {
+		maxParticipants := s.MaxParticipants
+		dm.MaxParticipants = &maxParticipants
+	}
+	return dm
+}
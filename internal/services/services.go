@@ -85,6 +85,7 @@ const (
 	ServiceStatusActive   ServiceStatus = "active"
 	ServiceStatusInactive ServiceStatus = "inactive"
 	ServiceStatusDraft    ServiceStatus = "draft"
+	ServiceStatusArchived ServiceStatus = "archived" // Deleted by the vendor but kept for its bookings
 )
 
 // ServiceCategory represents a service category
@@ -410,9 +411,13 @@ type AvailabilitySlot struct {
 
 // ScheduleException represents a schedule exception (holiday/closure)
 type ScheduleException struct {
-	Date     time.Time
-	Reason   *string
-	IsClosed bool
+	ID        int64
+	VendorID  *int64
+	ServiceID *int64
+	Date      time.Time
+	Reason    *string
+	IsClosed  bool
+	CreatedAt time.Time
 }
 
 // ReviewPreview represents review with parent information
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+)
+
+// ================== Waitlist Domain Models ==================
+
+// WaitlistStatus represents the state of a waitlist entry
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting   WaitlistStatus = "waiting"
+	WaitlistStatusOffered   WaitlistStatus = "offered"
+	WaitlistStatusClaimed   WaitlistStatus = "claimed"
+	WaitlistStatusExpired   WaitlistStatus = "expired"
+	WaitlistStatusCancelled WaitlistStatus = "cancelled"
+)
+
+// WaitlistEntry is a parent waiting for a seat in a fully booked (schedule, date) slot
+type WaitlistEntry struct {
+	ID               int64
+	ScheduleID       int64
+	SessionDate      time.Time
+	ParentID         int64
+	ChildID          int64
+	Status           WaitlistStatus
+	Position         int // 1-based place in the queue, 0 once the entry is no longer waiting
+	OfferedAt        *time.Time
+	OfferExpiresAt   *time.Time
+	ClaimedBookingID *int64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsActive checks if the entry is still waiting for or holding a seat
+func (e *WaitlistEntry) IsActive() bool {
+	return e.Status == WaitlistStatusWaiting || e.Status == WaitlistStatusOffered
+}
+
+// HasLiveOffer checks if the entry holds a seat the parent can still claim
+func (e *WaitlistEntry) HasLiveOffer(at time.Time) bool {
+	return e.Status == WaitlistStatusOffered && e.OfferExpiresAt != nil && at.Before(*e.OfferExpiresAt)
+}
+
+// SlotSeats describes how the seats of a (schedule, date) slot are taken, as seen when booking it for a child
+type SlotSeats struct {
+	Capacity     int
+	Booked       int  // Seats taken by bookings
+	OtherOffers  int  // Live offers held for other children
+	OtherWaiting int  // Other children still waiting for an offer
+	HoldsOffer   bool // The child holds a live offer for the slot
+}
+
+// Available returns the seats the child can book. Seats not taken by bookings or other children's offers are free.
+// A child holding an offer books one of them; anyone else only gets the free seats the waiting children do not need.
+func (s SlotSeats) Available() int {
+	free := s.Capacity - s.Booked - s.OtherOffers
+	if free <= 0 {
+		return 0
+	}
+	if s.HoldsOffer {
+		return free
+	}
+	if s.OtherWaiting >= free {
+		return 0
+	}
+	return free - s.OtherWaiting
+}
+
+// JoinWaitlistRequest represents a parent joining the waitlist of a full slot
+type JoinWaitlistRequest struct {
+	ParentID    int64
+	ChildID     int64
+	ScheduleID  int64
+	SessionDate time.Time
+}
+
+// Validate validates the join waitlist request
+func (r *JoinWaitlistRequest) Validate(now time.Time) error {
+	if r.ParentID <= 0 {
+		return fmt.Errorf("parent_id is required")
+	}
+	if r.ChildID <= 0 {
+		return fmt.Errorf("child_id is required")
+	}
+	if r.ScheduleID <= 0 {
+		return fmt.Errorf("schedule_id is required")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, r.SessionDate.Location())
+	if r.SessionDate.Before(today) {
+		return fmt.Errorf("session_date must not be in the past")
+	}
+	return nil
+}
+
+// WaitlistPolicy defines how long offers last and how often they are handed out
+type WaitlistPolicy struct {
+	OfferWindow   time.Duration
+	SweepInterval time.Duration
+}
+
+// DefaultWaitlistPolicy returns the policy used when none is configured:
+// offers last 30 minutes and are handed out every minute
+func DefaultWaitlistPolicy() WaitlistPolicy {
+	return WaitlistPolicy{
+		OfferWindow:   30 * time.Minute,
+		SweepInterval: time.Minute,
+	}
+}
+
+// NewWaitlistPolicy builds a policy from config, falling back to defaults for unset values
+func NewWaitlistPolicy(cfg internal.WaitlistConfig) WaitlistPolicy {
+	policy := DefaultWaitlistPolicy()
+	if cfg.OfferWindow > 0 {
+		policy.OfferWindow = cfg.OfferWindow
+	}
+	if cfg.SweepInterval > 0 {
+		policy.SweepInterval = cfg.SweepInterval
+	}
+	return policy
+}
+
+// WaitlistSweepResult summarizes one pass over the waitlist
+type WaitlistSweepResult struct {
+	Expired int              // Offers and entries that lapsed
+	Offered []*WaitlistEntry // Entries that were just offered a seat
+}
+
+// WaitlistItem is a waitlist entry with the details shown to the parent
+type WaitlistItem struct {
+	WaitlistEntry
+	ServiceID   int64
+	ServiceName string
+	ChildName   string
+	StartTime   string
+	EndTime     string
+}
@@ -0,0 +1,129 @@
+package waitlist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/core/common"
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+// JoinWaitlistParams represents the HTTP request body for joining the waitlist of a full session
+type JoinWaitlistParams struct {
+	ChildID     int64  `json:"child_id" validate:"required,gt=0"`
+	ScheduleID  int64  `json:"schedule_id" validate:"required,gt=0"`
+	SessionDate string `json:"session_date" validate:"required"` // YYYY-MM-DD format
+}
+
+// NewJoinWaitlistParams parses the join waitlist request
+func NewJoinWaitlistParams(r *http.Request) (*JoinWaitlistParams, error) {
+	var params JoinWaitlistParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("Invalid JSON format")
+	}
+	return &params, nil
+}
+
+// Validate validates the join waitlist parameters
+func (p *JoinWaitlistParams) Validate(ctx context.Context) error {
+	if err := common.ValidateStruct(p); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse("2006-01-02", p.SessionDate); err != nil {
+		return internal.NewValidationError("invalid session_date format, expected YYYY-MM-DD")
+	}
+
+	return nil
+}
+
+// ToJoinWaitlistRequest converts DTO to domain request
+func (p *JoinWaitlistParams) ToJoinWaitlistRequest(parentID int64) (*services.JoinWaitlistRequest, error) {
+	sessionDate, err := time.Parse("2006-01-02", p.SessionDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.JoinWaitlistRequest{
+		ParentID:    parentID,
+		ChildID:     p.ChildID,
+		ScheduleID:  p.ScheduleID,
+		SessionDate: sessionDate,
+	}, nil
+}
+
+// WaitlistEntryDTO is a waitlist entry in API responses
+type WaitlistEntryDTO struct {
+	ID               int64      `json:"id"`
+	ScheduleID       int64      `json:"schedule_id"`
+	SessionDate      string     `json:"session_date"`
+	ChildID          int64      `json:"child_id"`
+	Status           string     `json:"status"`
+	Position         *int       `json:"position,omitempty"`
+	OfferExpiresAt   *time.Time `json:"offer_expires_at,omitempty"`
+	ClaimedBookingID *int64     `json:"claimed_booking_id,omitempty"`
+	ServiceID        *int64     `json:"service_id,omitempty"`
+	ServiceName      *string    `json:"service_name,omitempty"`
+	ChildName        *string    `json:"child_name,omitempty"`
+	StartTime        *string    `json:"start_time,omitempty"`
+	EndTime          *string    `json:"end_time,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// WaitlistEntryResponse is the response for a joined waitlist
+type WaitlistEntryResponse struct {
+	Data    WaitlistEntryDTO `json:"data"`
+	Message string           `json:"message"`
+}
+
+// WaitlistResponse is the response for a parent's waitlist
+type WaitlistResponse struct {
+	Data []WaitlistEntryDTO `json:"data"`
+}
+
+// ToWaitlistEntryResponse converts a new waitlist entry to response
+func ToWaitlistEntryResponse(entry *services.WaitlistEntry, message string) *WaitlistEntryResponse {
+	return &WaitlistEntryResponse{
+		Data:    ToWaitlistEntryDTO(entry),
+		Message: message,
+	}
+}
+
+// ToWaitlistResponse converts a parent's waitlist items to response
+func ToWaitlistResponse(items []*services.WaitlistItem) *WaitlistResponse {
+	data := make([]WaitlistEntryDTO, 0, len(items))
+	for _, item := range items {
+		dto := ToWaitlistEntryDTO(&item.WaitlistEntry)
+		dto.ServiceID = &item.ServiceID
+		dto.ServiceName = &item.ServiceName
+		dto.ChildName = &item.ChildName
+		dto.StartTime = &item.StartTime
+		dto.EndTime = &item.EndTime
+		data = append(data, dto)
+	}
+	return &WaitlistResponse{Data: data}
+}
+
+// ToWaitlistEntryDTO converts domain waitlist entry to DTO
+func ToWaitlistEntryDTO(entry *services.WaitlistEntry) WaitlistEntryDTO {
+	dto := WaitlistEntryDTO{
+		ID:               entry.ID,
+		ScheduleID:       entry.ScheduleID,
+		SessionDate:      entry.SessionDate.Format("2006-01-02"),
+		ChildID:          entry.ChildID,
+		Status:           string(entry.Status),
+		ClaimedBookingID: entry.ClaimedBookingID,
+		CreatedAt:        entry.CreatedAt,
+	}
+	if entry.Status == services.WaitlistStatusWaiting && entry.Position > 0 {
+		position := entry.Position
+		dto.Position = &position
+	}
+	if entry.Status == services.WaitlistStatusOffered {
+		dto.OfferExpiresAt = entry.OfferExpiresAt
+	}
+	return dto
+}
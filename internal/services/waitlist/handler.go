@@ -0,0 +1,109 @@
+package waitlist
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// Handler handles HTTP requests for waitlist capability
+type Handler struct {
+	service *ServiceUsecase
+}
+
+// NewHandler creates a new waitlist handler
+func NewHandler(service *ServiceUsecase) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// JoinWaitlist handles POST /waitlist
+func (h *Handler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract authenticated parent ID from JWT context
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	// Parse and validate request
+	params, err := NewJoinWaitlistParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(ctx); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	req, err := params.ToJoinWaitlistRequest(parentID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError(err.Error()))
+		return
+	}
+
+	response, err := h.service.JoinWaitlist(ctx, req)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+// ListWaitlist handles GET /waitlist
+func (h *Handler) ListWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	response, err := h.service.ListWaitlist(ctx, parentID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// LeaveWaitlist handles DELETE /waitlist/{entry_id}
+func (h *Handler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	parentID, err := internal.ExtractParentID(ctx)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	entryID, err := strconv.ParseInt(chi.URLParam(r, "entry_id"), 10, 64)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewValidationError("entry_id must be a valid integer"))
+		return
+	}
+
+	if err := h.service.LeaveWaitlist(ctx, entryID, parentID); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "Left the waitlist successfully",
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
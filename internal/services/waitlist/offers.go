@@ -0,0 +1,69 @@
+package waitlist
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+// OfferRepository defines data access needed to hand out waitlist offers
+type OfferRepository interface {
+	ProcessWaitlist(ctx context.Context, now time.Time, offerWindow time.Duration) (*services.WaitlistSweepResult, error)
+}
+
+// OfferProcessor expires unclaimed offers and offers freed seats to the next parents in line
+type OfferProcessor struct {
+	repo   OfferRepository
+	policy services.WaitlistPolicy
+}
+
+// NewOfferProcessor creates a new offer processor
+func NewOfferProcessor(repo OfferRepository, policy services.WaitlistPolicy) *OfferProcessor {
+	return &OfferProcessor{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+// Run processes the waitlist every SweepInterval until the context is cancelled
+func (p *OfferProcessor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.policy.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.ProcessOnce(ctx); err != nil {
+			slog.ErrorContext(ctx, "Failed to process waitlist", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessOnce runs a single pass over the waitlist
+func (p *OfferProcessor) ProcessOnce(ctx context.Context) (*services.WaitlistSweepResult, error) {
+	result, err := p.repo.ProcessWaitlist(ctx, time.Now(), p.policy.OfferWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Expired > 0 {
+		slog.InfoContext(ctx, "Expired waitlist entries", slog.Int("count", result.Expired))
+	}
+	for _, entry := range result.Offered {
+		slog.InfoContext(ctx, "Offered waitlist seat",
+			slog.Int64("entry_id", entry.ID),
+			slog.Int64("parent_id", entry.ParentID),
+			slog.Int64("schedule_id", entry.ScheduleID),
+			slog.String("session_date", entry.SessionDate.Format("2006-01-02")),
+			slog.Time("offer_expires_at", *entry.OfferExpiresAt),
+		)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package waitlist
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	"github.com/frahmantamala/jadiles/internal/services"
+)
+
+type Repository interface {
+	JoinWaitlist(ctx context.Context, req *services.JoinWaitlistRequest, now time.Time) (*services.WaitlistEntry, error)
+	ListParentWaitlist(ctx context.Context, parentID int64) ([]*services.WaitlistItem, error)
+	GetWaitlistEntryByID(ctx context.Context, entryID int64) (*services.WaitlistEntry, error)
+	CancelWaitlistEntry(ctx context.Context, entryID int64, now time.Time) (bool, error)
+}
+
+type ServiceUsecase struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *ServiceUsecase {
+	return &ServiceUsecase{
+		repo: repo,
+	}
+}
+
+// JoinWaitlist queues a parent's child for a fully booked session.
+// Seats that free up are offered in the order parents joined.
+func (s *ServiceUsecase) JoinWaitlist(ctx context.Context, req *services.JoinWaitlistRequest) (*WaitlistEntryResponse, error) {
+	now := time.Now()
+	if err := req.Validate(now); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	entry, err := s.repo.JoinWaitlist(ctx, req, now)
+	if err != nil {
+		if internal.IsAppError(err) {
+			return nil, err
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToWaitlistEntryResponse(entry, "Joined the waitlist successfully"), nil
+}
+
+// ListWaitlist lists the entries a parent is still waiting on or holding an offer for
+func (s *ServiceUsecase) ListWaitlist(ctx context.Context, parentID int64) (*WaitlistResponse, error) {
+	items, err := s.repo.ListParentWaitlist(ctx, parentID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToWaitlistResponse(items), nil
+}
+
+// LeaveWaitlist takes a parent's entry off the waitlist. A seat offered to the entry goes to the next in line.
+func (s *ServiceUsecase) LeaveWaitlist(ctx context.Context, entryID, parentID int64) error {
+	entry, err := s.repo.GetWaitlistEntryByID(ctx, entryID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return internal.NewNotFoundError("Waitlist entry")
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	// Verify the entry belongs to the parent
+	if entry.ParentID != parentID {
+		return internal.NewForbiddenError("Access denied")
+	}
+
+	if !entry.IsActive() {
+		return internal.NewBusinessRuleError("Waitlist entry is no longer active", internal.ErrInvalidState)
+	}
+
+	cancelled, err := s.repo.CancelWaitlistEntry(ctx, entryID, time.Now())
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if !cancelled {
+		return internal.NewConflictError("Waitlist entry was modified, please try again", internal.ErrConflict)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package services
+
+import "testing"
+
+func TestSlotSeatsAvailable(t *testing.T) {
+	tests := []struct {
+		name  string
+		seats SlotSeats
+		want  int
+	}{
+		{
+			name:  "open slot",
+			seats: SlotSeats{Capacity: 4, Booked: 1},
+			want:  3,
+		},
+		{
+			name:  "fully booked",
+			seats: SlotSeats{Capacity: 4, Booked: 4},
+			want:  0,
+		},
+		{
+			name:  "other offers hold seats",
+			seats: SlotSeats{Capacity: 4, Booked: 2, OtherOffers: 2},
+			want:  0,
+		},
+		{
+			name:  "waiting children are ahead of a new booking",
+			seats: SlotSeats{Capacity: 4, Booked: 3, OtherWaiting: 1},
+			want:  0,
+		},
+		{
+			name:  "waiting children beyond the free seats do not count twice",
+			seats: SlotSeats{Capacity: 4, Booked: 1, OtherWaiting: 1},
+			want:  2,
+		},
+		{
+			name:  "offer holder claims with another child waiting",
+			seats: SlotSeats{Capacity: 4, Booked: 3, OtherWaiting: 2, HoldsOffer: true},
+			want:  1,
+		},
+		{
+			name:  "offer holder still loses to a full slot",
+			seats: SlotSeats{Capacity: 4, Booked: 4, OtherWaiting: 1, HoldsOffer: true},
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.seats.Available(); got != tt.want {
+				t.Errorf("Available() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,13 +22,14 @@ func CORSMiddleware(origins []string) func(handler http.Handler) http.Handler {
 			"Accept",
 			"Authorization",
 			"Content-Type",
+			IdempotencyKeyHeader,
 			"X-Authenticated-Userid",
 			"x-datadog-trace-id",
 			"x-datadog-parent-id",
 			"x-datadog-origin",
 			"x-datadog-sampling-priority",
 		},
-		ExposedHeaders:     []string{"Link"},
+		ExposedHeaders:     []string{"Link", IdempotentReplayedHeader},
 		AllowCredentials:   false,
 		MaxAge:             defautCORSMaxAge,
 		OptionsPassthrough: false,
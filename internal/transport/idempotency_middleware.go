@@ -0,0 +1,264 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+	"github.com/go-chi/render"
+	goRedis "github.com/redis/go-redis/v9"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyLockTTL = time.Minute
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentBodySize     = 1 << 20
+)
+
+const (
+	idempotencyStatusProcessing = "processing"
+	idempotencyStatusCompleted  = "completed"
+)
+
+// idempotencyRecord is stored in Redis for every (user, key) pair
+type idempotencyRecord struct {
+	Status      string `json:"status"`
+	Fingerprint string `json:"fingerprint"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// IdempotencyMiddleware replays the first response of a request when it is retried with the same Idempotency-Key.
+// It must run after authentication, as keys are scoped per user.
+type IdempotencyMiddleware struct {
+	client  goRedis.UniversalClient
+	ttl     time.Duration
+	lockTTL time.Duration
+}
+
+// NewIdempotencyMiddleware creates the middleware, falling back to defaults for unset config values
+func NewIdempotencyMiddleware(client goRedis.UniversalClient, cfg internal.IdempotencyConfig) *IdempotencyMiddleware {
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+	lockTTL := cfg.LockTTL
+	if lockTTL <= 0 {
+		lockTTL = defaultIdempotencyLockTTL
+	}
+
+	return &IdempotencyMiddleware{
+		client:  client,
+		ttl:     ttl,
+		lockTTL: lockTTL,
+	}
+}
+
+// Handler is the chi-compatible middleware.
+// Requests without an Idempotency-Key header are passed through unchanged.
+func (m *IdempotencyMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			internal.HandleEndpointError(w, r, internal.NewValidationError(
+				fmt.Sprintf("%s must not exceed %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength),
+			))
+			return
+		}
+
+		ctx := r.Context()
+		userID, err := internal.ExtractUserID(ctx)
+		if err != nil {
+			internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Authentication required"))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+		if err != nil {
+			internal.HandleEndpointError(w, r, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		redisKey := idempotencyKey(userID, key)
+		fingerprint := requestFingerprint(r, body)
+
+		// 1. Claim the key; only the first request gets to run the handler
+		claimed, err := m.claim(ctx, redisKey, fingerprint)
+		if err != nil {
+			// Fail open: losing idempotency is better than rejecting every request while Redis is down
+			slog.ErrorContext(ctx, "Idempotency store unavailable", slog.Any("error", err))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !claimed {
+			m.handleRetry(w, r, redisKey, fingerprint)
+			return
+		}
+
+		// 2. Run the handler and capture its response
+		rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				m.release(ctx, redisKey)
+				panic(rvr)
+			}
+		}()
+		next.ServeHTTP(rec, r)
+
+		// 3. Server errors are not stored so the client can retry them
+		if rec.statusCode >= http.StatusInternalServerError {
+			m.release(ctx, redisKey)
+			return
+		}
+
+		m.store(ctx, redisKey, &idempotencyRecord{
+			Status:      idempotencyStatusCompleted,
+			Fingerprint: fingerprint,
+			StatusCode:  rec.statusCode,
+			ContentType: rec.Header().Get("Content-Type"),
+			Body:        rec.body.Bytes(),
+		})
+	})
+}
+
+// handleRetry replays a stored response, or rejects the retry if it cannot be replayed
+func (m *IdempotencyMiddleware) handleRetry(w http.ResponseWriter, r *http.Request, redisKey, fingerprint string) {
+	ctx := r.Context()
+
+	record, err := m.load(ctx, redisKey)
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewInternalServerError(err))
+		return
+	}
+
+	switch {
+	case record == nil:
+		// The first request failed and released the key between our claim and load
+		writeConflict(w, r, "A request with this Idempotency-Key was just retried, please try again")
+	case record.Fingerprint != fingerprint:
+		writeConflict(w, r, "Idempotency-Key has already been used with a different request")
+	case record.Status == idempotencyStatusProcessing:
+		writeConflict(w, r, "A request with this Idempotency-Key is still being processed")
+	default:
+		if record.ContentType != "" {
+			w.Header().Set("Content-Type", record.ContentType)
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(record.StatusCode)
+		_, _ = w.Write(record.Body)
+	}
+}
+
+// claim marks the key as processing if nobody used it yet
+func (m *IdempotencyMiddleware) claim(ctx context.Context, redisKey, fingerprint string) (bool, error) {
+	payload, err := json.Marshal(&idempotencyRecord{
+		Status:      idempotencyStatusProcessing,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return m.client.SetNX(ctx, redisKey, payload, m.lockTTL).Result()
+}
+
+func (m *IdempotencyMiddleware) load(ctx context.Context, redisKey string) (*idempotencyRecord, error) {
+	payload, err := m.client.Get(ctx, redisKey).Bytes()
+	if err != nil {
+		if err == goRedis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load idempotency record: %w", err)
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal(payload, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode idempotency record: %w", err)
+	}
+	return &record, nil
+}
+
+func (m *IdempotencyMiddleware) store(ctx context.Context, redisKey string, record *idempotencyRecord) {
+	payload, err := json.Marshal(record)
+	if err == nil {
+		err = m.client.Set(context.WithoutCancel(ctx), redisKey, payload, m.ttl).Err()
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to store idempotent response", slog.Any("error", err))
+	}
+}
+
+func (m *IdempotencyMiddleware) release(ctx context.Context, redisKey string) {
+	if err := m.client.Del(context.WithoutCancel(ctx), redisKey).Err(); err != nil {
+		slog.ErrorContext(ctx, "Failed to release idempotency key", slog.Any("error", err))
+	}
+}
+
+func idempotencyKey(userID int64, key string) string {
+	return fmt.Sprintf("idempotency:user:%d:%s", userID, key)
+}
+
+// requestFingerprint identifies a request by method, path and body
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeConflict(w http.ResponseWriter, r *http.Request, message string) {
+	code := "IDEMPOTENCY_CONFLICT"
+	resp := &v1.DefaultErrorResponse{}
+	resp.Error.Code = &code
+	resp.Error.Message = message
+
+	render.Status(r, http.StatusConflict)
+	render.JSON(w, r, resp)
+}
+
+// responseRecorder passes the response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(statusCode int) {
+	if !rec.wroteHeader {
+		rec.statusCode = statusCode
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
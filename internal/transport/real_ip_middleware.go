@@ -0,0 +1,75 @@
+package transport
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RealIPMiddleware replaces the remote address of requests sent by a trusted proxy with the client
+// address the proxy forwarded. X-Forwarded-For is read from the right, skipping trusted proxies,
+// so clients cannot choose their own address by sending the header themselves. Requests from
+// anywhere else keep their peer address, and nothing is rewritten while no proxy is trusted.
+func RealIPMiddleware(trustedProxies []string) (func(http.Handler) http.Handler, error) {
+	nets := make([]*net.IPNet, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		cidr := proxy
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		nets = append(nets, ipNet)
+	}
+
+	trusted := func(ip net.IP) bool {
+		for _, ipNet := range nets {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(nets) > 0 {
+				if ip := forwardedClientIP(r, trusted); ip != "" {
+					r.RemoteAddr = ip
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// forwardedClientIP returns the first untrusted address in X-Forwarded-For, counting from the proxy
+// that connected to us, or "" if the request did not come through a trusted proxy
+func forwardedClientIP(r *http.Request, trusted func(net.IP) bool) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if peer := net.ParseIP(host); peer == nil || !trusted(peer) {
+		return ""
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			return ""
+		}
+		if !trusted(ip) {
+			return ip.String()
+		}
+	}
+	return ""
+}
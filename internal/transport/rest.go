@@ -10,8 +10,11 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/frahmantamala/jadiles/internal"
+	adminEndpoint "github.com/frahmantamala/jadiles/internal/admin/endpoint"
 	authpkg "github.com/frahmantamala/jadiles/internal/auth"
 	childEndpoint "github.com/frahmantamala/jadiles/internal/child/endpoint"
+	"github.com/frahmantamala/jadiles/internal/payment"
+	paymentEndpoint "github.com/frahmantamala/jadiles/internal/payment/endpoint"
 	serviceEndpoint "github.com/frahmantamala/jadiles/internal/services/endpoint"
 	userEndpoint "github.com/frahmantamala/jadiles/internal/user/endpoint"
 	"github.com/frahmantamala/jadiles/pkg/logger"
@@ -43,13 +46,22 @@ func NewRESTServer(
 			WithRequestBodyDecoder(logger.ByteDecoderJSONObfuscator(logger.SensitiveValueMatcher)).
 			WithResponseBodyDecoder(logger.ByteDecoderJSONObfuscator(logger.SensitiveValueMatcher)).
 			WithAllowedHTTPStatusesResponse(logger.HTTPStatus5xx, logger.HTTPStatus4xx).
-			WithSkipPath("/v1/media/upload"),
+			WithSkipPath("/v1/media/upload").
+			// Its body is a six digit OTP code, which hashing would not hide
+			WithSkipPath("/v1/verify-phone/confirm"),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// Initialize client address resolution behind the configured proxies
+	realIP, err := RealIPMiddleware(config.HTTPServer.GetTrustedProxies())
+	if err != nil {
+		return nil, err
+	}
+
 	routes := chi.NewRouter()
+	routes.Use(realIP)
 	routes.Use(CORSMiddleware(config.HTTPServer.GetAllowedOrigins()))
 	routes.Use(Recoverer)
 	routes.Use(chitrace.Middleware(chitrace.WithServiceName(config.Name)), TraceIDHandler)
@@ -69,7 +81,7 @@ func NewRESTServer(
 			r.Use(logMw.Middleware)
 
 			// Initialize JWT auth for child routes
-			jwtAuth, err := authpkg.NewJWTAuthentication(config.HTTPServer)
+			jwtAuth, err := authpkg.NewJWTAuthentication(config.HTTPServer, authpkg.NewRedisTokenStorage(goRedisClient))
 			if err != nil {
 				routeErr = fmt.Errorf("failed to initialize JWT auth: %w", err)
 				return
@@ -87,11 +99,33 @@ func NewRESTServer(
 				return
 			}
 
-			// Register service routes (public - no auth required)
-			if err := serviceEndpoint.RegisterServiceRoutes(r, gormDB); err != nil {
+			// Initialize the payment gateway shared by payment and booking routes
+			paymentGateway, err := payment.NewGateway(config.Payment)
+			if err != nil {
+				routeErr = fmt.Errorf("failed to initialize payment gateway: %w", err)
+				return
+			}
+
+			// Retried booking and payment creation replay the first response
+			idempotency := NewIdempotencyMiddleware(goRedisClient, config.Idempotency)
+
+			// Register payment routes
+			if err := paymentEndpoint.RegisterPaymentRoutes(r, gormDB, jwtAuth, paymentGateway, idempotency.Handler, config); err != nil {
+				routeErr = fmt.Errorf("failed to register payment routes: %w", err)
+				return
+			}
+
+			// Register service routes (public search and detail, authenticated bookings)
+			if err := serviceEndpoint.RegisterServiceRoutes(r, gormDB, goRedisClient, jwtAuth, paymentGateway, idempotency.Handler, config); err != nil {
 				routeErr = fmt.Errorf("failed to register service routes: %w", err)
 				return
 			}
+
+			// Register admin routes (vendor approval, platform statistics)
+			if err := adminEndpoint.RegisterAdminRoutes(r, gormDB, jwtAuth, config); err != nil {
+				routeErr = fmt.Errorf("failed to register admin routes: %w", err)
+				return
+			}
 		})
 	})
 
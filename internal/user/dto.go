@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/frahmantamala/jadiles/internal"
 	"github.com/frahmantamala/jadiles/internal/core/common"
@@ -193,6 +195,130 @@ func (p *RefreshTokenParams) Validate(ctx context.Context) error {
 	return nil
 }
 
+// VerifyEmailParams represents the token from an email verification link
+type VerifyEmailParams struct {
+	Token string `json:"token" validate:"required,max=512"`
+}
+
+// NewVerifyEmailParams creates VerifyEmailParams from HTTP request
+func NewVerifyEmailParams(r *http.Request) (*VerifyEmailParams, error) {
+	var params VerifyEmailParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("invalid request body")
+	}
+	return &params, nil
+}
+
+// Validate validates VerifyEmailParams
+func (p *VerifyEmailParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ForgotPasswordParams represents a request for a password reset link
+type ForgotPasswordParams struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// NewForgotPasswordParams creates ForgotPasswordParams from HTTP request
+func NewForgotPasswordParams(r *http.Request) (*ForgotPasswordParams, error) {
+	var params ForgotPasswordParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("invalid request body")
+	}
+	return &params, nil
+}
+
+// Validate validates ForgotPasswordParams
+func (p *ForgotPasswordParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ResetPasswordParams represents the token from a reset link and the new password
+type ResetPasswordParams struct {
+	Token       string `json:"token" validate:"required,max=512"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+}
+
+// NewResetPasswordParams creates ResetPasswordParams from HTTP request
+func NewResetPasswordParams(r *http.Request) (*ResetPasswordParams, error) {
+	var params ResetPasswordParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("invalid request body")
+	}
+	return &params, nil
+}
+
+// Validate validates ResetPasswordParams
+func (p *ResetPasswordParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ChangePasswordParams represents a signed in user's password change
+type ChangePasswordParams struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+}
+
+// NewChangePasswordParams creates ChangePasswordParams from HTTP request
+func NewChangePasswordParams(r *http.Request) (*ChangePasswordParams, error) {
+	var params ChangePasswordParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("invalid request body")
+	}
+	return &params, nil
+}
+
+// Validate validates ChangePasswordParams
+func (p *ChangePasswordParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// ConfirmPhoneParams represents the one-time code sent to the user's phone
+type ConfirmPhoneParams struct {
+	Code string `json:"code" validate:"required,len=6,number"`
+}
+
+// NewConfirmPhoneParams creates ConfirmPhoneParams from HTTP request
+func NewConfirmPhoneParams(r *http.Request) (*ConfirmPhoneParams, error) {
+	var params ConfirmPhoneParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil, internal.NewValidationError("invalid request body")
+	}
+	return &params, nil
+}
+
+// Validate validates ConfirmPhoneParams
+func (p *ConfirmPhoneParams) Validate(ctx context.Context) error {
+	return common.ValidateStruct(p)
+}
+
+// PhoneVerificationResponse tells the user where the one-time code was sent
+type PhoneVerificationResponse struct {
+	Message string `json:"message"`
+	Data    struct {
+		Phone            string `json:"phone"`
+		Channel          string `json:"channel"`
+		ExpiresInSeconds int    `json:"expires_in_seconds"`
+	} `json:"data"`
+}
+
+// ToPhoneVerificationResponse builds the response with the phone number masked
+func ToPhoneVerificationResponse(phone, channel string, ttl time.Duration) *PhoneVerificationResponse {
+	resp := &PhoneVerificationResponse{Message: "Verification code sent"}
+	resp.Data.Phone = maskPhone(phone)
+	resp.Data.Channel = channel
+	resp.Data.ExpiresInSeconds = int(ttl.Seconds())
+	return resp
+}
+
+// maskPhone hides all but the country code and the last four digits
+func maskPhone(phone string) string {
+	if len(phone) <= 7 {
+		return phone
+	}
+	return phone[:3] + strings.Repeat("*", len(phone)-7) + phone[len(phone)-4:]
+}
+
 // ToV1User converts domain User to v1.User
 func ToV1User(u *User) v1.User {
 	return v1.User{
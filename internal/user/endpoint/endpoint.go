@@ -3,6 +3,7 @@ package endpoint
 import (
 	"github.com/frahmantamala/jadiles/internal"
 	authpkg "github.com/frahmantamala/jadiles/internal/auth"
+	"github.com/frahmantamala/jadiles/internal/notification"
 	"github.com/frahmantamala/jadiles/internal/user"
 	"github.com/frahmantamala/jadiles/internal/user/postgresql"
 	"github.com/go-chi/chi/v5"
@@ -17,18 +18,44 @@ func RegisterUserRoutes(
 	redisClient goRedis.UniversalClient,
 	config internal.Config,
 ) error {
-	jwtAuth, err := authpkg.NewJWTAuthentication(config.HTTPServer)
+	tokenStorage := authpkg.NewRedisTokenStorage(redisClient)
+
+	jwtAuth, err := authpkg.NewJWTAuthentication(config.HTTPServer, tokenStorage)
 	if err != nil {
 		return err
 	}
 
 	passwordManager := authpkg.NewPasswordManager()
 
-	tokenStorage := authpkg.NewRedisTokenStorage(redisClient)
+	verificationTokens, err := authpkg.NewVerificationTokenStore(redisClient, config.HTTPServer)
+	if err != nil {
+		return err
+	}
+
+	otpStore, err := authpkg.NewOTPStore(redisClient, config.HTTPServer)
+	if err != nil {
+		return err
+	}
+
+	rateLimiter := authpkg.NewRedisRateLimiter(redisClient)
+
+	sender, err := notification.NewSender(config.Notification)
+	if err != nil {
+		return err
+	}
+
+	otpChannel, err := notification.NewOTPChannel(config.Verification.Phone.Channel, config.Notification)
+	if err != nil {
+		return err
+	}
 
 	repo := postgresql.NewUserRepository(db)
 
-	userService := user.NewService(repo, jwtAuth, passwordManager, tokenStorage)
+	userService := user.NewService(
+		repo, jwtAuth, passwordManager, tokenStorage,
+		verificationTokens, otpStore, rateLimiter, sender, otpChannel,
+		config,
+	)
 
 	userHandler := user.NewHandler(userService)
 	// Public routes (no authentication required)
@@ -37,6 +64,9 @@ func RegisterUserRoutes(
 		r.Post("/register/vendor", userHandler.RegisterVendor)
 		r.Post("/login", userHandler.Login)
 		r.Post("/refresh", userHandler.RefreshToken)
+		r.Post("/verify-email", userHandler.VerifyEmail)
+		r.Post("/password/forgot", userHandler.ForgotPassword)
+		r.Post("/password/reset", userHandler.ResetPassword)
 	})
 
 	r.Group(func(r chi.Router) {
@@ -44,6 +74,10 @@ func RegisterUserRoutes(
 
 		r.Post("/logout", userHandler.Logout)
 		r.Get("/me", userHandler.GetProfile)
+		r.Post("/verify-email/resend", userHandler.ResendVerificationEmail)
+		r.Post("/verify-phone/request", userHandler.RequestPhoneVerification)
+		r.Post("/verify-phone/confirm", userHandler.ConfirmPhoneVerification)
+		r.Post("/password/change", userHandler.ChangePassword)
 	})
 
 	return nil
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"net"
 	"net/http"
 
 	"github.com/frahmantamala/jadiles/internal"
@@ -16,6 +17,13 @@ type ServiceAPI interface {
 	Logout(ctx context.Context, userID int64, token string) error
 	RefreshToken(ctx context.Context, refreshToken string) (*v1.LoginResponse, error)
 	GetUserByID(ctx context.Context, userID int64) (*User, error)
+	VerifyEmail(ctx context.Context, clientIP string, params *VerifyEmailParams) error
+	ResendVerificationEmail(ctx context.Context, userID int64) error
+	RequestPhoneVerification(ctx context.Context, userID int64) (*PhoneVerificationResponse, error)
+	ConfirmPhoneVerification(ctx context.Context, userID int64, params *ConfirmPhoneParams) error
+	ForgotPassword(ctx context.Context, clientIP string, params *ForgotPasswordParams) error
+	ResetPassword(ctx context.Context, clientIP string, params *ResetPasswordParams) error
+	ChangePassword(ctx context.Context, userID int64, params *ChangePasswordParams) (*v1.LoginResponse, error)
 }
 
 type Handler struct {
@@ -167,17 +175,203 @@ func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	// Convert to response
 	resp := map[string]interface{}{
 		"data": map[string]interface{}{
-			"id":         user.ID,
-			"email":      user.Email,
-			"full_name":  user.FullName,
-			"phone":      user.Phone,
-			"role":       user.Role,
-			"status":     user.Status,
-			"created_at": user.CreatedAt,
-			"updated_at": user.UpdatedAt,
+			"id":             user.ID,
+			"email":          user.Email,
+			"full_name":      user.FullName,
+			"phone":          user.Phone,
+			"role":           user.Role,
+			"status":         user.Status,
+			"email_verified": user.EmailVerified,
+			"phone_verified": user.PhoneVerified,
+			"created_at":     user.CreatedAt,
+			"updated_at":     user.UpdatedAt,
 		},
 	}
 
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
+
+// VerifyEmail handles email verification from the link sent to the user
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	params, err := NewVerifyEmailParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(r.Context()); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := h.service.VerifyEmail(r.Context(), clientIP(r), params); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "Email verified successfully",
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ResendVerificationEmail handles sending a new verification link (requires authentication)
+func (h *Handler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	userID, err := internal.ExtractUserID(r.Context())
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Unauthorized"))
+		return
+	}
+
+	if err := h.service.ResendVerificationEmail(r.Context(), userID); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "Verification email sent",
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// RequestPhoneVerification handles sending a one-time code to the user's phone (requires authentication)
+func (h *Handler) RequestPhoneVerification(w http.ResponseWriter, r *http.Request) {
+	userID, err := internal.ExtractUserID(r.Context())
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Unauthorized"))
+		return
+	}
+
+	resp, err := h.service.RequestPhoneVerification(r.Context(), userID)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// ConfirmPhoneVerification handles confirming the one-time code (requires authentication)
+func (h *Handler) ConfirmPhoneVerification(w http.ResponseWriter, r *http.Request) {
+	userID, err := internal.ExtractUserID(r.Context())
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Unauthorized"))
+		return
+	}
+
+	params, err := NewConfirmPhoneParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(r.Context()); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := h.service.ConfirmPhoneVerification(r.Context(), userID, params); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "Phone number verified successfully",
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ForgotPassword handles requesting a password reset link.
+// The response is the same whether or not the email belongs to an account.
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	params, err := NewForgotPasswordParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(r.Context()); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := h.service.ForgotPassword(r.Context(), clientIP(r), params); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "If an account exists for this email, a password reset link has been sent",
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ResetPassword handles setting a new password from a reset link
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	params, err := NewResetPasswordParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(r.Context()); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := h.service.ResetPassword(r.Context(), clientIP(r), params); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message": "Password has been reset, please log in with your new password",
+	}
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// ChangePassword handles changing the password of a signed in user (requires authentication)
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, err := internal.ExtractUserID(r.Context())
+	if err != nil {
+		internal.HandleEndpointError(w, r, internal.NewUnauthorizedError("Unauthorized"))
+		return
+	}
+
+	params, err := NewChangePasswordParams(r)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	if err := params.Validate(r.Context()); err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	resp, err := h.service.ChangePassword(r.Context(), userID, params)
+	if err != nil {
+		internal.HandleEndpointError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// clientIP returns the address of the client the request came from. Behind a trusted proxy
+// RealIPMiddleware has already replaced the proxy's address with the forwarded one.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,182 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	authpkg "github.com/frahmantamala/jadiles/internal/auth"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/notification"
+	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+)
+
+const (
+	defaultPasswordResetTokenTTL = time.Hour
+	defaultPasswordResetRequests = 3
+	defaultPasswordResetWindow   = time.Hour
+)
+
+// withPasswordResetDefaults fills in the settings left out of config
+func withPasswordResetDefaults(cfg internal.PasswordResetConfig) internal.PasswordResetConfig {
+	if cfg.TokenTTL <= 0 {
+		cfg.TokenTTL = defaultPasswordResetTokenTTL
+	}
+	if cfg.MaxRequests <= 0 {
+		cfg.MaxRequests = defaultPasswordResetRequests
+	}
+	if cfg.RequestWindow <= 0 {
+		cfg.RequestWindow = defaultPasswordResetWindow
+	}
+	return cfg
+}
+
+// ForgotPassword emails a reset link if an active account uses the email. The outcome is the
+// same whether or not the account exists, so the endpoint cannot be used to find accounts;
+// only the per-client rate limit is reported.
+func (s *Service) ForgotPassword(ctx context.Context, clientIP string, params *ForgotPasswordParams) error {
+	if err := s.allow(ctx, "password_forgot:ip:"+clientIP, s.attemptsPerMinute, time.Minute); err != nil {
+		return err
+	}
+
+	userDM, err := s.repo.GetUserByEmail(ctx, params.Email)
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if userDM == nil {
+		return nil
+	}
+
+	domainUser := &User{ID: userDM.ID, Status: UserStatus(userDM.Status)}
+	if domainUser.CanLogin() != nil {
+		return nil
+	}
+
+	// Exceeding the per-account limit is not reported, it would confirm the account exists
+	cfg := s.passwordReset
+	allowed, _, err := s.rateLimiter.Allow(ctx, fmt.Sprintf("password_forgot:user:%d", userDM.ID), cfg.MaxRequests, cfg.RequestWindow)
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if !allowed {
+		return nil
+	}
+
+	// Sent in the background so the response time does not tell whether the account exists
+	sendCtx := internal.NewContextFrom(ctx)
+	go func() {
+		if err := s.sendPasswordResetEmail(sendCtx, userDM); err != nil {
+			slog.WarnContext(sendCtx, "Failed to send password reset email",
+				slog.Int64("user_id", userDM.ID),
+				slog.Any("error", err),
+			)
+		}
+	}()
+
+	return nil
+}
+
+// ResetPassword sets a new password from a reset link. The link works once, and every session of
+// the user is revoked so a stolen session does not survive the reset.
+func (s *Service) ResetPassword(ctx context.Context, clientIP string, params *ResetPasswordParams) error {
+	if err := s.allow(ctx, "password_reset:ip:"+clientIP, s.attemptsPerMinute, time.Minute); err != nil {
+		return err
+	}
+
+	// Check the new password first so a weak one does not use up the link
+	if err := (&User{}).ValidatePassword(params.NewPassword); err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+
+	userID, err := s.verificationTokens.Consume(ctx, authpkg.PurposePasswordReset, params.Token)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidToken) || errors.Is(err, internal.ErrInvalidSignature) {
+			return internal.NewValidationError("Reset link is invalid or has expired")
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	if err := s.setPassword(ctx, userID, params.NewPassword); err != nil {
+		return err
+	}
+
+	if err := s.tokenStorage.InvalidateAllUserTokens(ctx, userID); err != nil {
+		return internal.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// ChangePassword replaces the password of a signed in user after checking the current one.
+// Every other session is revoked and the caller gets a new token pair.
+func (s *Service) ChangePassword(ctx context.Context, userID int64, params *ChangePasswordParams) (*v1.LoginResponse, error) {
+	if err := s.allow(ctx, fmt.Sprintf("password_change:user:%d", userID), s.attemptsPerMinute, time.Minute); err != nil {
+		return nil, err
+	}
+
+	userDM, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("User")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if err := s.passwordManager.VerifyPassword(userDM.PasswordHash, params.CurrentPassword); err != nil {
+		return nil, internal.NewValidationError("Current password is incorrect")
+	}
+
+	if params.NewPassword == params.CurrentPassword {
+		return nil, internal.NewValidationError("New password must be different from the current password")
+	}
+
+	domainUser := &User{ID: userDM.ID}
+	if err := domainUser.ValidatePassword(params.NewPassword); err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	if err := s.setPassword(ctx, userID, params.NewPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenStorage.InvalidateAllUserTokens(ctx, userID); err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return s.issueTokens(ctx, userDM)
+}
+
+func (s *Service) setPassword(ctx context.Context, userID int64, password string) error {
+	hashedPassword, err := s.passwordManager.HashPassword(password)
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+
+	if err := s.repo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		if err == sql.ErrNoRows {
+			return internal.NewNotFoundError("User")
+		}
+		return internal.NewInternalServerError(err)
+	}
+	return nil
+}
+
+func (s *Service) sendPasswordResetEmail(ctx context.Context, userDM *datamodel.User) error {
+	token, err := s.verificationTokens.Issue(ctx, authpkg.PurposePasswordReset, userDM.ID, s.passwordReset.TokenTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.appURL + "/reset-password?token=" + url.QueryEscape(token)
+	return s.sender.Send(ctx, &notification.Message{
+		To:      userDM.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link expires in %s and can be used once. If you did not ask for this, you can ignore this email; your password stays the same.",
+			userDM.FullName, link, formatTTL(s.passwordReset.TokenTTL)),
+	})
+}
@@ -0,0 +1,119 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	authpkg "github.com/frahmantamala/jadiles/internal/auth"
+)
+
+const (
+	defaultPhoneCodeTTL        = 5 * time.Minute
+	defaultPhoneMaxAttempts    = 5
+	defaultPhoneResendCooldown = time.Minute
+	defaultPhoneMaxRequests    = 5
+	defaultPhoneRequestWindow  = time.Hour
+)
+
+// withPhoneVerificationDefaults fills in the settings left out of config
+func withPhoneVerificationDefaults(cfg internal.PhoneVerificationConfig) internal.PhoneVerificationConfig {
+	if cfg.CodeTTL <= 0 {
+		cfg.CodeTTL = defaultPhoneCodeTTL
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultPhoneMaxAttempts
+	}
+	if cfg.ResendCooldown <= 0 {
+		cfg.ResendCooldown = defaultPhoneResendCooldown
+	}
+	if cfg.MaxRequests <= 0 {
+		cfg.MaxRequests = defaultPhoneMaxRequests
+	}
+	if cfg.RequestWindow <= 0 {
+		cfg.RequestWindow = defaultPhoneRequestWindow
+	}
+	return cfg
+}
+
+// RequestPhoneVerification sends a one-time code to the user's phone number. Requests are limited
+// by a cooldown per user and a maximum per window for each number, so a number shared by several
+// accounts cannot be flooded either.
+func (s *Service) RequestPhoneVerification(ctx context.Context, userID int64) (*PhoneVerificationResponse, error) {
+	userDM, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, internal.NewNotFoundError("User")
+		}
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if userDM.PhoneVerified {
+		return nil, internal.NewBusinessRuleError("Phone number is already verified", internal.ErrInvalidState)
+	}
+
+	phone, err := NormalizePhone(userDM.Phone)
+	if err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+
+	cfg := s.phoneVerification
+	if err := s.allow(ctx, fmt.Sprintf("verify_phone_request:cooldown:%d", userID), 1, cfg.ResendCooldown); err != nil {
+		return nil, err
+	}
+	if err := s.allow(ctx, "verify_phone_request:phone:"+phone, cfg.MaxRequests, cfg.RequestWindow); err != nil {
+		return nil, err
+	}
+
+	code, err := s.otpStore.Issue(ctx, authpkg.PurposePhoneVerification, userID, phone, cfg.CodeTTL, cfg.MaxAttempts)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if err := s.otpChannel.SendCode(ctx, phone, code, cfg.CodeTTL); err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	return ToPhoneVerificationResponse(phone, s.otpChannel.Name(), cfg.CodeTTL), nil
+}
+
+// ConfirmPhoneVerification checks the code sent to the user's phone number and marks the number verified.
+// After too many wrong codes the code is discarded and a new one has to be requested.
+func (s *Service) ConfirmPhoneVerification(ctx context.Context, userID int64, params *ConfirmPhoneParams) error {
+	userDM, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return internal.NewNotFoundError("User")
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	phone, err := NormalizePhone(userDM.Phone)
+	if err != nil {
+		return internal.NewValidationError(err.Error())
+	}
+
+	if err := s.otpStore.Verify(ctx, authpkg.PurposePhoneVerification, userID, phone, params.Code); err != nil {
+		switch {
+		case errors.Is(err, authpkg.ErrOTPMismatch):
+			return internal.NewValidationError("Invalid verification code")
+		case errors.Is(err, authpkg.ErrOTPExpired):
+			return internal.NewValidationError("Verification code has expired, please request a new one")
+		case errors.Is(err, authpkg.ErrOTPAttemptsExceeded):
+			return internal.NewRateLimitError("Too many wrong codes, please request a new one")
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	if err := s.repo.MarkPhoneVerified(ctx, userID, phone); err != nil {
+		if err == sql.ErrNoRows {
+			return internal.NewNotFoundError("User")
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	return nil
+}
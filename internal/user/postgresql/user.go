@@ -2,6 +2,8 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/frahmantamala/jadiles/internal/core/datamodel"
 	"gorm.io/gorm"
@@ -32,12 +34,73 @@ func (r *Repository) GetUserByID(ctx context.Context, id int64) (*datamodel.User
 		First(&user).Error
 
 	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, sql.ErrNoRows
+		}
 		return nil, err
 	}
 
 	return &user, nil
 }
 
+// MarkEmailVerified records that the user proved they own their email address
+func (r *Repository) MarkEmailVerified(ctx context.Context, userID int64) error {
+	result := r.db.WithContext(ctx).
+		Model(&datamodel.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"email_verified": true,
+			"version":        gorm.Expr("version + 1"),
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// MarkPhoneVerified records that the user confirmed the phone number, stored in E.164
+func (r *Repository) MarkPhoneVerified(ctx context.Context, userID int64, phone string) error {
+	result := r.db.WithContext(ctx).
+		Model(&datamodel.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"phone":          phone,
+			"phone_verified": true,
+			"version":        gorm.Expr("version + 1"),
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpdatePassword replaces the user's password hash
+func (r *Repository) UpdatePassword(ctx context.Context, userID int64, passwordHash string) error {
+	result := r.db.WithContext(ctx).
+		Model(&datamodel.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"password_hash": passwordHash,
+			"version":       gorm.Expr("version + 1"),
+			"updated_at":    time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 // CreateUser creates a new user
 func (r *Repository) CreateUser(ctx context.Context, user *datamodel.User) error {
 	return r.db.WithContext(ctx).Create(user).Error
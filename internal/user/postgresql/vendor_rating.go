@@ -0,0 +1,40 @@
+package postgresql
+
+import (
+	"math"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"gorm.io/gorm"
+)
+
+// RecomputeVendorRating sets a vendor's rating and review count from its published reviews, inside the
+// caller's transaction. The vendor is locked first so concurrent review changes apply one at a time.
+// The average is always taken over the reviews themselves: rating_avg is stored rounded to two decimals,
+// so folding a new rating into the stored average would drift a little with every review.
+func RecomputeVendorRating(tx *gorm.DB, vendorID int64, at time.Time) error {
+	if err := tx.Exec(`SELECT id FROM vendors WHERE id = ? FOR UPDATE`, vendorID).Error; err != nil {
+		return err
+	}
+
+	var rating struct {
+		RatingAvg    float64
+		TotalReviews int
+	}
+	if err := tx.Raw(`
+		SELECT COALESCE(AVG(rating), 0) AS rating_avg, COUNT(*) AS total_reviews
+		FROM reviews
+		WHERE vendor_id = ? AND is_approved = true
+	`, vendorID).Scan(&rating).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&datamodel.Vendor{}).
+		Where("id = ?", vendorID).
+		Updates(map[string]interface{}{
+			"rating_avg":    math.Round(rating.RatingAvg*100) / 100,
+			"total_reviews": rating.TotalReviews,
+			"version":       gorm.Expr("version + 1"),
+			"updated_at":    at,
+		}).Error
+}
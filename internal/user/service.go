@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/frahmantamala/jadiles/internal"
 	authpkg "github.com/frahmantamala/jadiles/internal/auth"
 	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/notification"
 	v1 "github.com/frahmantamala/jadiles/pkg/openapi/v1"
+	"github.com/google/uuid"
 )
 
 type Repository interface {
@@ -21,20 +25,54 @@ type Repository interface {
 	// Transaction-based methods
 	CreateParentWithProfile(ctx context.Context, user *datamodel.User, profile *datamodel.ParentProfile) error
 	CreateVendorWithBusiness(ctx context.Context, user *datamodel.User, vendor *datamodel.Vendor) error
+	MarkEmailVerified(ctx context.Context, userID int64) error
+	MarkPhoneVerified(ctx context.Context, userID int64, phone string) error
+	UpdatePassword(ctx context.Context, userID int64, passwordHash string) error
 }
 
+// TokenStorage tracks issued tokens by jti and refresh token families
 type TokenStorage interface {
-	StoreToken(ctx context.Context, userID int64, token string, expiry time.Duration) error
-	IsTokenValid(ctx context.Context, userID int64, token string) (bool, error)
-	InvalidateToken(ctx context.Context, userID int64, token string) error
+	StoreToken(ctx context.Context, userID int64, tokenID string, expiry time.Duration) error
+	IsTokenValid(ctx context.Context, userID int64, tokenID string) (bool, error)
+	InvalidateToken(ctx context.Context, userID int64, tokenID string) error
 	InvalidateAllUserTokens(ctx context.Context, userID int64) error
+	StartRefreshFamily(ctx context.Context, userID int64, familyID, tokenID string, expiry time.Duration) error
+	RotateRefreshFamily(ctx context.Context, userID int64, familyID, currentTokenID, nextTokenID string, expiry time.Duration) error
+	RevokeRefreshFamily(ctx context.Context, userID int64, familyID string) error
+}
+
+// VerificationTokenStore issues and consumes the single-use tokens sent in verification links
+type VerificationTokenStore interface {
+	Issue(ctx context.Context, purpose string, userID int64, ttl time.Duration) (string, error)
+	Consume(ctx context.Context, purpose string, token string) (int64, error)
+}
+
+// OTPStore issues and checks the one-time codes sent to phone numbers
+type OTPStore interface {
+	Issue(ctx context.Context, purpose string, userID int64, target string, ttl time.Duration, maxAttempts int) (string, error)
+	Verify(ctx context.Context, purpose string, userID int64, target, code string) error
+}
+
+// RateLimiter limits attempts per key within a window
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error)
 }
 
 type Service struct {
-	repo            Repository
-	jwtAuth         *authpkg.JWTAuthentication
-	passwordManager *authpkg.PasswordManager
-	tokenStorage    TokenStorage
+	repo               Repository
+	jwtAuth            *authpkg.JWTAuthentication
+	passwordManager    *authpkg.PasswordManager
+	tokenStorage       TokenStorage
+	verificationTokens VerificationTokenStore
+	otpStore           OTPStore
+	rateLimiter        RateLimiter
+	sender             notification.Sender
+	otpChannel         notification.OTPChannel
+	emailVerification  internal.EmailVerificationConfig
+	phoneVerification  internal.PhoneVerificationConfig
+	passwordReset      internal.PasswordResetConfig
+	appURL             string
+	attemptsPerMinute  int
 }
 
 func NewService(
@@ -42,12 +80,33 @@ func NewService(
 	jwtAuth *authpkg.JWTAuthentication,
 	passwordManager *authpkg.PasswordManager,
 	tokenStorage TokenStorage,
+	verificationTokens VerificationTokenStore,
+	otpStore OTPStore,
+	rateLimiter RateLimiter,
+	sender notification.Sender,
+	otpChannel notification.OTPChannel,
+	config internal.Config,
 ) *Service {
+	attemptsPerMinute := config.RateLimit.AuthEndpoints.RequestsPerMinute
+	if attemptsPerMinute <= 0 {
+		attemptsPerMinute = defaultAuthAttemptsPerMinute
+	}
+
 	return &Service{
-		repo:            repo,
-		jwtAuth:         jwtAuth,
-		passwordManager: passwordManager,
-		tokenStorage:    tokenStorage,
+		repo:               repo,
+		jwtAuth:            jwtAuth,
+		passwordManager:    passwordManager,
+		tokenStorage:       tokenStorage,
+		verificationTokens: verificationTokens,
+		otpStore:           otpStore,
+		rateLimiter:        rateLimiter,
+		sender:             sender,
+		otpChannel:         otpChannel,
+		emailVerification:  withEmailVerificationDefaults(config.Verification.Email),
+		phoneVerification:  withPhoneVerificationDefaults(config.Verification.Phone),
+		passwordReset:      withPasswordResetDefaults(config.Verification.PasswordReset),
+		appURL:             strings.TrimRight(config.Notification.AppURL, "/"),
+		attemptsPerMinute:  attemptsPerMinute,
 	}
 }
 
@@ -76,6 +135,13 @@ func (s *Service) RegisterParent(ctx context.Context, params *RegisterParentPara
 		return nil, internal.NewValidationError(err.Error())
 	}
 
+	// Store the phone in E.164 so the same number is always written the same way
+	phone, err := NormalizePhone(params.Phone)
+	if err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+	params.Phone = phone
+
 	hashedPassword, err := s.passwordManager.HashPassword(params.Password)
 	if err != nil {
 		return nil, internal.NewInternalServerError(err)
@@ -104,6 +170,8 @@ func (s *Service) RegisterParent(ctx context.Context, params *RegisterParentPara
 		return nil, internal.NewInternalServerError(err)
 	}
 
+	s.sendVerificationEmailAfterRegistration(ctx, userDM)
+
 	// Build response
 	resp := &v1.RegisterResponse{}
 	resp.Data.User = v1.User{
@@ -116,6 +184,8 @@ func (s *Service) RegisterParent(ctx context.Context, params *RegisterParentPara
 		CreatedAt: &userDM.CreatedAt,
 	}
 
+	message := "Registration successful. Please check your email to verify your account."
+	resp.Message = &message
 	return resp, nil
 }
 
@@ -144,6 +214,13 @@ func (s *Service) RegisterVendor(ctx context.Context, params *RegisterVendorPara
 		return nil, internal.NewValidationError(err.Error())
 	}
 
+	// Store the phone in E.164 so the same number is always written the same way
+	phone, err := NormalizePhone(params.Phone)
+	if err != nil {
+		return nil, internal.NewValidationError(err.Error())
+	}
+	params.Phone = phone
+
 	domainVendor := &Vendor{
 		BusinessName: params.BusinessName,
 		BusinessType: params.BusinessType,
@@ -204,6 +281,8 @@ func (s *Service) RegisterVendor(ctx context.Context, params *RegisterVendorPara
 		return nil, internal.NewInternalServerError(err)
 	}
 
+	s.sendVerificationEmailAfterRegistration(ctx, userDM)
+
 	resp := &v1.RegisterVendorResponse{}
 	resp.Data.User = v1.User{
 		Id:        &userDM.ID,
@@ -223,7 +302,7 @@ func (s *Service) RegisterVendor(ctx context.Context, params *RegisterVendorPara
 		Status:       (*v1.VendorStatus)(&vendorDM.Status),
 	}
 
-	message := "Vendor registration successful. Awaiting admin approval."
+	message := "Vendor registration successful. Please verify your email while your account awaits admin approval."
 	resp.Message = &message
 	return resp, nil
 }
@@ -258,34 +337,7 @@ func (s *Service) Login(ctx context.Context, params *LoginParams) (*v1.LoginResp
 		return nil, internal.NewForbiddenError(err.Error())
 	}
 
-	// Generate access token
-	accessToken, _, err := s.jwtAuth.GenerateAccessToken(ctx, userDM.ID, userDM.Email, userDM.Role)
-	if err != nil {
-		return nil, internal.NewInternalServerError(err)
-	}
-
-	// Store access token
-	if err := s.tokenStorage.StoreToken(ctx, userDM.ID, accessToken, s.jwtAuth.AccessTokenDuration()); err != nil {
-		return nil, internal.NewInternalServerError(err)
-	}
-
-	// Generate refresh token
-	refreshToken, _, err := s.jwtAuth.GenerateRefreshToken(ctx, userDM.ID, userDM.Email, userDM.Role)
-	if err != nil {
-		return nil, internal.NewInternalServerError(err)
-	}
-
-	// Store refresh token
-	if err := s.tokenStorage.StoreToken(ctx, userDM.ID, refreshToken, s.jwtAuth.RefreshTokenDuration()); err != nil {
-		return nil, internal.NewInternalServerError(err)
-	}
-
-	// Build response
-	resp := &v1.LoginResponse{}
-	resp.Data.Token = accessToken
-	resp.Data.RefreshToken = refreshToken
-
-	return resp, nil
+	return s.issueTokens(ctx, userDM)
 }
 
 func (s *Service) Logout(ctx context.Context, userID int64, token string) error {
@@ -293,8 +345,10 @@ func (s *Service) Logout(ctx context.Context, userID int64, token string) error
 		return internal.NewInternalServerError(errors.New("authentication service not initialized"))
 	}
 	// Invalidate the access token
-	if err := s.tokenStorage.InvalidateToken(ctx, userID, token); err != nil {
-		return internal.NewInternalServerError(err)
+	if claims, err := s.jwtAuth.ParseAccessToken(ctx, token); err == nil {
+		if err := s.tokenStorage.InvalidateToken(ctx, userID, claims.JTI); err != nil {
+			return internal.NewInternalServerError(err)
+		}
 	}
 
 	// Optionally: invalidate all user tokens for complete logout
@@ -305,6 +359,9 @@ func (s *Service) Logout(ctx context.Context, userID int64, token string) error
 	return nil
 }
 
+// RefreshToken exchanges a refresh token for a new access and refresh token pair. Each refresh
+// token works once: it rotates its family to the new token, and presenting an already rotated
+// token again revokes the whole family so both the thief and the user have to log in again.
 func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*v1.LoginResponse, error) {
 	if s == nil || s.jwtAuth == nil || s.tokenStorage == nil || s.repo == nil {
 		return nil, internal.NewInternalServerError(errors.New("authentication service not initialized"))
@@ -315,9 +372,8 @@ func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*v1.Lo
 		return nil, internal.NewUnauthorizedError("Invalid refresh token")
 	}
 
-	// Check if refresh token is still valid in storage
-	valid, err := s.tokenStorage.IsTokenValid(ctx, claims.UserID, refreshToken)
-	if err != nil || !valid {
+	// Tokens issued before rotation belong to no family, their users have to log in again
+	if claims.FamilyID == "" || claims.JTI == "" {
 		return nil, internal.NewUnauthorizedError("Refresh token has been revoked")
 	}
 
@@ -339,21 +395,86 @@ func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*v1.Lo
 		return nil, internal.NewForbiddenError(err.Error())
 	}
 
-	// Generate new access token
-	accessToken, _, err := s.jwtAuth.GenerateAccessToken(ctx, userDM.ID, userDM.Email, userDM.Role)
+	// Issue the new pair before spending the presented refresh token, so a failure here leaves it usable
+	accessToken, accessClaims, err := s.jwtAuth.GenerateAccessToken(ctx, userDM.ID, userDM.Email, userDM.Role)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	if err := s.tokenStorage.StoreToken(ctx, userDM.ID, accessClaims.JTI, s.jwtAuth.AccessTokenDuration()); err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	nextRefreshToken, nextClaims, err := s.jwtAuth.GenerateRefreshToken(ctx, userDM.ID, userDM.Email, userDM.Role, claims.FamilyID)
 	if err != nil {
+		s.discardAccessToken(ctx, userDM.ID, accessClaims.JTI)
 		return nil, internal.NewInternalServerError(err)
 	}
 
-	// Store new access token
-	if err := s.tokenStorage.StoreToken(ctx, userDM.ID, accessToken, s.jwtAuth.AccessTokenDuration()); err != nil {
+	// Rotate the family to the new refresh token, spending the presented one
+	err = s.tokenStorage.RotateRefreshFamily(ctx, userDM.ID, claims.FamilyID, claims.JTI, nextClaims.JTI, s.jwtAuth.RefreshTokenDuration())
+	if err != nil {
+		s.discardAccessToken(ctx, userDM.ID, accessClaims.JTI)
+		switch {
+		case errors.Is(err, internal.ErrRefreshTokenIsBlocked):
+			slog.WarnContext(ctx, "Refresh token reuse detected, token family revoked",
+				slog.Int64("user_id", userDM.ID),
+				slog.String("family_id", claims.FamilyID),
+			)
+			return nil, internal.NewUnauthorizedError("Refresh token has already been used, please log in again")
+		case errors.Is(err, internal.ErrRefreshTokenNotFound):
+			return nil, internal.NewUnauthorizedError("Refresh token has been revoked")
+		}
 		return nil, internal.NewInternalServerError(err)
 	}
 
 	// Build response
 	resp := &v1.LoginResponse{}
 	resp.Data.Token = accessToken
-	resp.Data.RefreshToken = refreshToken // Keep the same refresh token
+	resp.Data.RefreshToken = nextRefreshToken
+
+	return resp, nil
+}
+
+// discardAccessToken revokes an access token that was stored but never handed out
+func (s *Service) discardAccessToken(ctx context.Context, userID int64, tokenID string) {
+	if err := s.tokenStorage.InvalidateToken(ctx, userID, tokenID); err != nil {
+		slog.WarnContext(ctx, "Failed to discard unused access token",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// issueTokens creates an access token and the first refresh token of a new token family
+func (s *Service) issueTokens(ctx context.Context, userDM *datamodel.User) (*v1.LoginResponse, error) {
+	// Generate access token
+	accessToken, accessClaims, err := s.jwtAuth.GenerateAccessToken(ctx, userDM.ID, userDM.Email, userDM.Role)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Store access token
+	if err := s.tokenStorage.StoreToken(ctx, userDM.ID, accessClaims.JTI, s.jwtAuth.AccessTokenDuration()); err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Generate refresh token in a new family
+	familyID := uuid.NewString()
+	refreshToken, refreshClaims, err := s.jwtAuth.GenerateRefreshToken(ctx, userDM.ID, userDM.Email, userDM.Role, familyID)
+	if err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Start the family with this refresh token
+	if err := s.tokenStorage.StartRefreshFamily(ctx, userDM.ID, familyID, refreshClaims.JTI, s.jwtAuth.RefreshTokenDuration()); err != nil {
+		return nil, internal.NewInternalServerError(err)
+	}
+
+	// Build response
+	resp := &v1.LoginResponse{}
+	resp.Data.Token = accessToken
+	resp.Data.RefreshToken = refreshToken
 
 	return resp, nil
 }
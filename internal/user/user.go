@@ -117,15 +117,29 @@ func (u *User) ValidateEmail() error {
 
 // ValidatePhone validates phone number format
 func (u *User) ValidatePhone() error {
-	if u.Phone == "" {
-		return fmt.Errorf("phone is required")
+	_, err := NormalizePhone(u.Phone)
+	return err
+}
+
+// NormalizePhone validates an Indonesian phone number and converts it to E.164,
+// so 0812..., 62812... and +62812... are the same number +62812...
+func NormalizePhone(phone string) (string, error) {
+	if phone == "" {
+		return "", fmt.Errorf("phone is required")
 	}
 	// Remove spaces and dashes
-	cleanPhone := strings.ReplaceAll(strings.ReplaceAll(u.Phone, " ", ""), "-", "")
+	cleanPhone := strings.ReplaceAll(strings.ReplaceAll(phone, " ", ""), "-", "")
 	if !phoneRegex.MatchString(cleanPhone) {
-		return fmt.Errorf("invalid phone format, must be Indonesian phone number")
+		return "", fmt.Errorf("invalid phone format, must be Indonesian phone number")
 	}
-	return nil
+
+	switch {
+	case strings.HasPrefix(cleanPhone, "+62"):
+		return cleanPhone, nil
+	case strings.HasPrefix(cleanPhone, "62"):
+		return "+" + cleanPhone, nil
+	}
+	return "+62" + strings.TrimPrefix(cleanPhone, "0"), nil
 }
 
 // ValidateFullName validates full name
@@ -423,21 +437,6 @@ func (v *Vendor) Suspend(reason string) {
 	v.UpdatedAt = time.Now()
 }
 
-// UpdateRating updates vendor rating
-func (v *Vendor) UpdateRating(newRating float64) error {
-	if newRating < 0 || newRating > 5 {
-		return fmt.Errorf("rating must be between 0 and 5")
-	}
-
-	// Calculate new average
-	totalRating := v.RatingAvg * float64(v.TotalReviews)
-	v.TotalReviews++
-	v.RatingAvg = (totalRating + newRating) / float64(v.TotalReviews)
-	v.UpdatedAt = time.Now()
-
-	return nil
-}
-
 // IncrementBooking increments total bookings
 func (v *Vendor) IncrementBooking() {
 	v.TotalBookings++
@@ -0,0 +1,152 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/url"
+	"time"
+
+	"github.com/frahmantamala/jadiles/internal"
+	authpkg "github.com/frahmantamala/jadiles/internal/auth"
+	"github.com/frahmantamala/jadiles/internal/core/datamodel"
+	"github.com/frahmantamala/jadiles/internal/notification"
+)
+
+const (
+	defaultEmailTokenTTL         = 24 * time.Hour
+	defaultEmailResendCooldown   = time.Minute
+	defaultEmailMaxResends       = 5
+	defaultEmailResendWindow     = time.Hour
+	defaultAuthAttemptsPerMinute = 10
+)
+
+// withEmailVerificationDefaults fills in the settings left out of config
+func withEmailVerificationDefaults(cfg internal.EmailVerificationConfig) internal.EmailVerificationConfig {
+	if cfg.TokenTTL <= 0 {
+		cfg.TokenTTL = defaultEmailTokenTTL
+	}
+	if cfg.ResendCooldown <= 0 {
+		cfg.ResendCooldown = defaultEmailResendCooldown
+	}
+	if cfg.MaxResends <= 0 {
+		cfg.MaxResends = defaultEmailMaxResends
+	}
+	if cfg.ResendWindow <= 0 {
+		cfg.ResendWindow = defaultEmailResendWindow
+	}
+	return cfg
+}
+
+// VerifyEmail marks the email of the link's user as verified. The link works once and
+// attempts are limited per client so tokens cannot be guessed.
+func (s *Service) VerifyEmail(ctx context.Context, clientIP string, params *VerifyEmailParams) error {
+	if err := s.allow(ctx, "verify_email:ip:"+clientIP, s.attemptsPerMinute, time.Minute); err != nil {
+		return err
+	}
+
+	userID, err := s.verificationTokens.Consume(ctx, authpkg.PurposeEmailVerification, params.Token)
+	if err != nil {
+		if errors.Is(err, internal.ErrInvalidToken) || errors.Is(err, internal.ErrInvalidSignature) {
+			return internal.NewValidationError("Verification link is invalid or has expired")
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return internal.NewNotFoundError("User")
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// ResendVerificationEmail sends a new verification link, which replaces the previous one.
+// Requests are limited by a cooldown and a maximum per window for each user.
+func (s *Service) ResendVerificationEmail(ctx context.Context, userID int64) error {
+	userDM, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return internal.NewNotFoundError("User")
+		}
+		return internal.NewInternalServerError(err)
+	}
+
+	if userDM.EmailVerified {
+		return internal.NewBusinessRuleError("Email is already verified", internal.ErrInvalidState)
+	}
+
+	cfg := s.emailVerification
+	if err := s.allow(ctx, fmt.Sprintf("verify_email_resend:cooldown:%d", userID), 1, cfg.ResendCooldown); err != nil {
+		return err
+	}
+	if err := s.allow(ctx, fmt.Sprintf("verify_email_resend:user:%d", userID), cfg.MaxResends, cfg.ResendWindow); err != nil {
+		return err
+	}
+
+	if err := s.sendVerificationEmail(ctx, userDM); err != nil {
+		return internal.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// sendVerificationEmailAfterRegistration sends the first verification link. A failure does not
+// fail the registration, the user can request a new link.
+func (s *Service) sendVerificationEmailAfterRegistration(ctx context.Context, userDM *datamodel.User) {
+	if err := s.sendVerificationEmail(ctx, userDM); err != nil {
+		slog.WarnContext(ctx, "Failed to send verification email after registration",
+			slog.Int64("user_id", userDM.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) sendVerificationEmail(ctx context.Context, userDM *datamodel.User) error {
+	token, err := s.verificationTokens.Issue(ctx, authpkg.PurposeEmailVerification, userDM.ID, s.emailVerification.TokenTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.appURL + "/verify-email?token=" + url.QueryEscape(token)
+	return s.sender.Send(ctx, &notification.Message{
+		To:      userDM.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s. If you did not create an account, you can ignore this email.",
+			userDM.FullName, link, formatTTL(s.emailVerification.TokenTTL)),
+	})
+}
+
+// allow records an attempt against the limit and rejects it once the limit is reached
+func (s *Service) allow(ctx context.Context, key string, limit int, window time.Duration) error {
+	allowed, retryAfter, err := s.rateLimiter.Allow(ctx, key, limit, window)
+	if err != nil {
+		return internal.NewInternalServerError(err)
+	}
+	if !allowed {
+		return internal.NewRateLimitError("Too many requests, please try again later").
+			WithDetail("retry_after_seconds", int(math.Ceil(retryAfter.Seconds())))
+	}
+	return nil
+}
+
+// formatTTL renders a validity period for users, in whole hours or minutes
+func formatTTL(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		hours := int(d / time.Hour)
+		if hours == 1 {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", hours)
+	}
+	minutes := int(math.Ceil(d.Minutes()))
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
@@ -110,6 +110,8 @@ func DebugContext(ctx context.Context, msg string, args ...any) {
 var (
 	sensitiveDataField = []string{
 		"password",
+		"new_password",
+		"current_password",
 		"client_secret",
 		"token",
 		"access_token",